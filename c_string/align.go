@@ -0,0 +1,155 @@
+package c_string
+
+import (
+	"strings"
+
+	"github.com/gdamore/tcell"
+)
+
+// Alignment is how the lines of a page are aligned.
+type Alignment int
+
+const (
+	// AlignLeft leaves the lines as they are.
+	AlignLeft Alignment = iota
+
+	// AlignRight pads the lines on the left so that they end at the width.
+	AlignRight
+
+	// AlignCenter pads the lines on the left so that they are centered
+	// within the width. The extra column, if any, is on the right.
+	AlignCenter
+
+	// AlignJustify widens the spaces between the words of the lines that
+	// were wrapped so that they end at the width. The other lines, such as
+	// the last line of a paragraph, are left as they are.
+	AlignJustify
+)
+
+// String implements the fmt.Stringer interface.
+func (a Alignment) String() string {
+	switch a {
+	case AlignLeft:
+		return "left"
+	case AlignRight:
+		return "right"
+	case AlignCenter:
+		return "center"
+	case AlignJustify:
+		return "justify"
+	default:
+		return "unknown"
+	}
+}
+
+// alignLine aligns a line within a width.
+//
+// Parameters:
+//   - line: The words of the line. It is not modified.
+//   - width: The number of columns to align the line within.
+//   - align: How the line is aligned.
+//   - wrapped: Whether the last word of the line was wrapped onto the next line.
+//
+// Returns:
+//   - [][]*Unit: The aligned line. The line itself if there is nothing to do.
+func alignLine(line [][]*Unit, width int, align Alignment, wrapped bool) [][]*Unit {
+	if len(line) == 0 {
+		return line
+	}
+
+	extra := width - lineWidth(line)
+	if extra <= 0 {
+		return line
+	}
+
+	switch align {
+	case AlignRight:
+		return padLeft(line, extra)
+	case AlignCenter:
+		return padLeft(line, extra/2)
+	case AlignJustify:
+		if !wrapped || len(line) < 2 {
+			return line
+		}
+
+		gaps := len(line) - 1
+
+		aligned := make([][]*Unit, 0, len(line))
+
+		for i, word := range line {
+			if i < gaps {
+				n := extra / gaps
+				if i < extra%gaps {
+					n++
+				}
+
+				word = append(word[:len(word):len(word)], NewUnit(strings.Repeat(" ", n), tcell.StyleDefault))
+			}
+
+			aligned = append(aligned, word)
+		}
+
+		return aligned
+	default:
+		return line
+	}
+}
+
+// padLeft adds spaces at the start of a line.
+//
+// Parameters:
+//   - line: The words of the line. Must not be empty. It is not modified.
+//   - n: The number of spaces.
+//
+// Returns:
+//   - [][]*Unit: The padded line.
+func padLeft(line [][]*Unit, n int) [][]*Unit {
+	if n <= 0 {
+		return line
+	}
+
+	first := make([]*Unit, 0, len(line[0])+1)
+	first = append(first, NewUnit(strings.Repeat(" ", n), tcell.StyleDefault))
+	first = append(first, line[0]...)
+
+	padded := make([][]*Unit, 0, len(line))
+	padded = append(padded, first)
+	padded = append(padded, line[1:]...)
+
+	return padded
+}
+
+// alignedLines returns the lines of the section, reordered for the direction
+// of the text and aligned as configured.
+//
+// Parameters:
+//   - fallback: The number of columns to align the lines within when the
+//     section has no width.
+//
+// Returns:
+//   - [][][]*Unit: The aligned lines.
+func (sb *sectionBuilder) alignedLines(fallback int) [][][]*Unit {
+	sb.mu.RLock()
+	defer sb.mu.RUnlock()
+
+	if sb.layout.align == AlignLeft && sb.layout.direction == DirectionNone {
+		return sb.lines
+	}
+
+	width := sb.layout.width
+	if width <= 0 {
+		width = fallback
+	}
+
+	lines := make([][][]*Unit, 0, len(sb.lines))
+
+	for i, line := range sb.lines {
+		if sb.layout.direction != DirectionNone {
+			line = reorderLine(line, sb.layout.direction)
+		}
+
+		lines = append(lines, alignLine(line, width, sb.layout.align, sb.wrapped[i]))
+	}
+
+	return lines
+}
@@ -0,0 +1,235 @@
+package c_string
+
+import (
+	"io"
+	"strconv"
+	"strings"
+
+	gcers "github.com/PlayerR9/go-errors"
+	"github.com/gdamore/tcell"
+)
+
+// SGRReset is the escape code that resets all the attributes.
+const SGRReset string = "\x1b[0m"
+
+// osc8 returns the OSC 8 escape code that starts or ends a hyperlink.
+//
+// Parameters:
+//   - url: The target of the hyperlink. Empty to end the hyperlink.
+//
+// Returns:
+//   - string: The escape code.
+//
+// Behaviors:
+//   - The bytes of the URL outside of printable ASCII, such as ESC or BEL,
+//     are percent-encoded so that they cannot end the escape code early.
+func osc8(url string) string {
+	var builder strings.Builder
+
+	builder.WriteString("\x1b]8;;")
+
+	for i := 0; i < len(url); i++ {
+		c := url[i]
+
+		if c < 0x20 || c > 0x7e {
+			builder.WriteByte('%')
+			builder.WriteByte("0123456789ABCDEF"[c>>4])
+			builder.WriteByte("0123456789ABCDEF"[c&0xf])
+		} else {
+			builder.WriteByte(c)
+		}
+	}
+
+	builder.WriteString("\x1b\\")
+
+	return builder.String()
+}
+
+// sgrAttrs maps the attributes of tcell to their SGR codes.
+var sgrAttrs = []struct {
+	attr tcell.AttrMask
+	code string
+}{
+	{tcell.AttrBold, "1"},
+	{tcell.AttrDim, "2"},
+	{tcell.AttrItalic, "3"},
+	{tcell.AttrUnderline, "4"},
+	{tcell.AttrBlink, "5"},
+	{tcell.AttrReverse, "7"},
+}
+
+// sgrColor returns the SGR parameters that select a color.
+//
+// Parameters:
+//   - c: The color.
+//   - base: 30 for the foreground, 40 for the background.
+//
+// Returns:
+//   - string: The SGR parameters. Empty if the color is the default one.
+func sgrColor(c tcell.Color, base int) string {
+	switch {
+	case c == tcell.ColorDefault:
+		return ""
+	case c&tcell.ColorIsRGB == 0 && c < 8:
+		return strconv.Itoa(base + int(c))
+	case c&tcell.ColorIsRGB == 0 && c < 16:
+		return strconv.Itoa(base + 60 + int(c) - 8)
+	case c&tcell.ColorIsRGB == 0 && c < 256:
+		return strconv.Itoa(base+8) + ";5;" + strconv.Itoa(int(c))
+	}
+
+	r, g, b := c.RGB()
+	if r < 0 {
+		return ""
+	}
+
+	return strconv.Itoa(base+8) + ";2;" + strconv.Itoa(int(r)) + ";" + strconv.Itoa(int(g)) + ";" + strconv.Itoa(int(b))
+}
+
+// SGR returns the escape code that selects a style, starting from the
+// default one. Colors are written with the 16-color codes when they are
+// among the first 16, and with the 256-color or true-color codes otherwise.
+//
+// Parameters:
+//   - style: The style.
+//
+// Returns:
+//   - string: The escape code. Empty if the style is the default one.
+func SGR(style tcell.Style) string {
+	fg, bg, attrs := style.Decompose()
+
+	var params []string
+
+	for _, a := range sgrAttrs {
+		if attrs&a.attr != 0 {
+			params = append(params, a.code)
+		}
+	}
+
+	if code := sgrColor(fg, 30); code != "" {
+		params = append(params, code)
+	}
+
+	if code := sgrColor(bg, 40); code != "" {
+		params = append(params, code)
+	}
+
+	if len(params) == 0 {
+		return ""
+	}
+
+	return "\x1b[" + strings.Join(params, ";") + "m"
+}
+
+// renderLine renders a line of words, separated by one space.
+//
+// Parameters:
+//   - line: The words of the line. Nil units are skipped.
+//   - ansi: Whether the styles are converted to SGR escape codes and the
+//     hyperlinks to OSC 8 escape codes. If so, the attributes are reset and
+//     the hyperlink is ended at the end of the line.
+//
+// Returns:
+//   - string: The line, without a trailing newline.
+func renderLine(line [][]*Unit, ansi bool) string {
+	var builder strings.Builder
+
+	current := tcell.StyleDefault
+	currentURL := ""
+
+	write := func(content string, style tcell.Style, url string) {
+		if ansi && url != currentURL {
+			builder.WriteString(osc8(url))
+
+			currentURL = url
+		}
+
+		if ansi && style != current {
+			if current != tcell.StyleDefault {
+				builder.WriteString(SGRReset)
+			}
+
+			builder.WriteString(SGR(style))
+
+			current = style
+		}
+
+		builder.WriteString(content)
+	}
+
+	for i, word := range line {
+		if i > 0 {
+			write(" ", tcell.StyleDefault, "")
+		}
+
+		for _, unit := range word {
+			if unit != nil {
+				write(unit.Content, unit.Style, unit.URL)
+			}
+		}
+	}
+
+	if currentURL != "" {
+		builder.WriteString(osc8(""))
+	}
+
+	if current != tcell.StyleDefault {
+		builder.WriteString(SGRReset)
+	}
+
+	return builder.String()
+}
+
+// Strings returns the pages of the printer as plain text. Styles are dropped
+// and the words of a line are separated by one space.
+//
+// Returns:
+//   - [][]string: The lines of every page.
+//
+// Behaviors:
+//   - Like GetPages, the printer is reset afterwards.
+func (p *Printer) Strings() [][]string {
+	pages := p.GetPages()
+
+	result := make([][]string, 0, len(pages))
+
+	for _, page := range pages {
+		var lines []string
+
+		for _, section := range page {
+			for _, line := range section {
+				lines = append(lines, renderLine(line, false))
+			}
+		}
+
+		result = append(result, lines)
+	}
+
+	return result
+}
+
+// RenderANSI writes the pages of the printer to a writer, with the styles
+// converted to SGR escape codes so that they can be printed to any terminal
+// without tcell. Hyperlinks are converted to OSC 8 escape codes, which
+// terminals that do not support them ignore. Every line ends with a newline and pages are separated by an
+// empty line, as in Draw.
+//
+// Parameters:
+//   - w: The writer to write to.
+//
+// Returns:
+//   - error: An error of type *errors.ErrNilParameter if w is nil, or the
+//     error of the writer, if any.
+//
+// Behaviors:
+//   - Like GetPages, the printer is reset afterwards, unless w is nil.
+//   - The attributes are reset at the end of every line that changed them, so
+//     that the lines can be printed independently.
+func (p *Printer) RenderANSI(w io.Writer) error {
+	if w == nil {
+		return gcers.NewErrNilParameter("w")
+	}
+
+	_, err := writePages(w, p.GetPages(), true)
+	return err
+}
@@ -0,0 +1,313 @@
+package c_string
+
+import (
+	"unicode"
+
+	"github.com/mattn/go-runewidth"
+)
+
+// Direction is the direction of the text of a document, used to reorder the
+// lines that mix left-to-right and right-to-left text, such as English and
+// Hebrew or Arabic, into the order in which a terminal displays them.
+type Direction int
+
+const (
+	// DirectionNone leaves the lines in logical order.
+	DirectionNone Direction = iota
+
+	// DirectionLTR reorders the lines of a left-to-right document.
+	DirectionLTR
+
+	// DirectionRTL reorders the lines of a right-to-left document.
+	DirectionRTL
+
+	// DirectionAuto reorders every line as a left-to-right or right-to-left
+	// line, depending on its first letter.
+	DirectionAuto
+)
+
+// String implements the fmt.Stringer interface.
+func (d Direction) String() string {
+	switch d {
+	case DirectionNone:
+		return "none"
+	case DirectionLTR:
+		return "ltr"
+	case DirectionRTL:
+		return "rtl"
+	case DirectionAuto:
+		return "auto"
+	default:
+		return "unknown"
+	}
+}
+
+// bidiClass is the simplified bidirectional class of a rune.
+type bidiClass int
+
+const (
+	// bidiNeutral is a space or a punctuation mark.
+	bidiNeutral bidiClass = iota
+
+	// bidiLeft is a left-to-right letter.
+	bidiLeft
+
+	// bidiRight is a right-to-left letter.
+	bidiRight
+
+	// bidiNumber is a digit.
+	bidiNumber
+)
+
+// rightToLeft are the scripts written from right to left.
+var rightToLeft []*unicode.RangeTable = []*unicode.RangeTable{
+	unicode.Hebrew, unicode.Arabic, unicode.Syriac, unicode.Thaana, unicode.Nko,
+	unicode.Samaritan, unicode.Mandaic,
+}
+
+// classOf returns the bidirectional class of a rune.
+//
+// Parameters:
+//   - r: The rune.
+//
+// Returns:
+//   - bidiClass: The class of the rune.
+func classOf(r rune) bidiClass {
+	switch {
+	case unicode.IsDigit(r):
+		return bidiNumber
+	case unicode.In(r, rightToLeft...):
+		if unicode.IsLetter(r) {
+			return bidiRight
+		}
+
+		return bidiNeutral
+	case unicode.IsLetter(r):
+		return bidiLeft
+	default:
+		return bidiNeutral
+	}
+}
+
+// mirrors are the runes that are mirrored in right-to-left text.
+var mirrors map[rune]rune = map[rune]rune{
+	'(': ')', ')': '(',
+	'[': ']', ']': '[',
+	'{': '}', '}': '{',
+	'<': '>', '>': '<',
+	'«': '»', '»': '«',
+}
+
+// bidiCluster is a rune of a line with the zero-width runes that follow it,
+// such as combining marks, which are reordered with it.
+type bidiCluster struct {
+	// runes are the runes of the cluster.
+	runes []rune
+
+	// unit is the unit the runes come from; it gives their style and URL.
+	unit *Unit
+
+	// class is the class of the cluster.
+	class bidiClass
+
+	// level is the embedding level of the cluster. Odd levels are displayed
+	// from right to left.
+	level int
+}
+
+// reorderLine reorders a line from logical order into display order with a
+// simplified version of the Unicode Bidirectional Algorithm, without
+// explicit embeddings.
+//
+// Parameters:
+//   - line: The words of the line. It is not modified.
+//   - dir: The direction of the document. Must not be DirectionNone.
+//
+// Returns:
+//   - [][]*Unit: The reordered line. The line itself if it has no
+//     right-to-left text and is displayed from left to right; otherwise, a
+//     single word.
+func reorderLine(line [][]*Unit, dir Direction) [][]*Unit {
+	var clusters []*bidiCluster
+
+	hasRight := false
+
+	for _, unit := range joinWords(line) {
+		for _, r := range unit.Content {
+			if len(clusters) > 0 && r != '\t' && runewidth.RuneWidth(r) == 0 {
+				last := clusters[len(clusters)-1]
+				last.runes = append(last.runes, r)
+
+				continue
+			}
+
+			class := classOf(r)
+			if class == bidiRight {
+				hasRight = true
+			}
+
+			clusters = append(clusters, &bidiCluster{
+				runes: []rune{r},
+				unit:  unit,
+				class: class,
+			})
+		}
+	}
+
+	base := 0
+
+	switch dir {
+	case DirectionRTL:
+		base = 1
+	case DirectionAuto:
+		for _, c := range clusters {
+			if c.class == bidiRight {
+				base = 1
+			}
+
+			if c.class != bidiNeutral && c.class != bidiNumber {
+				break
+			}
+		}
+	}
+
+	if len(clusters) == 0 || (base == 0 && !hasRight) {
+		return line
+	}
+
+	// Digits that follow left-to-right text are left-to-right text.
+	last := bidiLeft
+	if base%2 == 1 {
+		last = bidiRight
+	}
+
+	for _, c := range clusters {
+		switch c.class {
+		case bidiLeft, bidiRight:
+			last = c.class
+		case bidiNumber:
+			if last == bidiLeft {
+				c.class = bidiLeft
+			}
+		}
+	}
+
+	resolveNeutrals(clusters, base)
+
+	for _, c := range clusters {
+		switch {
+		case c.class == bidiNumber:
+			c.level = base + 2 - base%2
+		case c.class == bidiRight && base%2 == 0, c.class == bidiLeft && base%2 == 1:
+			c.level = base + 1
+		default:
+			c.level = base
+		}
+	}
+
+	// Trailing spaces take the level of the line.
+	for i := len(clusters) - 1; i >= 0 && clusters[i].runes[0] == ' '; i-- {
+		clusters[i].level = base
+	}
+
+	maxLevel := 0
+
+	for _, c := range clusters {
+		maxLevel = max(maxLevel, c.level)
+	}
+
+	// Reverse every run at the level or higher, from the highest level to the
+	// lowest odd one.
+	for level := maxLevel; level >= 1; level-- {
+		for i := 0; i < len(clusters); {
+			if clusters[i].level < level {
+				i++
+				continue
+			}
+
+			j := i
+
+			for j < len(clusters) && clusters[j].level >= level {
+				j++
+			}
+
+			for a, b := i, j-1; a < b; a, b = a+1, b-1 {
+				clusters[a], clusters[b] = clusters[b], clusters[a]
+			}
+
+			i = j
+		}
+	}
+
+	units := make([]*Unit, 0, len(clusters))
+
+	for _, c := range clusters {
+		runes := c.runes
+
+		if c.level%2 == 1 {
+			if m, ok := mirrors[runes[0]]; ok {
+				runes = append([]rune{m}, runes[1:]...)
+			}
+		}
+
+		units = append(units, NewLinkUnit(string(runes), c.unit.URL, c.unit.Style))
+	}
+
+	return [][]*Unit{ReduceUnitSequence(units)}
+}
+
+// resolveNeutrals gives a direction to the neutral runes: the direction of
+// the text around them if it is the same on both sides, and the direction of
+// the line otherwise. Digits count as right-to-left text on either side.
+//
+// Parameters:
+//   - clusters: The clusters of the line. Their class is updated.
+//   - base: The level of the line.
+func resolveNeutrals(clusters []*bidiCluster, base int) {
+	baseClass := bidiLeft
+	if base%2 == 1 {
+		baseClass = bidiRight
+	}
+
+	strong := func(class bidiClass) bidiClass {
+		if class == bidiNumber {
+			return bidiRight
+		}
+
+		return class
+	}
+
+	for i := 0; i < len(clusters); {
+		if clusters[i].class != bidiNeutral {
+			i++
+			continue
+		}
+
+		j := i
+
+		for j < len(clusters) && clusters[j].class == bidiNeutral {
+			j++
+		}
+
+		before, after := baseClass, baseClass
+
+		if i > 0 {
+			before = strong(clusters[i-1].class)
+		}
+
+		if j < len(clusters) {
+			after = strong(clusters[j].class)
+		}
+
+		class := baseClass
+		if before == after {
+			class = before
+		}
+
+		for k := i; k < j; k++ {
+			clusters[k].class = class
+		}
+
+		i = j
+	}
+}
@@ -0,0 +1,857 @@
+package c_string
+
+import (
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/gdamore/tcell"
+)
+
+// checkString is a private function that checks a string for invalid runes.
+//
+// Parameters:
+//   - str: The string to check.
+//
+// Returns:
+//   - int: The index of the invalid rune. -1 if no invalid runes are found.
+func checkString(str string) int {
+	for j := 0; len(str) > 0; j++ {
+		char, size := utf8.DecodeRuneInString(str)
+		str = str[size:]
+
+		if char == utf8.RuneError {
+			return j
+		}
+
+		if char == '\r' && size != 0 {
+			nextRune, size := utf8.DecodeRuneInString(str)
+
+			if nextRune == '\n' {
+				str = str[size:]
+			}
+		}
+	}
+
+	return -1
+}
+
+// unitBuffer is a type that represents a buffer of units.
+type unitBuffer struct {
+	// units are the units of the buffer.
+	units []*Unit
+}
+
+// Cleanup implements the Cleanup interface method.
+func (ub *unitBuffer) Cleanup() {
+	for i := 0; i < len(ub.units); i++ {
+		ub.units[i] = nil
+	}
+
+	ub.units = nil
+}
+
+// Len is a function that returns the number of units in the buffer.
+//
+// Returns:
+//   - int: The number of units in the buffer.
+func (ub *unitBuffer) Len() int {
+	return len(ub.units)
+}
+
+// removeLast is a function that removes the last unit from the buffer.
+//
+// Returns:
+//   - bool: True if a unit was removed. False otherwise.
+func (ub *unitBuffer) removeLast() bool {
+	for i := len(ub.units) - 1; i >= 0; i-- {
+		ok := ub.units[i].removeLastRune()
+		if ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// getUnits is a function that returns the units of the buffer.
+//
+// Returns:
+//   - []*Unit: The units of the buffer.
+func (ub *unitBuffer) getUnits() []*Unit {
+	return ub.units
+}
+
+// take is a function that returns the units of the buffer and empties it.
+// Unlike Cleanup, the returned units are left untouched.
+//
+// Returns:
+//   - []*Unit: The units of the buffer.
+func (ub *unitBuffer) take() []*Unit {
+	units := ub.units
+	ub.units = nil
+
+	return units
+}
+
+// WriteString is a function that adds a string to the buffer.
+//
+// Parameters:
+//   - str: The string to add.
+//   - style: The style of the string.
+func (ub *unitBuffer) WriteString(str string, style tcell.Style) {
+	ub.writeUnit(NewUnit(str, style))
+}
+
+// writeUnit is a function that adds a unit to the buffer.
+//
+// Parameters:
+//   - newUnit: The unit to add. It is merged with the last unit if possible,
+//     so it must not be shared.
+func (ub *unitBuffer) writeUnit(newUnit *Unit) {
+	if len(ub.units) == 0 {
+		ub.units = append(ub.units, newUnit)
+	} else {
+		ok := ub.units[len(ub.units)-1].Merge(newUnit)
+		if !ok {
+			ub.units = append(ub.units, newUnit)
+		}
+	}
+}
+
+// layout is a type that represents how the lines of a section are laid out.
+type layout struct {
+	// width is the number of columns after which words are wrapped onto a new
+	// line. 0 if words are never wrapped.
+	width int
+
+	// align is how the lines are aligned once the pages are finalized.
+	align Alignment
+
+	// direction is how the lines are reordered once the pages are finalized,
+	// before they are aligned.
+	direction Direction
+}
+
+// sectionBuilder is a type that represents a section of a page.
+type sectionBuilder struct {
+	// buff is the string buff for the section.
+	buff *unitBuffer
+
+	// lines are the lines in the section.
+	lines [][][]*Unit
+
+	// lastLine is the last line of the section.
+	lastLine int
+
+	// layout is how the lines of the section are laid out.
+	layout layout
+
+	// wrapped tells, for every line, whether its last word was wrapped onto
+	// the next line.
+	wrapped []bool
+
+	// indent is the indentation written at the start of the last line. It is
+	// repeated at the start of the wrapped lines.
+	indent []*Unit
+
+	// suffix is written at the end of the last line and of the lines its
+	// words are wrapped onto.
+	suffix []*Unit
+
+	// mu is the mutex for the builder.
+	mu sync.RWMutex
+}
+
+// Cleanup implements the Cleanup interface method.
+func (sb *sectionBuilder) Cleanup() {
+	sb.mu.Lock()
+	defer sb.mu.Unlock()
+
+	for i := 0; i < len(sb.lines); i++ {
+		sb.lines[i] = nil
+	}
+
+	sb.lines = nil
+	sb.wrapped = nil
+
+	sb.buff.Cleanup()
+}
+
+// newSectionBuilder creates a new section.
+//
+// Parameters:
+//   - l: How the lines of the section are laid out.
+//
+// Returns:
+//   - *Section: The new section.
+func newSectionBuilder(l layout) *sectionBuilder {
+	return &sectionBuilder{
+		buff:     &unitBuffer{},
+		lines:    [][][]*Unit{{}},
+		lastLine: 0,
+		layout:   l,
+		wrapped:  []bool{false},
+	}
+}
+
+// newSectionFromLines creates a new section with the given lines.
+//
+// Parameters:
+//   - lines: The lines of the section. Must not be empty.
+//   - wrapped: Whether the last word of each line was wrapped.
+//   - l: How the lines of the section are laid out.
+//
+// Returns:
+//   - *sectionBuilder: The new section.
+func newSectionFromLines(lines [][][]*Unit, wrapped []bool, l layout) *sectionBuilder {
+	return &sectionBuilder{
+		buff:     &unitBuffer{},
+		lines:    lines,
+		lastLine: len(lines) - 1,
+		layout:   l,
+		wrapped:  wrapped,
+	}
+}
+
+// setLayout is a function that changes how the lines of the section are laid
+// out. The lines already accepted are not wrapped again.
+//
+// Parameters:
+//   - l: The new layout.
+func (sb *sectionBuilder) setLayout(l layout) {
+	sb.mu.Lock()
+	defer sb.mu.Unlock()
+
+	sb.layout = l
+}
+
+// appendWord is a function that appends a word to the last line. If the word
+// does not fit in the width, it is wrapped onto a new line that starts with
+// the indentation of the last one. The caller must hold the lock.
+//
+// Parameters:
+//   - word: The word to append.
+//
+// Behaviors:
+//   - A word that is wider than the width on its own is not broken; it takes
+//     a line of its own.
+func (sb *sectionBuilder) appendWord(word []*Unit) {
+	line := sb.lines[sb.lastLine]
+
+	reserved := 0
+	if len(sb.suffix) > 0 {
+		reserved = 1 + wordColumn(0, sb.suffix)
+	}
+
+	if sb.layout.width > 0 && len(line) > 0 && wordColumn(lineWidth(line)+1, word)+reserved > sb.layout.width {
+		sb.endLine()
+
+		sb.wrapped[sb.lastLine] = true
+
+		sb.lines = append(sb.lines, [][]*Unit{})
+		sb.wrapped = append(sb.wrapped, false)
+		sb.lastLine++
+
+		if len(sb.indent) > 0 {
+			wrapped := make([]*Unit, 0, len(sb.indent)+len(word))
+
+			for _, unit := range sb.indent {
+				wrapped = append(wrapped, unit.Copy())
+			}
+
+			word = append(wrapped, word...)
+		}
+	}
+
+	sb.lines[sb.lastLine] = append(sb.lines[sb.lastLine], word)
+}
+
+// split is a function that moves the complete lines of the section, all but
+// the last one, to a new section.
+//
+// Returns:
+//   - *sectionBuilder: The new section.
+//   - bool: True if there were complete lines, false otherwise.
+func (sb *sectionBuilder) split() (*sectionBuilder, bool) {
+	sb.mu.Lock()
+	defer sb.mu.Unlock()
+
+	if sb.lastLine == 0 {
+		return nil, false
+	}
+
+	section := newSectionFromLines(sb.lines[:sb.lastLine:sb.lastLine], sb.wrapped[:sb.lastLine:sb.lastLine], sb.layout)
+
+	sb.lines = sb.lines[sb.lastLine:]
+	sb.wrapped = sb.wrapped[sb.lastLine:]
+	sb.lastLine = 0
+
+	return section, true
+}
+
+// endLine is a function that writes the suffix, if any, at the end of the
+// last line. The caller must hold the lock.
+func (sb *sectionBuilder) endLine() {
+	if len(sb.suffix) == 0 {
+		return
+	}
+
+	suffix := make([]*Unit, 0, len(sb.suffix))
+
+	for _, unit := range sb.suffix {
+		suffix = append(suffix, unit.Copy())
+	}
+
+	sb.lines[sb.lastLine] = append(sb.lines[sb.lastLine], suffix)
+}
+
+// removeOne is a function that removes the last character from the section.
+//
+// Returns:
+//   - bool: True if a character was removed. False otherwise.
+func (sb *sectionBuilder) removeOne() bool {
+	sb.mu.Lock()
+	defer sb.mu.Unlock()
+
+	ok := sb.buff.removeLast()
+	if ok {
+		return true
+	}
+
+	for i := sb.lastLine; i >= 0; i-- {
+		words := sb.lines[i]
+
+		for j := len(words) - 1; j >= 0; j-- {
+			if len(words[j]) > 0 {
+				words[j] = words[j][:len(words[j])-1]
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// getLines is a function that returns the words of the section.
+//
+// Returns:
+//   - [][]string: The words of the section.
+func (sb *sectionBuilder) getLines() [][][]*Unit {
+	sb.mu.RLock()
+	defer sb.mu.RUnlock()
+
+	return sb.lines
+}
+
+// isFirstOfLine is a function that returns true if the current position is the first
+// position of a line.
+//
+// Returns:
+//   - bool: True if the current position is the first position of a line.
+func (sb *sectionBuilder) isFirstOfLine() bool {
+	sb.mu.RLock()
+	defer sb.mu.RUnlock()
+
+	return sb.buff.Len() == 0 && len(sb.lines[sb.lastLine]) == 0
+}
+
+// accept is a function that accepts the current word and
+// creates a new line.
+func (sb *sectionBuilder) accept() {
+	sb.mu.Lock()
+	defer sb.mu.Unlock()
+
+	if sb.buff.Len() > 0 {
+		sb.appendWord(sb.buff.take())
+	}
+
+	sb.endLine()
+
+	sb.lines = append(sb.lines, [][]*Unit{})
+	sb.wrapped = append(sb.wrapped, false)
+	sb.lastLine++
+
+	sb.indent = nil
+	sb.suffix = nil
+}
+
+// close is a function that accepts the current word and ends the last line
+// without creating a new one, as the section is about to be added to a page.
+// The empty line left open by accept, if any, is dropped, so that it is
+// neither printed nor counted against the height of the page.
+func (sb *sectionBuilder) close() {
+	sb.mu.Lock()
+	defer sb.mu.Unlock()
+
+	if sb.buff.Len() > 0 {
+		sb.appendWord(sb.buff.take())
+	}
+
+	if sb.lastLine > 0 && len(sb.lines[sb.lastLine]) == 0 {
+		sb.lines = sb.lines[:sb.lastLine]
+		sb.wrapped = sb.wrapped[:sb.lastLine]
+		sb.lastLine--
+	} else {
+		sb.endLine()
+	}
+
+	sb.indent = nil
+	sb.suffix = nil
+}
+
+// acceptWord is a function that accepts the current in-progress word
+// and resets the builder.
+func (sb *sectionBuilder) acceptWord() {
+	sb.mu.Lock()
+	defer sb.mu.Unlock()
+
+	if sb.buff.Len() == 0 {
+		return
+	}
+
+	sb.appendWord(sb.buff.take())
+}
+
+// writeString adds a string to the current, in-progress word.
+//
+// Parameters:
+//   - str: The string to write.
+//   - style: The style of the string.
+func (sb *sectionBuilder) writeString(str string, style tcell.Style) {
+	sb.mu.Lock()
+	defer sb.mu.Unlock()
+
+	sb.buff.WriteString(str, style)
+}
+
+// writeUnit adds a unit to the current, in-progress word.
+//
+// Parameters:
+//   - unit: The unit to write. It is copied.
+func (sb *sectionBuilder) writeUnit(unit *Unit) {
+	sb.mu.Lock()
+	defer sb.mu.Unlock()
+
+	sb.buff.writeUnit(unit.Copy())
+}
+
+// writeIndent adds the indentation of the line to the current, in-progress
+// word.
+//
+// Parameters:
+//   - units: The units to write. They are copied, since the last one may be
+//     merged with what is written next.
+//   - suffix: The units to write at the end of the line. Nil if there are none.
+func (sb *sectionBuilder) writeIndent(units, suffix []*Unit) {
+	sb.mu.Lock()
+	defer sb.mu.Unlock()
+
+	sb.indent = units
+	sb.suffix = suffix
+
+	for i := 0; i < len(units); i++ {
+		sb.buff.units = append(sb.buff.units, units[i].Copy())
+	}
+}
+
+// buffer is a type that represents a buffer of a document.
+type buffer struct {
+	// pages are the pages of the buffer.
+	pages [][]*sectionBuilder
+
+	// buff is the in-progress section of the buffer.
+	buff *sectionBuilder
+
+	// lastPage is the last page of the buffer.
+	lastPage int
+
+	// layout is how the lines of the sections are laid out.
+	layout layout
+
+	// height is the number of lines after which a new page is started. 0 if
+	// pages are only started by form feeds.
+	height int
+
+	// onLine is called every time a line is accepted, once the buffer is
+	// ready for the next one. Nil if there is nothing to call.
+	onLine func()
+
+	// consumed is the number of lines of the last page that were taken by
+	// popSection. They still count towards the height of the page.
+	consumed int
+}
+
+// Cleanup implements the Cleanup interface method.
+func (b *buffer) Cleanup() {
+	// pages are the pages of the buffer.
+	for i := 0; i < len(b.pages); i++ {
+		for j := 0; j < len(b.pages[i]); j++ {
+			b.pages[i][j].Cleanup()
+			b.pages[i][j] = nil
+		}
+
+		b.pages[i] = nil
+	}
+
+	b.pages = nil
+
+	b.buff.Cleanup()
+	b.buff = nil
+}
+
+// newBuffer creates a new buffer.
+//
+// Returns:
+//   - *buffer: The new buffer.
+func newBuffer() *buffer {
+	return &buffer{
+		pages:    [][]*sectionBuilder{{}},
+		buff:     nil,
+		lastPage: 0,
+	}
+}
+
+// reset is a private function that empties the buffer. Unlike Cleanup, the
+// buffer can still be written to, and the pages are left untouched.
+func (b *buffer) reset() {
+	b.pages = [][]*sectionBuilder{{}}
+	b.buff = nil
+	b.lastPage = 0
+	b.consumed = 0
+}
+
+// popSection is a private function that removes the first complete section
+// of the buffer. When there is none, the complete lines of the in-progress
+// section become one.
+//
+// Returns:
+//   - *sectionBuilder: The section.
+//   - bool: True if there was a section, false otherwise.
+//
+// Behaviors:
+//   - Pages whose sections were all removed are removed too, except the last
+//     one.
+func (b *buffer) popSection() (*sectionBuilder, bool) {
+	for {
+		if len(b.pages[0]) > 0 {
+			section := b.pages[0][0]
+			b.pages[0] = b.pages[0][1:]
+
+			if b.lastPage == 0 {
+				b.consumed += len(section.getLines())
+			}
+
+			return section, true
+		}
+
+		if b.lastPage > 0 {
+			b.pages = b.pages[1:]
+			b.lastPage--
+
+			continue
+		}
+
+		if b.buff == nil {
+			return nil, false
+		}
+
+		section, ok := b.buff.split()
+		if !ok {
+			return nil, false
+		}
+
+		b.addSection(section)
+	}
+}
+
+// lineAccepted is a private function that calls onLine, if any.
+func (b *buffer) lineAccepted() {
+	if b.onLine != nil {
+		b.onLine()
+	}
+}
+
+// isFirstOfLine is a private function that returns true if the current position is the first
+// position of a line.
+//
+// Returns:
+//   - bool: True if the current position is the first position of a line.
+func (b *buffer) isFirstOfLine() bool {
+	return b.buff == nil || b.buff.isFirstOfLine()
+}
+
+// writeIndent is a private function that writes the indentation to the formatted string.
+//
+// Parameters:
+//   - units: The units of the indentation.
+//   - suffix: The units to write at the end of the line. Nil if there are none.
+func (b *buffer) writeIndent(units, suffix []*Unit) {
+	if len(units) == 0 && len(suffix) == 0 {
+		return
+	}
+
+	if b.buff == nil {
+		b.buff = newSectionBuilder(b.layout)
+	}
+
+	b.buff.writeIndent(units, suffix)
+}
+
+// writeUnits is a private function that appends units to the current word
+// as they are. Unlike writeString, spaces never separate words, so the units
+// are never wrapped apart.
+//
+// Parameters:
+//   - units: The units to append. Nil units are skipped.
+func (b *buffer) writeUnits(units []*Unit) {
+	if b.buff == nil {
+		b.buff = newSectionBuilder(b.layout)
+	}
+
+	for _, unit := range units {
+		if unit != nil {
+			b.buff.writeUnit(unit)
+		}
+	}
+}
+
+// Accept is a function that accepts the current in-progress buffer
+// by converting it to the specified section type. Lastly, the section
+// is added to the page.
+//
+// Parameters:
+//   - sectionType: The section type to convert the buffer to.
+//
+// Behaviors:
+//   - Even when the buffer is empty, the section is still added to the page.
+//     To avoid this, use the Finalize function.
+func (b *buffer) accept() {
+	if b.buff == nil {
+		b.buff = newSectionBuilder(b.layout)
+	} else {
+		b.buff.close()
+	}
+
+	b.addSection(b.buff)
+
+	b.buff = nil
+
+	b.lineAccepted()
+}
+
+// addSection is a private function that adds a section to the last page. If
+// the page cannot hold all of its lines, the section is split and the lines
+// that do not fit go to new pages.
+//
+// Parameters:
+//   - sb: The section to add.
+func (b *buffer) addSection(sb *sectionBuilder) {
+	if b.height <= 0 {
+		b.pages[b.lastPage] = append(b.pages[b.lastPage], sb)
+		return
+	}
+
+	used := b.consumed
+
+	for _, section := range b.pages[b.lastPage] {
+		used += len(section.getLines())
+	}
+
+	lines := sb.getLines()
+	wrapped := sb.wrapped
+
+	for used+len(lines) > b.height {
+		n := max(b.height-used, 0)
+
+		if n > 0 {
+			b.pages[b.lastPage] = append(b.pages[b.lastPage], newSectionFromLines(lines[:n], wrapped[:n], sb.layout))
+		}
+
+		lines = lines[n:]
+		wrapped = wrapped[n:]
+
+		b.lastPage++
+		b.pages = append(b.pages, []*sectionBuilder{})
+		b.consumed = 0
+
+		used = 0
+	}
+
+	if len(lines) > 0 {
+		b.pages[b.lastPage] = append(b.pages[b.lastPage], newSectionFromLines(lines, wrapped, sb.layout))
+	}
+}
+
+// write is a private function that appends a rune to the buffer
+// while dealing with special characters.
+//
+// Parameters:
+//   - char: The rune to append.
+func (b *buffer) write(char rune, style tcell.Style) {
+	switch char {
+	case '\t':
+		// Tab : Add spaces until the next tab stop
+		if b.buff == nil {
+			b.buff = newSectionBuilder(b.layout)
+		} else {
+			b.buff.acceptWord()
+		}
+
+		b.buff.writeString(string(char), style)
+
+		b.buff.acceptWord()
+	case '\v':
+		// vertical tab : Add vertical tabulation
+
+		// Do nothing
+	case '\r', '\n', '\u0085':
+		// carriage return : Move to the start of the line (alone)
+		// or move to the start of the line and down (with line feed)
+		// line feed : Add a new line or move to the left edge and down
+
+		b.accept()
+	case '\f':
+		// form feed : Go to the next page
+		b.accept()
+
+		b.lastPage++
+		b.pages = append(b.pages, []*sectionBuilder{})
+		b.consumed = 0
+	case ' ':
+		// Space
+		if b.buff != nil {
+			b.buff.acceptWord()
+		}
+	case '\u0000', '\a':
+		// null : Ignore this character
+		// Bell : Ignore this character
+	case '\b':
+		// backspace : Remove the last character
+		if b.buff != nil {
+			ok := b.buff.removeOne()
+			if ok {
+				return
+			}
+		}
+
+		for i := b.lastPage; i >= 0; i-- {
+			sections := b.pages[i]
+
+			for j := len(sections) - 1; j >= 0; j-- {
+				section := sections[j]
+
+				ok := section.removeOne()
+				if ok {
+					return
+				}
+			}
+		}
+	case '\u001A':
+		// Control-Z : End of file for Windows text-mode file i/o
+		b.finalize()
+	case '\u001B':
+		// escape : Introduce an escape sequence (next character)
+		// Do nothing
+	default:
+		// NBSP : Non-breaking space
+		// any other normal character
+		if b.buff == nil {
+			b.buff = newSectionBuilder(b.layout)
+		}
+
+		if char == NBSP {
+			// Non-breaking space
+			b.buff.writeString(" ", style)
+		} else {
+			b.buff.writeString(string(char), style)
+		}
+	}
+}
+
+// writeRune is a private function that appends a rune to the buffer
+// without checking for special characters.
+//
+// Parameters:
+//   - r: The rune to append.
+//   - style: The style of the rune.
+//
+// Behaviors:
+//   - When words are wrapped, spaces separate words as they do in write, so
+//     that long strings can be wrapped too.
+func (b *buffer) writeString(str string, style tcell.Style) {
+	if b.buff == nil {
+		b.buff = newSectionBuilder(b.layout)
+	}
+
+	if b.layout.width <= 0 {
+		b.buff.writeString(str, style)
+		return
+	}
+
+	for i, field := range strings.Split(str, " ") {
+		if i > 0 {
+			b.buff.acceptWord()
+		}
+
+		if field != "" {
+			b.buff.writeString(field, style)
+		}
+	}
+}
+
+// setLayout is a private function that changes how the lines are laid out
+// and the number of lines after which a new page is started.
+//
+// Parameters:
+//   - l: How the lines are laid out.
+//   - height: The number of lines. 0 if pages are only started by form feeds.
+func (b *buffer) setLayout(l layout, height int) {
+	b.layout = l
+	b.height = height
+
+	if b.buff != nil {
+		b.buff.setLayout(l)
+	}
+}
+
+// acceptWord is a private function that accepts the current word of the formatted string.
+func (b *buffer) acceptWord() {
+	if b.buff != nil {
+		b.buff.acceptWord()
+	}
+}
+
+// acceptLine is a private function that accepts the current line of the formatted string.
+func (b *buffer) acceptLine() {
+	if b.buff != nil {
+		b.buff.accept()
+
+		b.lineAccepted()
+	}
+}
+
+// writeEmptyLine is a private function that accepts the current line
+// regardless of the whether the line is empty or not.
+func (b *buffer) writeEmptyLine() {
+	if b.buff == nil {
+		b.buff = newSectionBuilder(b.layout)
+	}
+
+	b.buff.accept()
+
+	b.lineAccepted()
+}
+
+// finalize is a private function that finalizes the buffer.
+func (b *buffer) finalize() {
+	if b.buff == nil {
+		return
+	}
+
+	b.buff.close()
+
+	b.addSection(b.buff)
+
+	b.buff = nil
+}
@@ -225,6 +225,247 @@ func NewStyleConfig(defaultStyle tcell.Style) *StyleConfig {
 	}
 }
 
+// WidthConfig is a type that represents the configuration for word wrapping.
+type WidthConfig struct {
+	// width is the number of columns after which words are wrapped.
+	width int
+}
+
+// Copy is a method of uc.Copier interface.
+//
+// Returns:
+//   - *WidthConfig: A copy of the width configuration.
+func (c *WidthConfig) Copy() *WidthConfig {
+	return &WidthConfig{
+		width: c.width,
+	}
+}
+
+// NewWidthConfig is a function that creates a new width configuration.
+//
+// Parameters:
+//   - width: The number of display columns after which words are wrapped
+//     onto a new line. The indentation counts towards it.
+//
+// Returns:
+//   - *WidthConfig: A pointer to the new width configuration.
+//
+// Behaviors:
+//   - If width is less than 1, words are never wrapped.
+func NewWidthConfig(width int) *WidthConfig {
+	if width < 0 {
+		width = 0
+	}
+
+	return &WidthConfig{
+		width: width,
+	}
+}
+
+// GetWidth is a method that returns the number of columns after which words
+// are wrapped.
+//
+// Returns:
+//   - int: The number of columns. 0 if words are never wrapped.
+func (c *WidthConfig) GetWidth() int {
+	return c.width
+}
+
+// PageConfig is a type that represents the configuration for page breaks.
+type PageConfig struct {
+	// height is the maximum number of lines per page.
+	height int
+}
+
+// Copy is a method of uc.Copier interface.
+//
+// Returns:
+//   - *PageConfig: A copy of the page configuration.
+func (c *PageConfig) Copy() *PageConfig {
+	return &PageConfig{
+		height: c.height,
+	}
+}
+
+// NewPageConfig is a function that creates a new page configuration.
+//
+// Parameters:
+//   - height: The maximum number of lines per page. When a page is full, the
+//     lines that follow go to a new page, as if a form feed was written.
+//
+// Returns:
+//   - *PageConfig: A pointer to the new page configuration.
+//
+// Behaviors:
+//   - If height is less than 1, pages are only started by form feeds.
+func NewPageConfig(height int) *PageConfig {
+	if height < 0 {
+		height = 0
+	}
+
+	return &PageConfig{
+		height: height,
+	}
+}
+
+// GetHeight is a method that returns the maximum number of lines per page.
+//
+// Returns:
+//   - int: The number of lines. 0 if pages are only started by form feeds.
+func (c *PageConfig) GetHeight() int {
+	return c.height
+}
+
+// AlignConfig is a type that represents the configuration for alignment.
+type AlignConfig struct {
+	// align is how the lines are aligned.
+	align Alignment
+}
+
+// Copy is a method of uc.Copier interface.
+//
+// Returns:
+//   - *AlignConfig: A copy of the alignment configuration.
+func (c *AlignConfig) Copy() *AlignConfig {
+	return &AlignConfig{
+		align: c.align,
+	}
+}
+
+// NewAlignConfig is a function that creates a new alignment configuration.
+//
+// Parameters:
+//   - align: How the lines are aligned. They are aligned within the width of
+//     the WidthConfig if any, or else within the widest line of their page.
+//
+// Returns:
+//   - *AlignConfig: A pointer to the new alignment configuration.
+func NewAlignConfig(align Alignment) *AlignConfig {
+	return &AlignConfig{
+		align: align,
+	}
+}
+
+// GetAlignment is a method that returns how the lines are aligned.
+//
+// Returns:
+//   - Alignment: How the lines are aligned.
+func (c *AlignConfig) GetAlignment() Alignment {
+	return c.align
+}
+
+// DecoratorConfig is a type that represents the configuration for the
+// decoration of lines, such as the "> " of quotes or the gutter of log levels.
+type DecoratorConfig struct {
+	// prefix are the units written at the start of every line.
+	prefix []*Unit
+
+	// suffix are the units written at the end of every line.
+	suffix []*Unit
+}
+
+// Copy is a method of uc.Copier interface.
+//
+// Returns:
+//   - *DecoratorConfig: A copy of the decorator configuration.
+func (c *DecoratorConfig) Copy() *DecoratorConfig {
+	configCopy := &DecoratorConfig{
+		prefix: make([]*Unit, 0, len(c.prefix)),
+		suffix: make([]*Unit, 0, len(c.suffix)),
+	}
+
+	for _, unit := range c.prefix {
+		configCopy.prefix = append(configCopy.prefix, unit.Copy())
+	}
+
+	for _, unit := range c.suffix {
+		configCopy.suffix = append(configCopy.suffix, unit.Copy())
+	}
+
+	return configCopy
+}
+
+// NewDecoratorConfig is a function that creates a new decorator configuration.
+//
+// Parameters:
+//   - prefix: The units written at the start of every line, before the
+//     indentation.
+//   - suffix: The units written at the end of every line, separated from
+//     its last word by one space.
+//
+// Returns:
+//   - *DecoratorConfig: A pointer to the new decorator configuration.
+//
+// Behaviors:
+//   - Nil units are ignored.
+//   - The lines onto which words are wrapped are decorated too, and the width
+//     of the suffix counts towards the width of the WidthConfig.
+func NewDecoratorConfig(prefix, suffix []*Unit) *DecoratorConfig {
+	return &DecoratorConfig{
+		prefix: ReduceUnitSequence(prefix),
+		suffix: ReduceUnitSequence(suffix),
+	}
+}
+
+// GetPrefix is a method that returns the units written at the start of every
+// line.
+//
+// Returns:
+//   - []*Unit: The units of the prefix.
+func (c *DecoratorConfig) GetPrefix() []*Unit {
+	return c.prefix
+}
+
+// GetSuffix is a method that returns the units written at the end of every
+// line.
+//
+// Returns:
+//   - []*Unit: The units of the suffix.
+func (c *DecoratorConfig) GetSuffix() []*Unit {
+	return c.suffix
+}
+
+// DirectionConfig is a type that represents the configuration for the
+// direction of the text.
+type DirectionConfig struct {
+	// direction is the direction of the text.
+	direction Direction
+}
+
+// Copy is a method of uc.Copier interface.
+//
+// Returns:
+//   - *DirectionConfig: A copy of the direction configuration.
+func (c *DirectionConfig) Copy() *DirectionConfig {
+	return &DirectionConfig{
+		direction: c.direction,
+	}
+}
+
+// NewDirectionConfig is a function that creates a new direction configuration.
+//
+// Parameters:
+//   - direction: The direction of the text. Once the pages are finalized,
+//     the lines that hold right-to-left text, or all of them if direction is
+//     DirectionRTL, are reordered for display. This happens before they are
+//     aligned, so right-to-left documents are usually aligned to the right.
+//
+// Returns:
+//   - *DirectionConfig: A pointer to the new direction configuration.
+func NewDirectionConfig(direction Direction) *DirectionConfig {
+	return &DirectionConfig{
+		direction: direction,
+	}
+}
+
+// GetDirection is a method that returns the direction of the text.
+//
+// Returns:
+//   - Direction: The direction of the text.
+func (c *DirectionConfig) GetDirection() Direction {
+	return c.direction
+}
+
 //////////////////////////////////////////////////////////////
 
 /*
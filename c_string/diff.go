@@ -0,0 +1,380 @@
+package c_string
+
+import (
+	"fmt"
+
+	"github.com/gdamore/tcell"
+)
+
+// diffOp is a type that represents a line of a diff.
+type diffOp struct {
+	// kind is the marker of the line: ' ' for context, '-' for removed, and
+	// '+' for added lines.
+	kind byte
+
+	// text is the text of the line.
+	text string
+
+	// a is the number of lines of the old text before this line.
+	a int
+
+	// b is the number of lines of the new text before this line.
+	b int
+}
+
+// Diff is a type that shows the changes between two texts, line by line,
+// as added, removed, and context lines. A Diff implements the CStringer
+// interface; it is then styled with the theme of the traversor.
+type Diff struct {
+	// ops are the lines of the diff.
+	ops []diffOp
+
+	// context is the number of unchanged lines around the changes. -1 if all
+	// of them are shown.
+	context int
+
+	// intraLine tells whether the changed parts of modified lines are
+	// highlighted.
+	intraLine bool
+}
+
+// NewDiff creates a new diff between two texts. All the unchanged lines are
+// shown and modified lines are highlighted.
+//
+// Parameters:
+//   - a: The lines of the old text.
+//   - b: The lines of the new text.
+//
+// Returns:
+//   - *Diff: The new diff.
+func NewDiff(a, b []string) *Diff {
+	return &Diff{
+		ops:       diffLines(a, b),
+		context:   -1,
+		intraLine: true,
+	}
+}
+
+// SetContext sets the number of unchanged lines shown around the changes.
+// Changes further apart are shown as separate hunks, each headed by a
+// "@@ -l,n +l,n @@" line, as in unified diffs.
+//
+// Parameters:
+//   - n: The number of lines. If negative, all the unchanged lines are shown.
+func (d *Diff) SetContext(n int) {
+	if n < 0 {
+		n = -1
+	}
+
+	d.context = n
+}
+
+// SetIntraLine sets whether the changed parts of modified lines are
+// highlighted. A removed line followed by an added one is a modified line.
+//
+// Parameters:
+//   - enabled: True to highlight the changed parts, false otherwise.
+func (d *Diff) SetIntraLine(enabled bool) {
+	d.intraLine = enabled
+}
+
+// diffLines computes the lines of the diff between two texts with their
+// longest common subsequence.
+//
+// Parameters:
+//   - a: The lines of the old text.
+//   - b: The lines of the new text.
+//
+// Returns:
+//   - []diffOp: The lines of the diff. Removed lines come before the added
+//     lines that replace them.
+func diffLines(a, b []string) []diffOp {
+	// lcs[i][j] is the length of the longest common subsequence of a[i:]
+	// and b[j:].
+	lcs := make([][]int, len(a)+1)
+
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var ops []diffOp
+
+	i, j := 0, 0
+
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			ops = append(ops, diffOp{kind: ' ', text: a[i], a: i, b: j})
+			i++
+			j++
+		case j == len(b) || (i < len(a) && lcs[i+1][j] >= lcs[i][j+1]):
+			ops = append(ops, diffOp{kind: '-', text: a[i], a: i, b: j})
+			i++
+		default:
+			ops = append(ops, diffOp{kind: '+', text: b[j], a: i, b: j})
+			j++
+		}
+	}
+
+	return ops
+}
+
+// hunks returns the ranges of the lines of the diff that are shown.
+//
+// Returns:
+//   - [][2]int: The start and end of every hunk, in the lines of the diff.
+func (d *Diff) hunks() [][2]int {
+	if d.context < 0 {
+		return [][2]int{{0, len(d.ops)}}
+	}
+
+	var hunks [][2]int
+
+	for i, op := range d.ops {
+		if op.kind == ' ' {
+			continue
+		}
+
+		start := max(i-d.context, 0)
+		end := min(i+d.context+1, len(d.ops))
+
+		if len(hunks) > 0 && start <= hunks[len(hunks)-1][1] {
+			hunks[len(hunks)-1][1] = end
+		} else {
+			hunks = append(hunks, [2]int{start, end})
+		}
+	}
+
+	return hunks
+}
+
+// hunkHeader returns the header of a hunk.
+//
+// Parameters:
+//   - ops: The lines of the hunk.
+//
+// Returns:
+//   - string: The header, such as "@@ -1,3 +1,4 @@".
+func hunkHeader(ops []diffOp) string {
+	var aLen, bLen int
+
+	for _, op := range ops {
+		if op.kind != '+' {
+			aLen++
+		}
+
+		if op.kind != '-' {
+			bLen++
+		}
+	}
+
+	aStart, bStart := ops[0].a, ops[0].b
+
+	if aLen > 0 {
+		aStart++
+	}
+
+	if bLen > 0 {
+		bStart++
+	}
+
+	return fmt.Sprintf("@@ -%d,%d +%d,%d @@", aStart, aLen, bStart, bLen)
+}
+
+// changedRange returns the part of two lines that differs: what remains once
+// their common prefix and suffix are removed.
+//
+// Parameters:
+//   - a: The old line.
+//   - b: The new line.
+//
+// Returns:
+//   - int: The number of runes of the common prefix.
+//   - int: The number of runes of the common suffix.
+func changedRange(a, b []rune) (int, int) {
+	prefix := 0
+
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+
+	suffix := 0
+
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	return prefix, suffix
+}
+
+// lineUnits returns the units of a line of the diff.
+//
+// Parameters:
+//   - marker: The marker of the line.
+//   - text: The text of the line.
+//   - style: The style of the line.
+//   - change: The style of the changed part.
+//   - prefix: The number of runes before the changed part. -1 if the whole
+//     line is styled with style.
+//   - suffix: The number of runes after the changed part.
+//
+// Returns:
+//   - []*Unit: The units of the line.
+func lineUnits(marker byte, text string, style, change tcell.Style, prefix, suffix int) []*Unit {
+	units := []*Unit{NewUnit(string(marker)+" ", style)}
+
+	if prefix < 0 {
+		return ReduceUnitSequence(append(units, NewUnit(text, style)))
+	}
+
+	runes := []rune(text)
+
+	parts := [3]string{
+		string(runes[:prefix]),
+		string(runes[prefix : len(runes)-suffix]),
+		string(runes[len(runes)-suffix:]),
+	}
+
+	for i, part := range parts {
+		if part == "" {
+			continue
+		}
+
+		if i == 1 {
+			units = append(units, NewUnit(part, change))
+		} else {
+			units = append(units, NewUnit(part, style))
+		}
+	}
+
+	return ReduceUnitSequence(units)
+}
+
+// Lines returns the lines of the diff, styled with a theme: StyleDiffAdded,
+// StyleDiffRemoved, and StyleDiffContext for the lines, StyleDiffHunk for
+// the headers of the hunks, and StyleDiffAddedChange and
+// StyleDiffRemovedChange for the changed parts of modified lines.
+//
+// Parameters:
+//   - theme: The theme to use. If nil, DefaultTheme is used.
+//
+// Returns:
+//   - [][]*Unit: The units of every line. Every line starts with its marker:
+//     "+ ", "- ", or "  ".
+func (d *Diff) Lines(theme Theme) [][]*Unit {
+	if theme == nil {
+		theme = DefaultTheme()
+	}
+
+	styles := map[byte][2]tcell.Style{
+		' ': {theme.Style(StyleDiffContext), theme.Style(StyleDiffContext)},
+		'-': {theme.Style(StyleDiffRemoved), theme.Style(StyleDiffRemovedChange)},
+		'+': {theme.Style(StyleDiffAdded), theme.Style(StyleDiffAddedChange)},
+	}
+
+	var lines [][]*Unit
+
+	for _, hunk := range d.hunks() {
+		ops := d.ops[hunk[0]:hunk[1]]
+
+		if d.context >= 0 {
+			lines = append(lines, []*Unit{NewUnit(hunkHeader(ops), theme.Style(StyleDiffHunk))})
+		}
+
+		// changes holds the common prefix and suffix of the modified lines.
+		changes := make([][2]int, len(ops))
+
+		for i := range changes {
+			changes[i] = [2]int{-1, 0}
+		}
+
+		if d.intraLine {
+			d.pairLines(ops, changes)
+		}
+
+		for i, op := range ops {
+			style := styles[op.kind]
+
+			lines = append(lines, lineUnits(op.kind, op.text, style[0], style[1], changes[i][0], changes[i][1]))
+		}
+	}
+
+	return lines
+}
+
+// pairLines pairs the removed lines with the added lines that directly
+// follow them, in order, and computes their changed parts.
+//
+// Parameters:
+//   - ops: The lines of a hunk.
+//   - changes: The common prefix and suffix of every line. It is updated for
+//     the paired lines.
+func (d *Diff) pairLines(ops []diffOp, changes [][2]int) {
+	for i := 0; i < len(ops); {
+		if ops[i].kind != '-' {
+			i++
+			continue
+		}
+
+		removed := i
+
+		for i < len(ops) && ops[i].kind == '-' {
+			i++
+		}
+
+		added := i
+
+		for i < len(ops) && ops[i].kind == '+' {
+			i++
+		}
+
+		for k := 0; removed+k < added && added+k < i; k++ {
+			a, b := []rune(ops[removed+k].text), []rune(ops[added+k].text)
+
+			prefix, suffix := changedRange(a, b)
+
+			changes[removed+k] = [2]int{prefix, suffix}
+			changes[added+k] = [2]int{prefix, suffix}
+		}
+	}
+}
+
+// CString implements the CStringer interface. Every line of the diff is
+// written as a line of the traversor, styled with the theme of the
+// traversor.
+func (d *Diff) CString(trav *Traversor) error {
+	if trav == nil {
+		return nil
+	}
+
+	for _, line := range d.Lines(trav.Theme()) {
+		trav.writeUnitLine(line)
+	}
+
+	return nil
+}
+
+// RenderDiff is a function that shows the changes between two texts, line by
+// line, with all the unchanged lines and the changed parts of modified lines
+// highlighted. Use NewDiff for other settings.
+//
+// Parameters:
+//   - a: The lines of the old text.
+//   - b: The lines of the new text.
+//   - theme: The theme to use. If nil, DefaultTheme is used.
+//
+// Returns:
+//   - [][]*Unit: The units of every line. See Diff.Lines.
+func RenderDiff(a, b []string, theme Theme) [][]*Unit {
+	return NewDiff(a, b).Lines(theme)
+}
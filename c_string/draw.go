@@ -0,0 +1,143 @@
+package c_string
+
+import (
+	"github.com/gdamore/tcell"
+	"github.com/mattn/go-runewidth"
+)
+
+const (
+	// TabWidth is the number of columns between two tab stops.
+	TabWidth int = 8
+)
+
+// cellWriter is a type that writes runes to a row of a screen, one cell at
+// a time, so that combining marks end up in the cell of the rune before them.
+type cellWriter struct {
+	// screen is the screen to write to.
+	screen tcell.Screen
+
+	// left is the column where the row starts. Tab stops are relative to it.
+	left int
+
+	// x and y are the position of the next cell.
+	x, y int
+
+	// mainc is the rune of the pending cell. 0 if there is none.
+	mainc rune
+
+	// combc are the combining marks of the pending cell.
+	combc []rune
+
+	// style is the style of the pending cell.
+	style tcell.Style
+}
+
+// flush writes the pending cell, if any, and moves past it.
+func (cw *cellWriter) flush() {
+	if cw.mainc == 0 {
+		return
+	}
+
+	cw.screen.SetContent(cw.x, cw.y, cw.mainc, cw.combc, cw.style)
+
+	cw.x += max(runewidth.RuneWidth(cw.mainc), 1)
+
+	cw.mainc = 0
+	cw.combc = nil
+}
+
+// writeRune writes a rune.
+//
+// Parameters:
+//   - r: The rune to write. Tabs are expanded to the next tab stop.
+//   - style: The style of the rune.
+func (cw *cellWriter) writeRune(r rune, style tcell.Style) {
+	if r == '\t' {
+		cw.flush()
+
+		n := TabWidth - (cw.x-cw.left)%TabWidth
+
+		for i := 0; i < n; i++ {
+			cw.screen.SetContent(cw.x, cw.y, ' ', nil, style)
+			cw.x++
+		}
+
+		return
+	}
+
+	if runewidth.RuneWidth(r) == 0 && cw.mainc != 0 {
+		cw.combc = append(cw.combc, r)
+		return
+	}
+
+	cw.flush()
+
+	cw.mainc = r
+	cw.style = style
+}
+
+// newLine moves to the start of the next row.
+func (cw *cellWriter) newLine() {
+	cw.flush()
+
+	cw.x = cw.left
+	cw.y++
+}
+
+// Draw draws pages, as returned by Printer.GetPages, on a screen. Every line
+// takes one row and the words of a line are separated by one space; pages are
+// separated by an empty row. Wide runes take two columns and combining marks
+// are drawn in the cell of the rune before them.
+//
+// Parameters:
+//   - screen: The screen to draw on. If nil, nothing is drawn.
+//   - pages: The pages to draw. Nil units are skipped.
+//   - x: The column of the first cell of every line.
+//   - y: The row of the first line.
+//
+// Behaviors:
+//   - Cells outside the screen are ignored by the screen itself, so the pages
+//     may be drawn partially off-screen.
+//   - The screen is not shown; call screen.Show afterwards.
+func Draw(screen tcell.Screen, pages [][][][][]*Unit, x, y int) {
+	if screen == nil {
+		return
+	}
+
+	cw := &cellWriter{
+		screen: screen,
+		left:   x,
+		x:      x,
+		y:      y,
+	}
+
+	for i, page := range pages {
+		if i > 0 {
+			cw.newLine()
+		}
+
+		for _, section := range page {
+			for _, line := range section {
+				for j, word := range line {
+					if j > 0 {
+						cw.writeRune(' ', tcell.StyleDefault)
+					}
+
+					for _, unit := range word {
+						if unit == nil {
+							continue
+						}
+
+						for _, r := range unit.Content {
+							cw.writeRune(r, unit.Style)
+						}
+					}
+				}
+
+				cw.newLine()
+			}
+		}
+	}
+
+	cw.flush()
+}
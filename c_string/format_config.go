@@ -29,8 +29,9 @@ func DefaultFormatter(style tcell.Style) FormatConfig {
 }
 
 // FormatConfig is a type that represents a configuration for formatting.
-// [Indentation] [Left Delimiter] [Right Delimiter] [Separator]
-type FormatConfig [5]any
+// [Indentation] [Left Delimiter] [Right Delimiter] [Separator] [Style] [Width] [Page] [Alignment]
+// [Theme] [Decorator] [Direction]
+type FormatConfig [11]any
 
 const (
 	// ConfInd_Idx is the index for the indentation configuration.
@@ -47,6 +48,24 @@ const (
 
 	// ConfStyle_Idx is the index for the style configuration.
 	ConfStyle_Idx
+
+	// ConfWidth_Idx is the index for the width configuration.
+	ConfWidth_Idx
+
+	// ConfPage_Idx is the index for the page configuration.
+	ConfPage_Idx
+
+	// ConfAlign_Idx is the index for the alignment configuration.
+	ConfAlign_Idx
+
+	// ConfTheme_Idx is the index for the theme.
+	ConfTheme_Idx
+
+	// ConfDecor_Idx is the index for the decorator configuration.
+	ConfDecor_Idx
+
+	// ConfDir_Idx is the index for the direction configuration.
+	ConfDir_Idx
 )
 
 // NewFormatter is a function that creates a new formatter with the given configuration.
@@ -59,7 +78,8 @@ const (
 //
 // Behaviors:
 //   - The function panics if an invalid configuration type is given. (i.e., not IndentConfig,
-//     DelimiterConfig, or SeparatorConfig)
+//     DelimiterConfig, SeparatorConfig, WidthConfig, PageConfig, AlignConfig, Theme,
+//     DecoratorConfig, or DirectionConfig)
 func NewFormatter(options ...any) (form FormatConfig) {
 	if len(options) == 0 {
 		return
@@ -77,6 +97,18 @@ func NewFormatter(options ...any) (form FormatConfig) {
 			}
 		case *SeparatorConfig:
 			form[3] = opt
+		case *WidthConfig:
+			form[ConfWidth_Idx] = opt
+		case *PageConfig:
+			form[ConfPage_Idx] = opt
+		case *AlignConfig:
+			form[ConfAlign_Idx] = opt
+		case Theme:
+			form[ConfTheme_Idx] = opt
+		case *DecoratorConfig:
+			form[ConfDecor_Idx] = opt
+		case *DirectionConfig:
+			form[ConfDir_Idx] = opt
 		default:
 			panic(fmt.Errorf("invalid configuration type: %T", opt))
 		}
@@ -85,6 +117,69 @@ func NewFormatter(options ...any) (form FormatConfig) {
 	return
 }
 
+// Copy is a method that creates a deep copy of the formatter, so that the
+// configurations of the copy can be modified without affecting the original.
+//
+// Returns:
+//   - FormatConfig: The copy of the formatter.
+//
+// Behaviors:
+//   - The function panics if an invalid configuration type is found.
+func (form FormatConfig) Copy() FormatConfig {
+	var formCopy FormatConfig
+
+	for i, opt := range form {
+		switch opt := opt.(type) {
+		case nil:
+			// Do nothing
+		case *IndentConfig:
+			if opt != nil {
+				formCopy[i] = opt.Copy()
+			}
+		case *DelimiterConfig:
+			if opt != nil {
+				formCopy[i] = opt.Copy()
+			}
+		case *SeparatorConfig:
+			if opt != nil {
+				formCopy[i] = opt.Copy()
+			}
+		case *StyleConfig:
+			if opt != nil {
+				formCopy[i] = opt.Copy()
+			}
+		case *WidthConfig:
+			if opt != nil {
+				formCopy[i] = opt.Copy()
+			}
+		case *PageConfig:
+			if opt != nil {
+				formCopy[i] = opt.Copy()
+			}
+		case *AlignConfig:
+			if opt != nil {
+				formCopy[i] = opt.Copy()
+			}
+		case Theme:
+			if opt != nil {
+				formCopy[i] = opt.Copy()
+			}
+		case *DecoratorConfig:
+			if opt != nil {
+				formCopy[i] = opt.Copy()
+			}
+		case *DirectionConfig:
+			if opt != nil {
+				formCopy[i] = opt.Copy()
+			}
+		default:
+			panic(fmt.Errorf("invalid configuration type: %T", opt))
+		}
+	}
+
+	return formCopy
+}
+
 // ApplyForm is a function that applies the format to an element.
 //
 // Parameters:
@@ -152,7 +247,7 @@ func ApplyFormMany[T CStringer](form FormatConfig, trav *Traversor, elems []T) e
 func MergeForm(form1, form2 FormatConfig) FormatConfig {
 	var form FormatConfig
 
-	for i := 0; i < 4; i++ {
+	for i := 0; i < len(form); i++ {
 		if form1[i] != nil {
 			form[i] = form1[i]
 		} else {
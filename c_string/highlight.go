@@ -0,0 +1,420 @@
+package c_string
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+
+	gcers "github.com/PlayerR9/go-errors"
+	"github.com/dustin/go-humanize"
+)
+
+// Token is a type that represents a piece of source code.
+type Token struct {
+	// Text is the text of the token. It may span several lines.
+	Text string
+
+	// Kind is the name of the style of the token in a theme, such as
+	// StyleKeyword. Tokens without a kind are styled with StyleCode.
+	Kind string
+}
+
+// Lexer is an interface that splits source code into tokens.
+type Lexer interface {
+	// Tokenize splits source code into tokens.
+	//
+	// Parameters:
+	//   - code: The source code.
+	//
+	// Returns:
+	//   - []Token: The tokens. Joined, their texts must be the source code.
+	Tokenize(code string) []Token
+}
+
+// LexerFunc is a function that implements the Lexer interface.
+type LexerFunc func(code string) []Token
+
+// Tokenize implements the Lexer interface.
+func (f LexerFunc) Tokenize(code string) []Token {
+	return f(code)
+}
+
+// languageLexer is a lexer for the common syntax of programming languages:
+// identifiers, keywords, numbers, quoted strings, and comments.
+type languageLexer struct {
+	// keywords are the keywords of the language.
+	keywords map[string]struct{}
+
+	// lineComments are the markers that start a comment up to the end of the
+	// line.
+	lineComments []string
+
+	// blockComment are the markers that start and end a block comment. Empty
+	// if there are none.
+	blockComment [2]string
+
+	// quotes are the runes that delimit strings.
+	quotes string
+
+	// rawQuotes are the quotes in which backslashes do not escape.
+	rawQuotes string
+}
+
+// newLanguageLexer creates a new lexer of the common syntax of programming
+// languages.
+//
+// Parameters:
+//   - keywords: The keywords of the language, separated by spaces.
+//   - lineComments: The markers that start a comment up to the end of the line.
+//   - blockComment: The markers that start and end a block comment.
+//   - quotes: The runes that delimit strings.
+//   - rawQuotes: The quotes in which backslashes do not escape.
+//
+// Returns:
+//   - *languageLexer: The new lexer.
+func newLanguageLexer(keywords string, lineComments []string, blockComment [2]string, quotes, rawQuotes string) *languageLexer {
+	lexer := &languageLexer{
+		keywords:     make(map[string]struct{}),
+		lineComments: lineComments,
+		blockComment: blockComment,
+		quotes:       quotes,
+		rawQuotes:    rawQuotes,
+	}
+
+	for _, keyword := range strings.Fields(keywords) {
+		lexer.keywords[keyword] = struct{}{}
+	}
+
+	return lexer
+}
+
+// Tokenize implements the Lexer interface.
+func (l *languageLexer) Tokenize(code string) []Token {
+	var tokens []Token
+
+	emit := func(n int, kind string) {
+		tokens = append(tokens, Token{Text: code[:n], Kind: kind})
+		code = code[n:]
+	}
+
+	for len(code) > 0 {
+		if n := l.comment(code); n > 0 {
+			emit(n, StyleComment)
+			continue
+		}
+
+		r, size := utf8.DecodeRuneInString(code)
+
+		switch {
+		case strings.ContainsRune(l.quotes, r):
+			emit(quoted(code, r, !strings.ContainsRune(l.rawQuotes, r)), StyleString)
+		case unicode.IsDigit(r):
+			emit(scanWord(code), StyleNumber)
+		case r == '_' || unicode.IsLetter(r):
+			n := scanWord(code)
+
+			if _, ok := l.keywords[code[:n]]; ok {
+				emit(n, StyleKeyword)
+			} else {
+				emit(n, "")
+			}
+		default:
+			emit(size, "")
+		}
+	}
+
+	return tokens
+}
+
+// comment returns the length of the comment at the start of the code.
+//
+// Parameters:
+//   - code: The source code.
+//
+// Returns:
+//   - int: The length of the comment, in bytes. 0 if the code does not start
+//     with a comment.
+//
+// Behaviors:
+//   - An unterminated block comment spans the rest of the code.
+func (l *languageLexer) comment(code string) int {
+	for _, marker := range l.lineComments {
+		if !strings.HasPrefix(code, marker) {
+			continue
+		}
+
+		end := strings.IndexByte(code, '\n')
+		if end == -1 {
+			return len(code)
+		}
+
+		return end
+	}
+
+	start, stop := l.blockComment[0], l.blockComment[1]
+
+	if start == "" || !strings.HasPrefix(code, start) {
+		return 0
+	}
+
+	end := strings.Index(code[len(start):], stop)
+	if end == -1 {
+		return len(code)
+	}
+
+	return len(start) + end + len(stop)
+}
+
+// quoted returns the length of the string at the start of the code.
+//
+// Parameters:
+//   - code: The source code. It starts with the quote.
+//   - quote: The quote that delimits the string.
+//   - escapes: Whether backslashes escape the rune that follows them.
+//
+// Returns:
+//   - int: The length of the string, quotes included, in bytes.
+//
+// Behaviors:
+//   - A string that is not closed ends at the end of its line, unless the
+//     quote is a raw one.
+func quoted(code string, quote rune, escapes bool) int {
+	i := utf8.RuneLen(quote)
+
+	for i < len(code) {
+		r, size := utf8.DecodeRuneInString(code[i:])
+
+		switch {
+		case r == quote:
+			return i + size
+		case r == '\\' && escapes:
+			i += size
+
+			if i < len(code) {
+				_, size = utf8.DecodeRuneInString(code[i:])
+			} else {
+				size = 0
+			}
+		case r == '\n' && escapes:
+			return i
+		}
+
+		i += size
+	}
+
+	return i
+}
+
+// scanWord returns the length of the identifier or number at the start of
+// the code.
+//
+// Parameters:
+//   - code: The source code.
+//
+// Returns:
+//   - int: The length of the word, in bytes.
+func scanWord(code string) int {
+	for i, r := range code {
+		if r != '_' && r != '.' && !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			return i
+		}
+
+		// A dot only belongs to numbers, as in "3.14".
+		if r == '.' && (i == 0 || !unicode.IsDigit(rune(code[0]))) {
+			return i
+		}
+	}
+
+	return len(code)
+}
+
+var (
+	// lexers are the lexers of the languages, by name.
+	lexers map[string]Lexer = map[string]Lexer{}
+
+	// lexersMu is the mutex for lexers.
+	lexersMu sync.RWMutex
+)
+
+func init() {
+	c := newLanguageLexer(
+		"auto break case char const continue default do double else enum extern float for goto if "+
+			"inline int long register return short signed sizeof static struct switch typedef union "+
+			"unsigned void volatile while NULL true false bool",
+		[]string{"//"}, [2]string{"/*", "*/"}, "\"'", "",
+	)
+
+	goLexer := newLanguageLexer(
+		"break case chan const continue default defer else fallthrough for func go goto if import "+
+			"interface map package range return select struct switch type var true false nil iota",
+		[]string{"//"}, [2]string{"/*", "*/"}, "\"'`", "`",
+	)
+
+	js := newLanguageLexer(
+		"async await break case catch class const continue debugger default delete do else export "+
+			"extends finally for function if import in instanceof let new of return static super switch "+
+			"this throw try typeof var void while yield true false null undefined",
+		[]string{"//"}, [2]string{"/*", "*/"}, "\"'`", "",
+	)
+
+	python := newLanguageLexer(
+		"and as assert async await break class continue def del elif else except finally for from "+
+			"global if import in is lambda nonlocal not or pass raise return try while with yield "+
+			"True False None",
+		[]string{"#"}, [2]string{}, "\"'", "",
+	)
+
+	shell := newLanguageLexer(
+		"if then else elif fi case esac for while until do done in function return local export",
+		[]string{"#"}, [2]string{}, "\"'", "'",
+	)
+
+	json := newLanguageLexer("true false null", nil, [2]string{}, "\"", "")
+
+	for lang, lexer := range map[string]Lexer{
+		"c": c, "cpp": c, "c++": c, "h": c,
+		"go": goLexer, "golang": goLexer,
+		"javascript": js, "js": js, "typescript": js, "ts": js,
+		"python": python, "py": python,
+		"sh": shell, "shell": shell, "bash": shell,
+		"json": json,
+	} {
+		lexers[lang] = lexer
+	}
+}
+
+// RegisterLexer registers the lexer of a language, replacing any lexer the
+// language had. Lexers are provided for C, Go, JavaScript, Python, shell,
+// and JSON.
+//
+// Parameters:
+//   - lang: The name of the language. Case is ignored.
+//   - lexer: The lexer of the language.
+//
+// Returns:
+//   - error: An error of type *errors.ErrNilParameter if lexer is nil.
+func RegisterLexer(lang string, lexer Lexer) error {
+	if lexer == nil {
+		return gcers.NewErrNilParameter("lexer")
+	}
+
+	lexersMu.Lock()
+	defer lexersMu.Unlock()
+
+	lexers[strings.ToLower(lang)] = lexer
+
+	return nil
+}
+
+// getLexer returns the lexer of a language.
+//
+// Parameters:
+//   - lang: The name of the language. Case is ignored.
+//
+// Returns:
+//   - Lexer: The lexer. Nil if the language has none.
+func getLexer(lang string) Lexer {
+	lexersMu.RLock()
+	defer lexersMu.RUnlock()
+
+	return lexers[strings.ToLower(lang)]
+}
+
+// Highlight is a function that colorizes source code with the styles of a
+// theme: StyleKeyword, StyleString, StyleNumber, StyleComment, and
+// StyleCode for everything else.
+//
+// Parameters:
+//   - code: The source code.
+//   - lang: The language of the code, such as "go". See RegisterLexer.
+//   - theme: The theme to use. If nil, DefaultTheme is used.
+//
+// Returns:
+//   - [][]*Unit: The units of every line of the code.
+//   - error: An error of type *Errors.ErrAt if the code is not valid UTF-8.
+//
+// Behaviors:
+//   - If the language has no lexer, the code is styled with StyleCode only.
+//   - A trailing newline does not start a new line.
+func Highlight(code, lang string, theme Theme) ([][]*Unit, error) {
+	n := checkString(code)
+	if n != -1 {
+		return nil, gcers.NewErrAt(humanize.Ordinal(n+1)+" rune", errors.New("not proper UTF-8 encoding"))
+	}
+
+	if theme == nil {
+		theme = DefaultTheme()
+	}
+
+	code = strings.TrimSuffix(code, "\n")
+
+	var tokens []Token
+
+	lexer := getLexer(lang)
+	if lexer == nil {
+		tokens = []Token{{Text: code}}
+	} else {
+		tokens = lexer.Tokenize(code)
+	}
+
+	lines := [][]*Unit{nil}
+
+	for _, token := range tokens {
+		kind := token.Kind
+		if kind == "" {
+			kind = StyleCode
+		}
+
+		style := theme.Style(kind)
+
+		for i, text := range strings.Split(token.Text, "\n") {
+			if i > 0 {
+				lines = append(lines, nil)
+			}
+
+			text = strings.TrimSuffix(text, "\r")
+
+			if text != "" {
+				lines[len(lines)-1] = append(lines[len(lines)-1], NewUnit(text, style))
+			}
+		}
+	}
+
+	for i, line := range lines {
+		lines[i] = ReduceUnitSequence(line)
+	}
+
+	return lines, nil
+}
+
+// AddCode adds source code to the traversor, colorized with the theme of the
+// traversor, one line at a time. See Highlight.
+//
+// Parameters:
+//   - code: The source code.
+//   - lang: The language of the code, such as "go".
+//
+// Returns:
+//   - error: An error of type *Errors.ErrAt if the code is not valid UTF-8.
+//
+// Behaviors:
+//   - Any in-progress line is accepted first.
+//   - The spaces of the code are kept as they are.
+func (trav *Traversor) AddCode(code, lang string) error {
+	if trav.source == nil {
+		return nil
+	}
+
+	lines, err := Highlight(code, lang, trav.Theme())
+	if err != nil {
+		return err
+	}
+
+	for _, line := range lines {
+		trav.writeUnitLine(line)
+	}
+
+	return nil
+}
@@ -0,0 +1,142 @@
+package c_string
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"strings"
+
+	gcers "github.com/PlayerR9/go-errors"
+	"github.com/gdamore/tcell"
+)
+
+// cssColor returns the CSS value of a color.
+//
+// Parameters:
+//   - c: The color.
+//
+// Returns:
+//   - string: The CSS value, such as "#ff0000". Empty if the color is the
+//     default one or cannot be converted.
+func cssColor(c tcell.Color) string {
+	if c == tcell.ColorDefault {
+		return ""
+	}
+
+	hex := c.Hex()
+	if hex < 0 {
+		return ""
+	}
+
+	return fmt.Sprintf("#%06x", hex)
+}
+
+// css returns the inline CSS of a style.
+//
+// Parameters:
+//   - style: The style.
+//
+// Returns:
+//   - string: The CSS declarations. Empty if the style is the default one.
+func css(style tcell.Style) string {
+	fg, bg, attrs := style.Decompose()
+
+	if attrs&tcell.AttrReverse != 0 {
+		fg, bg = bg, fg
+	}
+
+	var decls []string
+
+	if color := cssColor(fg); color != "" {
+		decls = append(decls, "color:"+color)
+	}
+
+	if color := cssColor(bg); color != "" {
+		decls = append(decls, "background-color:"+color)
+	}
+
+	if attrs&tcell.AttrBold != 0 {
+		decls = append(decls, "font-weight:bold")
+	}
+
+	if attrs&tcell.AttrItalic != 0 {
+		decls = append(decls, "font-style:italic")
+	}
+
+	if attrs&tcell.AttrDim != 0 {
+		decls = append(decls, "opacity:0.5")
+	}
+
+	var decorations []string
+
+	if attrs&tcell.AttrUnderline != 0 {
+		decorations = append(decorations, "underline")
+	}
+
+	if attrs&tcell.AttrBlink != 0 {
+		decorations = append(decorations, "blink")
+	}
+
+	if len(decorations) > 0 {
+		decls = append(decls, "text-decoration:"+strings.Join(decorations, " "))
+	}
+
+	return strings.Join(decls, ";")
+}
+
+// RenderHTML writes the pages of the printer to a writer as HTML, so that the
+// same document can be shown in web-based reports. Every page is a <pre>
+// element, and the styles of the units are converted to inline CSS on <span>
+// elements.
+//
+// Parameters:
+//   - w: The writer to write to.
+//
+// Returns:
+//   - error: An error of type *errors.ErrNilParameter if w is nil, or the
+//     error of the writer, if any.
+//
+// Behaviors:
+//   - Like GetPages, the printer is reset afterwards, unless w is nil.
+//   - The content is escaped, so it is never interpreted as HTML.
+func (p *Printer) RenderHTML(w io.Writer) error {
+	if w == nil {
+		return gcers.NewErrNilParameter("w")
+	}
+
+	pages := p.GetPages()
+
+	var builder strings.Builder
+
+	for _, page := range pages {
+		builder.WriteString("<pre>")
+
+		first := true
+
+		for _, section := range page {
+			for _, line := range section {
+				if !first {
+					builder.WriteRune('\n')
+				}
+
+				first = false
+
+				for _, unit := range ReduceUnitSequence(joinWords(line)) {
+					content := html.EscapeString(unit.Content)
+
+					decls := css(unit.Style)
+					if decls == "" {
+						builder.WriteString(content)
+					} else {
+						fmt.Fprintf(&builder, "<span style=\"%s\">%s</span>", decls, content)
+					}
+				}
+			}
+		}
+
+		builder.WriteString("</pre>\n")
+	}
+
+	_, err := io.WriteString(w, builder.String())
+	return err
+}
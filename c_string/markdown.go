@@ -0,0 +1,397 @@
+package c_string
+
+import (
+	"errors"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	gcers "github.com/PlayerR9/go-errors"
+	"github.com/dustin/go-humanize"
+	"github.com/gdamore/tcell"
+)
+
+// FromMarkdown is a function that prints basic Markdown with the styles of a
+// theme. The following is supported:
+//   - Headings ("# Title" to "###### Title"), styled with StyleHeading.
+//   - Paragraphs, whose lines are joined; blank lines separate them.
+//   - Emphasis ("*text*" or "_text_") and strong emphasis ("**text**" or
+//     "__text__"), styled with StyleEmphasis and StyleStrong.
+//   - Code spans ("`code`") and fenced code blocks ("```"), styled with
+//     StyleCode. The lines of code blocks are kept as they are, and blocks
+//     whose language is given ("```go") are colorized with Highlight.
+//   - Unordered ("-", "*", or "+") and ordered ("1.") list items, indented by
+//     one level for every two leading spaces. Their markers are styled with
+//     StyleBullet.
+//
+// Parameters:
+//   - src: The Markdown source.
+//   - theme: The theme to use. If nil, DefaultTheme is used.
+//
+// Returns:
+//   - *Printer: The printer the source was printed to.
+//   - error: An error of type *Errors.ErrAt if a line is not valid UTF-8.
+func FromMarkdown(src string, theme Theme) (*Printer, error) {
+	if theme == nil {
+		theme = DefaultTheme()
+	}
+
+	p := NewPrinter(DefaultFormatter(theme.Style(StyleText)))
+
+	md := &markdownPrinter{
+		trav:  p.GetTraversor(),
+		theme: theme,
+	}
+
+	for i, line := range strings.Split(src, "\n") {
+		if !utf8.ValidString(line) {
+			return nil, gcers.NewErrAt(humanize.Ordinal(i+1)+" line", errors.New("not proper UTF-8 encoding"))
+		}
+
+		md.line(strings.TrimSuffix(line, "\r"))
+	}
+
+	md.flush()
+	md.flushCode()
+
+	return p, nil
+}
+
+// markdownPrinter is a type that prints Markdown, one line at a time.
+type markdownPrinter struct {
+	// trav is the traversor of the document.
+	trav *Traversor
+
+	// theme is the theme of the document.
+	theme Theme
+
+	// paragraph are the lines of the in-progress paragraph.
+	paragraph []string
+
+	// inCode tells whether the lines are in a fenced code block.
+	inCode bool
+
+	// lang is the language of the fenced code block.
+	lang string
+
+	// code are the lines of the fenced code block.
+	code []string
+}
+
+// flush prints the in-progress paragraph, if any.
+func (md *markdownPrinter) flush() {
+	if len(md.paragraph) == 0 {
+		return
+	}
+
+	text := strings.Join(md.paragraph, " ")
+	md.paragraph = nil
+
+	md.trav.writeWordLine(splitWords(parseInline(text, md.theme.Style(StyleText), md.theme)))
+}
+
+// flushCode prints the fenced code block, if any.
+func (md *markdownPrinter) flushCode() {
+	if len(md.code) == 0 {
+		return
+	}
+
+	// The newline keeps the last line, even when it is empty.
+	code := strings.Join(md.code, "\n") + "\n"
+	md.code = nil
+
+	// The lines were checked to be valid UTF-8.
+	lines, _ := Highlight(code, md.lang, md.theme)
+
+	for _, line := range lines {
+		md.trav.writeUnitLine(line)
+	}
+}
+
+// line prints a line of Markdown.
+//
+// Parameters:
+//   - line: The line, without its newline.
+func (md *markdownPrinter) line(line string) {
+	trimmed := strings.TrimSpace(line)
+
+	if strings.HasPrefix(trimmed, "```") {
+		md.flush()
+		md.flushCode()
+
+		md.inCode = !md.inCode
+		md.lang = strings.TrimSpace(strings.TrimPrefix(trimmed, "```"))
+
+		return
+	}
+
+	if md.inCode {
+		md.code = append(md.code, line)
+		return
+	}
+
+	if trimmed == "" {
+		md.flush()
+		md.trav.EmptyLine()
+
+		return
+	}
+
+	if text, ok := heading(trimmed); ok {
+		md.flush()
+
+		md.trav.writeWordLine(splitWords(parseInline(text, md.theme.Style(StyleHeading), md.theme)))
+		return
+	}
+
+	if depth, marker, text, ok := listItem(line); ok {
+		md.flush()
+
+		trav := newTraversor(md.trav.GetConfig(WithModifiedIndent(depth)), md.trav.source)
+
+		words := [][]*Unit{{NewUnit(marker, md.theme.Style(StyleBullet))}}
+		words = append(words, splitWords(parseInline(text, md.theme.Style(StyleText), md.theme))...)
+
+		trav.writeWordLine(words)
+		return
+	}
+
+	md.paragraph = append(md.paragraph, trimmed)
+}
+
+// heading parses a heading.
+//
+// Parameters:
+//   - line: The line, without its leading and trailing spaces.
+//
+// Returns:
+//   - string: The text of the heading.
+//   - bool: True if the line is a heading of level 1 to 6, false otherwise.
+func heading(line string) (string, bool) {
+	level := 0
+
+	for level < len(line) && line[level] == '#' {
+		level++
+	}
+
+	if level == 0 || level > 6 {
+		return "", false
+	}
+
+	rest := line[level:]
+
+	if rest != "" && rest[0] != ' ' && rest[0] != '\t' {
+		return "", false
+	}
+
+	return strings.TrimSpace(rest), true
+}
+
+// listItem parses a list item.
+//
+// Parameters:
+//   - line: The line.
+//
+// Returns:
+//   - int: The depth of the item: one for every two leading spaces.
+//   - string: The marker of the item, such as "-" or "1.".
+//   - string: The text of the item.
+//   - bool: True if the line is a list item, false otherwise.
+func listItem(line string) (int, string, string, bool) {
+	indent := len(line) - len(strings.TrimLeft(line, " "))
+	rest := line[indent:]
+
+	marker, text, ok := strings.Cut(rest, " ")
+	if !ok {
+		return 0, "", "", false
+	}
+
+	switch {
+	case marker == "-" || marker == "*" || marker == "+":
+		// Unordered item
+	case len(marker) > 1 && strings.HasSuffix(marker, ".") && isDigits(marker[:len(marker)-1]):
+		// Ordered item
+	default:
+		return 0, "", "", false
+	}
+
+	return indent / 2, marker, strings.TrimSpace(text), true
+}
+
+// isDigits checks whether a string is made of ASCII digits only.
+//
+// Parameters:
+//   - s: The string.
+//
+// Returns:
+//   - bool: True if s is not empty and made of digits only, false otherwise.
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// parseInline parses the inline elements of Markdown text: emphasis, strong
+// emphasis, code spans, and backslash escapes.
+//
+// Parameters:
+//   - text: The text.
+//   - base: The style of the text outside the inline elements.
+//   - theme: The theme.
+//
+// Returns:
+//   - []*Unit: The units of the text.
+func parseInline(text string, base tcell.Style, theme Theme) []*Unit {
+	var units []*Unit
+
+	var builder strings.Builder
+
+	var strong, em bool
+
+	style := func() tcell.Style {
+		switch {
+		case strong && em:
+			return theme.Style(StyleStrong).Italic(true)
+		case strong:
+			return theme.Style(StyleStrong)
+		case em:
+			return theme.Style(StyleEmphasis)
+		default:
+			return base
+		}
+	}
+
+	emit := func() {
+		if builder.Len() > 0 {
+			units = append(units, NewUnit(builder.String(), style()))
+			builder.Reset()
+		}
+	}
+
+	runes := []rune(text)
+
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+
+		switch {
+		case r == '\\' && i+1 < len(runes):
+			i++
+			builder.WriteRune(runes[i])
+		case r == '`':
+			end := indexRune(runes[i+1:], '`')
+			if end == -1 {
+				builder.WriteRune(r)
+				continue
+			}
+
+			emit()
+
+			units = append(units, NewUnit(string(runes[i+1:i+1+end]), theme.Style(StyleCode)))
+
+			i += end + 1
+		case (r == '*' || r == '_') && i+1 < len(runes) && runes[i+1] == r:
+			emit()
+
+			strong = !strong
+			i++
+		case (r == '*' && !isSpaced(runes, i)) || (r == '_' && isDelimiter(runes, i)):
+			emit()
+
+			em = !em
+		default:
+			builder.WriteRune(r)
+		}
+	}
+
+	emit()
+
+	return units
+}
+
+// indexRune returns the index of the first occurrence of a rune.
+//
+// Parameters:
+//   - runes: The runes to search.
+//   - r: The rune to search for.
+//
+// Returns:
+//   - int: The index of r. -1 if there is none.
+func indexRune(runes []rune, r rune) int {
+	for i, c := range runes {
+		if c == r {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// isDelimiter checks whether an underscore delimits emphasis rather than
+// being part of a word, as in snake_case.
+//
+// Parameters:
+//   - runes: The runes of the text.
+//   - i: The index of the underscore.
+//
+// Returns:
+//   - bool: True if the underscore is not between two letters or digits.
+func isDelimiter(runes []rune, i int) bool {
+	isWord := func(j int) bool {
+		return j >= 0 && j < len(runes) && (unicode.IsLetter(runes[j]) || unicode.IsDigit(runes[j]))
+	}
+
+	return !isWord(i-1) || !isWord(i+1)
+}
+
+// isSpaced checks whether a rune is between two spaces, as in "2 * 3", in
+// which case it does not delimit emphasis.
+//
+// Parameters:
+//   - runes: The runes of the text.
+//   - i: The index of the rune.
+//
+// Returns:
+//   - bool: True if the rune is between two spaces or the edges of the text.
+func isSpaced(runes []rune, i int) bool {
+	return (i == 0 || runes[i-1] == ' ') && (i+1 == len(runes) || runes[i+1] == ' ')
+}
+
+// splitWords splits units into words at their spaces.
+//
+// Parameters:
+//   - units: The units.
+//
+// Returns:
+//   - [][]*Unit: The words. Consecutive spaces do not make empty words.
+func splitWords(units []*Unit) [][]*Unit {
+	var words [][]*Unit
+
+	var word []*Unit
+
+	for _, unit := range units {
+		for i, field := range strings.Split(unit.Content, " ") {
+			if i > 0 && len(word) > 0 {
+				words = append(words, word)
+				word = nil
+			}
+
+			if field != "" {
+				word = append(word, NewLinkUnit(field, unit.URL, unit.Style))
+			}
+		}
+	}
+
+	if len(word) > 0 {
+		words = append(words, word)
+	}
+
+	return words
+}
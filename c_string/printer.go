@@ -1,6 +1,10 @@
 package c_string
 
 import (
+	"fmt"
+	"io"
+	"strings"
+
 	gcers "github.com/PlayerR9/go-errors"
 	"github.com/dustin/go-humanize"
 	"github.com/gdamore/tcell"
@@ -13,6 +17,21 @@ type Printer struct {
 
 	// formatter is the formatter of the document.
 	formatter FormatConfig
+
+	// ansi tells whether WriteTo converts the styles to SGR escape codes.
+	ansi bool
+
+	// flushW is the writer the lines are flushed to. Nil if they are not.
+	flushW io.Writer
+
+	// flushEvery is the number of lines after which they are flushed.
+	flushEvery int
+
+	// lines is the number of lines accepted since the last flush.
+	lines int
+
+	// flushErr is the first error of flushW, if any.
+	flushErr error
 }
 
 // NewPrinter creates a new printer.
@@ -26,10 +45,14 @@ type Printer struct {
 // Behaviors:
 //   - If the formatter is nil, the function uses the formatter with nil values.
 func NewPrinter(form FormatConfig) *Printer {
-	return &Printer{
+	p := &Printer{
 		buff:      newBuffer(),
 		formatter: form,
 	}
+
+	p.buff.onLine = p.lineAccepted
+
+	return p
 }
 
 // NewPrinterFromConfig creates a new printer from a configuration.
@@ -45,10 +68,7 @@ func NewPrinter(form FormatConfig) *Printer {
 //   - Panics if an invalid configuration type is given (i.e., not IndentConfig, DelimiterConfig,
 //     or SeparatorConfig).
 func NewPrinterFromConfig(opts ...any) *Printer {
-	return &Printer{
-		buff:      newBuffer(),
-		formatter: NewFormatter(opts...),
-	}
+	return NewPrinter(NewFormatter(opts...))
 }
 
 // GetTraversor returns a traversor for the printer.
@@ -198,16 +218,38 @@ func (p *Printer) GetPages() [][][][][]*Unit {
 
 	pages := p.buff.pages
 
-	// Reset the buffer
-	p.buff = newBuffer()
+	// Reset the buffer in place, as traversors may still write to it.
+	p.buff.reset()
 
+	return finalizePages(pages)
+}
+
+// finalizePages returns the lines of pages, reordered and aligned as
+// configured.
+//
+// Parameters:
+//   - pages: The sections of every page.
+//
+// Returns:
+//   - [][][][][]*Unit: The lines of every section of every page.
+func finalizePages(pages [][]*sectionBuilder) [][][][][]*Unit {
 	allStrings := make([][][][][]*Unit, 0, len(pages))
 
 	for _, page := range pages {
+		// Lines of sections without a width are aligned within the
+		// widest line of the page.
+		widest := 0
+
+		for _, section := range page {
+			for _, line := range section.getLines() {
+				widest = max(widest, lineWidth(line))
+			}
+		}
+
 		sectionLines := make([][][][]*Unit, 0)
 
 		for _, section := range page {
-			sectionLines = append(sectionLines, section.getLines())
+			sectionLines = append(sectionLines, section.alignedLines(widest))
 		}
 
 		allStrings = append(allStrings, sectionLines)
@@ -216,6 +258,19 @@ func (p *Printer) GetPages() [][][][][]*Unit {
 	return allStrings
 }
 
+// Embed splices the pages of another printer into the printer. See
+// Traversor.Embed; the lines are indented as configured by the formatter of
+// the printer.
+//
+// Parameters:
+//   - sub: The printer to embed. Like GetPages, it is reset afterwards.
+//
+// Returns:
+//   - error: An error of type *errors.ErrNilParameter if sub is nil.
+func (p *Printer) Embed(sub *Printer) error {
+	return p.GetTraversor().Embed(sub)
+}
+
 // Cleanup implements the Cleaner interface.
 func (p *Printer) Cleanup() {
 	p.buff.Cleanup()
@@ -420,6 +475,106 @@ func Fprintln(form FormatConfig, style tcell.Style, a ...interface{}) ([][][][][
 	return p.GetPages(), nil
 }
 
+// String returns the pages of the printer as plain text: the lines are
+// separated by newlines and the pages by form feeds. Styles are dropped.
+//
+// Returns:
+//   - string: The text of the printer.
+//
+// Behaviors:
+//   - Like GetPages, the printer is reset afterwards.
+func (p *Printer) String() string {
+	pages := p.Strings()
+
+	texts := make([]string, 0, len(pages))
+
+	for _, lines := range pages {
+		texts = append(texts, strings.Join(lines, "\n"))
+	}
+
+	return strings.Join(texts, "\f")
+}
+
+// sprint prints a string with the formatter and returns the result as plain
+// text. Every line of the string is indented as configured.
+//
+// Parameters:
+//   - form: The formatter to use.
+//   - str: The string to print.
+//
+// Returns:
+//   - string: The text. See Printer.String.
+//   - error: An error of type *Errors.ErrAt if the string is not valid UTF-8.
+func sprint(form FormatConfig, str string) (string, error) {
+	p := NewPrinter(form)
+
+	trav := p.GetTraversor()
+
+	lines := strings.Split(str, "\n")
+	last := lines[len(lines)-1]
+
+	err := trav.AddLines(lines[:len(lines)-1], tcell.StyleDefault)
+	if err != nil {
+		return "", err
+	}
+
+	if last != "" {
+		err := trav.AppendString(last, tcell.StyleDefault)
+		if err != nil {
+			return "", gcers.NewErrAt(humanize.Ordinal(len(lines))+" line", err)
+		}
+	}
+
+	return p.String(), nil
+}
+
+// Sprint formats its operands as fmt.Sprint does, runs them through the
+// formatter, and returns the result as plain text, so that no pages need to
+// be unpacked when only the indentation, delimiters, or separators matter.
+//
+// Parameters:
+//   - form: The formatter to use.
+//   - a: The operands to print.
+//
+// Returns:
+//   - string: The lines, separated by newlines. Pages are separated by form
+//     feeds. Styles are dropped.
+//   - error: An error of type *Errors.ErrAt if the text is not valid UTF-8.
+func Sprint(form FormatConfig, a ...any) (string, error) {
+	return sprint(form, fmt.Sprint(a...))
+}
+
+// Sprintf formats its operands as fmt.Sprintf does, runs them through the
+// formatter, and returns the result as plain text. See Sprint.
+//
+// Parameters:
+//   - form: The formatter to use.
+//   - format: The format string.
+//   - a: The operands to print.
+//
+// Returns:
+//   - string: The lines, separated by newlines. Pages are separated by form
+//     feeds. Styles are dropped.
+//   - error: An error of type *Errors.ErrAt if the text is not valid UTF-8.
+func Sprintf(form FormatConfig, format string, a ...any) (string, error) {
+	return sprint(form, fmt.Sprintf(format, a...))
+}
+
+// Sprintln formats its operands as fmt.Sprintln does, runs them through the
+// formatter, and returns the result as plain text. See Sprint.
+//
+// Parameters:
+//   - form: The formatter to use.
+//   - a: The operands to print.
+//
+// Returns:
+//   - string: The lines, separated by newlines and ending with one. Pages are
+//     separated by form feeds. Styles are dropped.
+//   - error: An error of type *Errors.ErrAt if the text is not valid UTF-8.
+func Sprintln(form FormatConfig, a ...any) (string, error) {
+	return sprint(form, fmt.Sprintln(a...))
+}
+
 //////////////////////////////////////////////////////////////
 /*
 const (
@@ -0,0 +1,176 @@
+package c_string
+
+import (
+	"io"
+	"iter"
+	"strings"
+
+	gcers "github.com/PlayerR9/go-errors"
+)
+
+// writePages writes the lines of pages to a writer. Every line ends with a
+// newline and pages are separated by an empty line.
+//
+// Parameters:
+//   - w: The writer to write to.
+//   - pages: The pages to write.
+//   - ansi: Whether the styles are converted to SGR escape codes.
+//
+// Returns:
+//   - int64: The number of bytes written.
+//   - error: The error of the writer, if any.
+func writePages(w io.Writer, pages [][][][][]*Unit, ansi bool) (int64, error) {
+	var builder strings.Builder
+
+	for i, page := range pages {
+		if i > 0 {
+			builder.WriteRune('\n')
+		}
+
+		for _, section := range page {
+			for _, line := range section {
+				builder.WriteString(renderLine(line, ansi))
+				builder.WriteRune('\n')
+			}
+		}
+	}
+
+	n, err := io.WriteString(w, builder.String())
+	return int64(n), err
+}
+
+// SetANSI sets whether WriteTo, and thus the flushes of FlushEvery, convert
+// the styles to SGR escape codes. By default, they write plain text.
+//
+// Parameters:
+//   - enabled: True to write SGR escape codes, false otherwise.
+func (p *Printer) SetANSI(enabled bool) {
+	p.ansi = enabled
+}
+
+// WriteTo implements the io.WriterTo interface. It writes the pages of the
+// printer as text, as RenderANSI does, with or without SGR escape codes
+// depending on SetANSI.
+//
+// Parameters:
+//   - w: The writer to write to.
+//
+// Returns:
+//   - int64: The number of bytes written.
+//   - error: An error of type *errors.ErrNilParameter if w is nil, or the
+//     error of the writer, if any.
+//
+// Behaviors:
+//   - Like GetPages, the printer is reset afterwards, unless w is nil.
+func (p *Printer) WriteTo(w io.Writer) (int64, error) {
+	if w == nil {
+		return 0, gcers.NewErrNilParameter("w")
+	}
+
+	return writePages(w, p.GetPages(), p.ansi)
+}
+
+// FlushEvery makes the printer write its lines to a writer, with WriteTo,
+// every time a number of lines have been accepted, so that a long-running
+// process can stream its output as it is produced.
+//
+// Parameters:
+//   - w: The writer to write to.
+//   - n: The number of lines. If less than 1, the lines are no longer
+//     flushed.
+//
+// Returns:
+//   - error: An error of type *errors.ErrNilParameter if w is nil and n is at
+//     least 1.
+//
+// Behaviors:
+//   - Lines are only flushed once they are complete. Call Flush to write the
+//     rest of the output.
+//   - Lines are aligned within the lines flushed with them, since the lines
+//     that follow are not known yet.
+//   - Once the writer fails, nothing is flushed anymore; Flush returns the
+//     error.
+func (p *Printer) FlushEvery(w io.Writer, n int) error {
+	if n < 1 {
+		p.flushW = nil
+		p.flushEvery = 0
+
+		return nil
+	}
+
+	if w == nil {
+		return gcers.NewErrNilParameter("w")
+	}
+
+	p.flushW = w
+	p.flushEvery = n
+	p.lines = 0
+
+	return nil
+}
+
+// Flush writes the rest of the output to the writer of FlushEvery.
+//
+// Returns:
+//   - error: The first error of the writer, if any. Nil if there is no
+//     writer.
+func (p *Printer) Flush() error {
+	if p.flushW == nil || p.flushErr != nil {
+		return p.flushErr
+	}
+
+	p.lines = 0
+
+	_, err := p.WriteTo(p.flushW)
+	if err != nil {
+		p.flushErr = err
+	}
+
+	return err
+}
+
+// lineAccepted is called by the buffer every time a line is accepted, and
+// flushes the lines when there are enough of them.
+func (p *Printer) lineAccepted() {
+	p.lines++
+
+	if p.flushEvery > 0 && p.lines >= p.flushEvery {
+		_ = p.Flush()
+	}
+}
+
+// Sections returns an iterator over the sections of the printer that are
+// complete, so that large documents can be rendered as they are written
+// rather than all at once with GetPages. The complete lines of the
+// in-progress section count as a section of their own; the line in progress
+// does not.
+//
+// Returns:
+//   - iter.Seq[[][][]*Unit]: The iterator. It yields the lines of every
+//     section, reordered and aligned as GetPages does, except that lines
+//     without a width are aligned within the widest line of their section.
+//
+// Behaviors:
+//   - Yielded sections are removed from the printer, so ranging over the
+//     iterator again, or calling GetPages, only gives what was written since.
+//   - Page breaks are not reported.
+func (p *Printer) Sections() iter.Seq[[][][]*Unit] {
+	return func(yield func([][][]*Unit) bool) {
+		for {
+			section, ok := p.buff.popSection()
+			if !ok {
+				return
+			}
+
+			widest := 0
+
+			for _, line := range section.getLines() {
+				widest = max(widest, lineWidth(line))
+			}
+
+			if !yield(section.alignedLines(widest)) {
+				return
+			}
+		}
+	}
+}
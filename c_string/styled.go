@@ -0,0 +1,261 @@
+package c_string
+
+import (
+	"errors"
+	"strings"
+
+	gcers "github.com/PlayerR9/go-errors"
+	"github.com/dustin/go-humanize"
+	"github.com/gdamore/tcell"
+)
+
+// StyledSpan is a type that represents a piece of text with its style.
+type StyledSpan struct {
+	// Text is the text of the span.
+	Text string
+
+	// Style is the style of the span.
+	Style tcell.Style
+}
+
+// AppendStyled appends styled spans to the half-line of the traversor. This
+// is equivalent to calling AppendString for each span.
+//
+// Parameters:
+//   - spans: The spans to append.
+//
+// Returns:
+//   - error: An error of type *Errors.ErrAt if there is an error appending a span.
+func (trav *Traversor) AppendStyled(spans ...StyledSpan) error {
+	if trav.source == nil || len(spans) == 0 {
+		return nil
+	}
+
+	for i, span := range spans {
+		err := trav.writeString(span.Text, span.Style)
+		if err != nil {
+			return gcers.NewErrAt(humanize.Ordinal(i+1)+" span", err)
+		}
+	}
+
+	return nil
+}
+
+// AppendLink appends a hyperlink to the half-line of the traversor.
+//
+// Parameters:
+//   - text: The text of the hyperlink.
+//   - url: The target of the hyperlink.
+//   - style: The style of the text.
+//
+// Returns:
+//   - error: An error of type *Errors.ErrAt if there is an invalid rune in
+//     the text.
+//
+// Behaviors:
+//   - The text is kept as a single word, even when words are wrapped.
+func (trav *Traversor) AppendLink(text, url string, style tcell.Style) error {
+	if trav.source == nil {
+		return nil
+	}
+
+	trav.writeIndent()
+
+	if text == "" {
+		return nil
+	}
+
+	n := checkString(text)
+	if n != -1 {
+		return gcers.NewErrAt(humanize.Ordinal(n+1)+" rune", errors.New("not proper UTF-8 encoding"))
+	}
+
+	trav.source.writeUnits([]*Unit{NewLinkUnit(text, url, style)})
+
+	return nil
+}
+
+// AppendNamed appends text to the half-line of the traversor, styled with the
+// style of the given name in the theme of the traversor.
+//
+// Parameters:
+//   - name: The name of the style, such as StyleError.
+//   - text: The text to append.
+//
+// Returns:
+//   - error: An error of type *Errors.ErrInvalidRuneAt if there is an invalid rune
+//     in the text.
+//
+// Behaviors:
+//   - If the formatter of the traversor has no Theme, DefaultTheme is used.
+//   - See Theme.Style for the style used when the name is not in the theme.
+func (trav *Traversor) AppendNamed(name, text string) error {
+	if trav.source == nil {
+		return nil
+	}
+
+	return trav.writeString(text, trav.Theme().Style(name))
+}
+
+// Theme returns the theme of the traversor.
+//
+// Returns:
+//   - Theme: The theme given to the formatter of the traversor, or
+//     DefaultTheme if there is none. It must not be modified.
+func (trav *Traversor) Theme() Theme {
+	theme, ok := trav.form[ConfTheme_Idx].(Theme)
+	if !ok || theme == nil {
+		return DefaultTheme()
+	}
+
+	return theme
+}
+
+// AppendMarkup appends text written in the markup of ParseMarkup to the
+// half-line of the traversor.
+//
+// Parameters:
+//   - markup: The text to append.
+//   - base: The style of the text outside of any tag.
+//
+// Returns:
+//   - error: An error of type *Errors.ErrAt if there is an error appending a span.
+func (trav *Traversor) AppendMarkup(markup string, base tcell.Style) error {
+	return trav.AppendStyled(ParseMarkup(markup, base)...)
+}
+
+// ParseMarkup is a function that parses text written in a lightweight markup
+// into styled spans, such as "[red]error[/red]: message". Tags are:
+//   - [bold], [italic], [underline], [dim], [blink], and [reverse] for
+//     attributes.
+//   - [<color>] for the foreground, where <color> is a name known to tcell
+//     (e.g., "red") or a hexadecimal color (e.g., "#ff8800").
+//   - [bg:<color>] for the background.
+//
+// Every tag is closed by the same tag with a slash (e.g., "[/red]") and tags
+// can be nested.
+//
+// Parameters:
+//   - markup: The text to parse.
+//   - base: The style of the text outside of any tag.
+//
+// Returns:
+//   - []StyledSpan: The spans of the text. Nil if the text is empty.
+//
+// Behaviors:
+//   - Unknown tags, and closing tags that do not close the innermost open tag,
+//     are kept as text, so text such as "[1/3]" needs no escaping.
+//   - A backslash makes the next character text (e.g., "\[red]").
+//   - Tags left open are closed at the end of the text.
+func ParseMarkup(markup string, base tcell.Style) []StyledSpan {
+	var spans []StyledSpan
+
+	var builder strings.Builder
+
+	stack := []tcell.Style{base}
+	var tags []string
+
+	emit := func() {
+		if builder.Len() > 0 {
+			spans = append(spans, StyledSpan{
+				Text:  builder.String(),
+				Style: stack[len(stack)-1],
+			})
+
+			builder.Reset()
+		}
+	}
+
+	for len(markup) > 0 {
+		switch {
+		case markup[0] == '\\' && len(markup) > 1:
+			builder.WriteByte(markup[1])
+			markup = markup[2:]
+
+			continue
+		case markup[0] != '[':
+			builder.WriteByte(markup[0])
+			markup = markup[1:]
+
+			continue
+		}
+
+		end := strings.IndexByte(markup, ']')
+		if end == -1 {
+			builder.WriteString(markup)
+			break
+		}
+
+		tag := markup[1:end]
+
+		if name, ok := strings.CutPrefix(tag, "/"); ok {
+			if len(tags) > 0 && tags[len(tags)-1] == name {
+				emit()
+
+				stack = stack[:len(stack)-1]
+				tags = tags[:len(tags)-1]
+
+				markup = markup[end+1:]
+				continue
+			}
+		} else if style, ok := applyTag(stack[len(stack)-1], tag); ok {
+			emit()
+
+			stack = append(stack, style)
+			tags = append(tags, tag)
+
+			markup = markup[end+1:]
+			continue
+		}
+
+		// Not a tag: keep the bracket as text.
+		builder.WriteByte('[')
+		markup = markup[1:]
+	}
+
+	emit()
+
+	return spans
+}
+
+// applyTag applies a tag of ParseMarkup to a style.
+//
+// Parameters:
+//   - style: The style.
+//   - tag: The name of the tag, without its brackets.
+//
+// Returns:
+//   - tcell.Style: The style with the tag applied.
+//   - bool: True if the tag is known, false otherwise.
+func applyTag(style tcell.Style, tag string) (tcell.Style, bool) {
+	switch tag {
+	case "bold":
+		return style.Bold(true), true
+	case "italic":
+		return style.Italic(true), true
+	case "underline":
+		return style.Underline(true), true
+	case "dim":
+		return style.Dim(true), true
+	case "blink":
+		return style.Blink(true), true
+	case "reverse":
+		return style.Reverse(true), true
+	}
+
+	if name, ok := strings.CutPrefix(tag, "bg:"); ok {
+		color := tcell.GetColor(name)
+		if color == tcell.ColorDefault {
+			return style, false
+		}
+
+		return style.Background(color), true
+	}
+
+	color := tcell.GetColor(tag)
+	if color == tcell.ColorDefault {
+		return style, false
+	}
+
+	return style.Foreground(color), true
+}
@@ -0,0 +1,384 @@
+package c_string
+
+import (
+	"fmt"
+	"strings"
+
+	gcers "github.com/PlayerR9/go-errors"
+	"github.com/dustin/go-humanize"
+	"github.com/gdamore/tcell"
+)
+
+// TableBorder is a type that represents the runes used to draw the border of
+// a table.
+type TableBorder struct {
+	// Horizontal is the rune of the top and bottom edges.
+	Horizontal rune
+
+	// Vertical is the rune of the left and right edges, and of the lines
+	// between the columns.
+	Vertical rune
+
+	// TopLeft, TopJoin, and TopRight are the runes of the top edge at the
+	// left corner, where a column line meets it, and at the right corner.
+	TopLeft, TopJoin, TopRight rune
+
+	// BottomLeft, BottomJoin, and BottomRight are the runes of the bottom
+	// edge at the left corner, where a column line meets it, and at the right
+	// corner.
+	BottomLeft, BottomJoin, BottomRight rune
+}
+
+var (
+	// LineBorder is a border drawn with box-drawing characters.
+	//
+	//	┌───┬───┐
+	//	│ a │ b │
+	//	└───┴───┘
+	LineBorder *TableBorder = &TableBorder{
+		Horizontal:  '─',
+		Vertical:    '│',
+		TopLeft:     '┌',
+		TopJoin:     '┬',
+		TopRight:    '┐',
+		BottomLeft:  '└',
+		BottomJoin:  '┴',
+		BottomRight: '┘',
+	}
+
+	// ASCIIBorder is a border drawn with ASCII characters only.
+	//
+	//	+---+---+
+	//	| a | b |
+	//	+---+---+
+	ASCIIBorder *TableBorder = &TableBorder{
+		Horizontal:  '-',
+		Vertical:    '|',
+		TopLeft:     '+',
+		TopJoin:     '+',
+		TopRight:    '+',
+		BottomLeft:  '+',
+		BottomJoin:  '+',
+		BottomRight: '+',
+	}
+)
+
+// Table is a type that lays out cells in rows and columns. The columns are
+// as wide as their widest cell. A Table implements the CStringer interface,
+// so it is printed like any other element.
+type Table struct {
+	// rows are the rows of the table. Every cell is a list of lines.
+	rows [][][][]*Unit
+
+	// styles are the styles of the columns. Missing styles are the default one.
+	styles []tcell.Style
+
+	// border is the border of the table. Nil if there is none.
+	border *TableBorder
+
+	// borderStyle is the style of the border.
+	borderStyle tcell.Style
+}
+
+// NewTable creates a new table without rows nor borders.
+//
+// Returns:
+//   - *Table: The new table.
+func NewTable() *Table {
+	return &Table{}
+}
+
+// SetColumnStyle sets the style of the cells of a column that are added as
+// strings afterwards.
+//
+// Parameters:
+//   - col: The index of the column.
+//   - style: The style of the column.
+//
+// Returns:
+//   - error: An error of type *errors.ErrInvalidParameter if col is negative.
+func (t *Table) SetColumnStyle(col int, style tcell.Style) error {
+	if col < 0 {
+		return gcers.NewErrInvalidParameter(fmt.Sprintf("col must be non-negative, got %d", col))
+	}
+
+	for len(t.styles) <= col {
+		t.styles = append(t.styles, tcell.StyleDefault)
+	}
+
+	t.styles[col] = style
+
+	return nil
+}
+
+// SetBorder sets the border of the table.
+//
+// Parameters:
+//   - border: The border. If nil, the table has no border and its columns
+//     are separated by one space.
+//   - style: The style of the border.
+func (t *Table) SetBorder(border *TableBorder, style tcell.Style) {
+	t.border = border
+	t.borderStyle = style
+}
+
+// columnStyle returns the style of a column.
+//
+// Parameters:
+//   - col: The index of the column.
+//
+// Returns:
+//   - tcell.Style: The style of the column.
+func (t *Table) columnStyle(col int) tcell.Style {
+	if col < len(t.styles) {
+		return t.styles[col]
+	}
+
+	return tcell.StyleDefault
+}
+
+// AddRow adds a row of strings. Every cell is styled with the style of its
+// column, and the newlines of a cell split it into several lines.
+//
+// Parameters:
+//   - cells: The cells of the row. Rows may have different numbers of cells;
+//     missing cells are empty.
+func (t *Table) AddRow(cells ...string) {
+	row := make([][][]*Unit, 0, len(cells))
+
+	for i, cell := range cells {
+		style := t.columnStyle(i)
+
+		var lines [][]*Unit
+
+		for _, line := range strings.Split(cell, "\n") {
+			lines = append(lines, []*Unit{NewUnit(line, style)})
+		}
+
+		row = append(row, lines)
+	}
+
+	t.rows = append(t.rows, row)
+}
+
+// AddRowFrom adds a row of elements. Every cell holds the lines of its
+// element, printed with the given formatter and keeping their own styles.
+//
+// Parameters:
+//   - form: The formatter used to print the elements.
+//   - cells: The cells of the row. Rows may have different numbers of cells;
+//     missing cells are empty.
+//
+// Returns:
+//   - error: An error of type *Errors.ErrAt if an element cannot be printed.
+//     The row is then not added.
+func (t *Table) AddRowFrom(form FormatConfig, cells ...CStringer) error {
+	row := make([][][]*Unit, 0, len(cells))
+
+	for i, cell := range cells {
+		p := NewPrinter(form)
+
+		err := Apply(p, cell)
+		if err != nil {
+			return gcers.NewErrAt(humanize.Ordinal(i+1)+" cell", err)
+		}
+
+		var lines [][]*Unit
+
+		for _, page := range p.GetPages() {
+			for _, section := range page {
+				for _, line := range section {
+					lines = append(lines, joinWords(line))
+				}
+			}
+		}
+
+		// Trailing empty lines do not make the cell taller.
+		for len(lines) > 0 && len(lines[len(lines)-1]) == 0 {
+			lines = lines[:len(lines)-1]
+		}
+
+		row = append(row, lines)
+	}
+
+	t.rows = append(t.rows, row)
+
+	return nil
+}
+
+// joinWords joins the words of a line, separated by one space.
+//
+// Parameters:
+//   - line: The words of the line.
+//
+// Returns:
+//   - []*Unit: The units of the line.
+func joinWords(line [][]*Unit) []*Unit {
+	var units []*Unit
+
+	for i, word := range line {
+		if i > 0 {
+			units = append(units, NewUnit(" ", tcell.StyleDefault))
+		}
+
+		units = append(units, word...)
+	}
+
+	return units
+}
+
+// columnWidths returns the width of every column of the table.
+//
+// Returns:
+//   - []int: The width of every column, in display columns.
+func (t *Table) columnWidths() []int {
+	var widths []int
+
+	for _, row := range t.rows {
+		for i, cell := range row {
+			if i == len(widths) {
+				widths = append(widths, 0)
+			}
+
+			for _, line := range cell {
+				widths[i] = max(widths[i], wordColumn(0, line))
+			}
+		}
+	}
+
+	return widths
+}
+
+// edge returns a horizontal edge of the border.
+//
+// Parameters:
+//   - widths: The width of every column.
+//   - left: The rune at the left corner.
+//   - join: The rune where a column line meets the edge.
+//   - right: The rune at the right corner.
+//
+// Returns:
+//   - []*Unit: The units of the edge.
+func (t *Table) edge(widths []int, left, join, right rune) []*Unit {
+	var builder strings.Builder
+
+	builder.WriteRune(left)
+
+	for i, width := range widths {
+		if i > 0 {
+			builder.WriteRune(join)
+		}
+
+		builder.WriteString(strings.Repeat(string(t.border.Horizontal), width+2))
+	}
+
+	builder.WriteRune(right)
+
+	return []*Unit{NewUnit(builder.String(), t.borderStyle)}
+}
+
+// CString implements the CStringer interface. Every line of the table is
+// written as a line of the traversor, indented as the traversor is.
+func (t *Table) CString(trav *Traversor) error {
+	if trav == nil || len(t.rows) == 0 {
+		return nil
+	}
+
+	widths := t.columnWidths()
+
+	if t.border != nil {
+		trav.writeUnitLine(t.edge(widths, t.border.TopLeft, t.border.TopJoin, t.border.TopRight))
+	}
+
+	var vertical *Unit
+
+	if t.border != nil {
+		vertical = NewUnit(string(t.border.Vertical), t.borderStyle)
+	}
+
+	for _, row := range t.rows {
+		height := 1
+
+		for _, cell := range row {
+			height = max(height, len(cell))
+		}
+
+		for y := 0; y < height; y++ {
+			var units []*Unit
+
+			if t.border != nil {
+				units = append(units, vertical.Copy(), NewUnit(" ", tcell.StyleDefault))
+			}
+
+			for i, width := range widths {
+				if i > 0 {
+					if t.border != nil {
+						units = append(units, NewUnit(" ", tcell.StyleDefault), vertical.Copy())
+					}
+
+					units = append(units, NewUnit(" ", tcell.StyleDefault))
+				}
+
+				var line []*Unit
+
+				if i < len(row) && y < len(row[i]) {
+					line = row[i][y]
+				}
+
+				for _, unit := range line {
+					units = append(units, unit.Copy())
+				}
+
+				// Without a border, the last column is not padded so that
+				// the lines do not end with spaces.
+				if t.border == nil && i == len(widths)-1 {
+					continue
+				}
+
+				if pad := width - wordColumn(0, line); pad > 0 {
+					units = append(units, NewUnit(strings.Repeat(" ", pad), tcell.StyleDefault))
+				}
+			}
+
+			if t.border != nil {
+				units = append(units, NewUnit(" ", tcell.StyleDefault), vertical.Copy())
+			}
+
+			units = ReduceUnitSequence(units)
+
+			if t.border == nil {
+				units = trimTrailingSpaces(units)
+			}
+
+			trav.writeUnitLine(units)
+		}
+	}
+
+	if t.border != nil {
+		trav.writeUnitLine(t.edge(widths, t.border.BottomLeft, t.border.BottomJoin, t.border.BottomRight))
+	}
+
+	return nil
+}
+
+// trimTrailingSpaces removes the spaces at the end of a line.
+//
+// Parameters:
+//   - units: The units of the line. The last ones may be modified.
+//
+// Returns:
+//   - []*Unit: The units of the line without the trailing spaces.
+func trimTrailingSpaces(units []*Unit) []*Unit {
+	for len(units) > 0 {
+		last := units[len(units)-1]
+
+		last.Content = strings.TrimRight(last.Content, " ")
+		if last.Content != "" {
+			break
+		}
+
+		units = units[:len(units)-1]
+	}
+
+	return units
+}
@@ -0,0 +1,147 @@
+package c_string
+
+import (
+	"github.com/gdamore/tcell"
+)
+
+const (
+	// StyleText is the name of the style of plain text.
+	StyleText string = "text"
+
+	// StyleHeading is the name of the style of headings.
+	StyleHeading string = "heading"
+
+	// StyleEmphasis is the name of the style of emphasized text.
+	StyleEmphasis string = "emphasis"
+
+	// StyleStrong is the name of the style of strongly emphasized text.
+	StyleStrong string = "strong"
+
+	// StyleCode is the name of the style of code.
+	StyleCode string = "code"
+
+	// StyleBullet is the name of the style of the markers of list items.
+	StyleBullet string = "bullet"
+
+	// StyleError is the name of the style of errors.
+	StyleError string = "error"
+
+	// StyleWarning is the name of the style of warnings.
+	StyleWarning string = "warning"
+
+	// StyleSuccess is the name of the style of successes.
+	StyleSuccess string = "success"
+
+	// StyleMuted is the name of the style of secondary text.
+	StyleMuted string = "muted"
+
+	// StyleKeyword is the name of the style of the keywords of source code.
+	StyleKeyword string = "keyword"
+
+	// StyleString is the name of the style of the strings of source code.
+	StyleString string = "string"
+
+	// StyleNumber is the name of the style of the numbers of source code.
+	StyleNumber string = "number"
+
+	// StyleComment is the name of the style of the comments of source code.
+	StyleComment string = "comment"
+
+	// StyleDiffAdded is the name of the style of the added lines of diffs.
+	StyleDiffAdded string = "diff-added"
+
+	// StyleDiffRemoved is the name of the style of the removed lines of diffs.
+	StyleDiffRemoved string = "diff-removed"
+
+	// StyleDiffContext is the name of the style of the unchanged lines of
+	// diffs.
+	StyleDiffContext string = "diff-context"
+
+	// StyleDiffAddedChange is the name of the style of the changed parts of
+	// the added lines of diffs.
+	StyleDiffAddedChange string = "diff-added-change"
+
+	// StyleDiffRemovedChange is the name of the style of the changed parts of
+	// the removed lines of diffs.
+	StyleDiffRemovedChange string = "diff-removed-change"
+
+	// StyleDiffHunk is the name of the style of the headers of the hunks of
+	// diffs.
+	StyleDiffHunk string = "diff-hunk"
+)
+
+// Theme is a type that maps semantic names of styles, such as StyleError or
+// StyleHeading, to styles. Elements refer to styles by name so that the
+// whole application can be restyled by changing the theme. A Theme can be
+// given to NewFormatter, and is then used by Traversor.AppendNamed.
+type Theme map[string]tcell.Style
+
+// DefaultTheme is a function that returns the default theme.
+//
+// Returns:
+//   - Theme: A new theme with a style for every name declared by this package.
+func DefaultTheme() Theme {
+	return Theme{
+		StyleText:     tcell.StyleDefault,
+		StyleHeading:  tcell.StyleDefault.Bold(true).Underline(true),
+		StyleEmphasis: tcell.StyleDefault.Italic(true),
+		StyleStrong:   tcell.StyleDefault.Bold(true),
+		StyleCode:     tcell.StyleDefault.Foreground(tcell.ColorTeal),
+		StyleBullet:   tcell.StyleDefault.Foreground(tcell.ColorYellow),
+		StyleError:    tcell.StyleDefault.Foreground(tcell.ColorRed).Bold(true),
+		StyleWarning:  tcell.StyleDefault.Foreground(tcell.ColorYellow),
+		StyleSuccess:  tcell.StyleDefault.Foreground(tcell.ColorGreen),
+		StyleMuted:    tcell.StyleDefault.Dim(true),
+		StyleKeyword:  tcell.StyleDefault.Foreground(tcell.ColorPurple).Bold(true),
+		StyleString:   tcell.StyleDefault.Foreground(tcell.ColorGreen),
+		StyleNumber:   tcell.StyleDefault.Foreground(tcell.ColorOlive),
+		StyleComment:  tcell.StyleDefault.Foreground(tcell.ColorGray).Italic(true),
+
+		StyleDiffAdded:         tcell.StyleDefault.Foreground(tcell.ColorGreen),
+		StyleDiffRemoved:       tcell.StyleDefault.Foreground(tcell.ColorRed),
+		StyleDiffContext:       tcell.StyleDefault,
+		StyleDiffAddedChange:   tcell.StyleDefault.Foreground(tcell.ColorGreen).Reverse(true),
+		StyleDiffRemovedChange: tcell.StyleDefault.Foreground(tcell.ColorRed).Reverse(true),
+		StyleDiffHunk:          tcell.StyleDefault.Foreground(tcell.ColorTeal),
+	}
+}
+
+// Copy is a method of uc.Copier interface.
+//
+// Returns:
+//   - Theme: A copy of the theme.
+func (t Theme) Copy() Theme {
+	if t == nil {
+		return nil
+	}
+
+	themeCopy := make(Theme, len(t))
+
+	for name, style := range t {
+		themeCopy[name] = style
+	}
+
+	return themeCopy
+}
+
+// Style is a method that returns the style with the given name.
+//
+// Parameters:
+//   - name: The name of the style.
+//
+// Returns:
+//   - tcell.Style: The style. The style of StyleText if there is none with
+//     that name, or tcell.StyleDefault if there is neither.
+func (t Theme) Style(name string) tcell.Style {
+	style, ok := t[name]
+	if ok {
+		return style
+	}
+
+	style, ok = t[StyleText]
+	if ok {
+		return style
+	}
+
+	return tcell.StyleDefault
+}
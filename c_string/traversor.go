@@ -83,11 +83,25 @@ type Traversor struct {
 	// hasIndent is a flag that indicates if the traversor has indentation.
 	hasIndent bool
 
+	// prefix are the units written at the start of every line, before the
+	// indentation.
+	prefix []*Unit
+
+	// suffix are the units written at the end of every line.
+	suffix []*Unit
+
 	// source is the buffer of the traversor.
 	source *buffer
 
 	// form is the formatter of the traversor.
 	form FormatConfig
+
+	// layout is how the lines written by the traversor are laid out.
+	layout layout
+
+	// height is the number of lines after which a new page is started. 0 if
+	// pages are only started by form feeds.
+	height int
 }
 
 // Cleanup implements the Cleaner interface.
@@ -110,31 +124,69 @@ func newTraversor(config FormatConfig, source *buffer) *Traversor {
 		form:   config,
 	}
 
-	indentConfig, ok := config[ConfInd_Idx].(*IndentConfig)
-	if !ok {
-		panic(fmt.Errorf("invalid configuration type for indentation: %T", config[ConfInd_Idx]))
-	}
+	indentConfig := getIndentConfig(config)
 
 	if indentConfig == nil {
 		trav.hasIndent = false
 		trav.indentation = nil
 	} else {
 		trav.indentation = indentConfig.GetIndentation()
-		trav.hasIndent = true
+		trav.hasIndent = len(trav.indentation) > 0
+		trav.indentStr = indentConfig.units
+	}
+
+	widthConfig, ok := config[ConfWidth_Idx].(*WidthConfig)
+	if ok && widthConfig != nil {
+		trav.layout.width = widthConfig.width
+	}
+
+	alignConfig, ok := config[ConfAlign_Idx].(*AlignConfig)
+	if ok && alignConfig != nil {
+		trav.layout.align = alignConfig.align
+	}
+
+	directionConfig, ok := config[ConfDir_Idx].(*DirectionConfig)
+	if ok && directionConfig != nil {
+		trav.layout.direction = directionConfig.direction
+	}
+
+	pageConfig, ok := config[ConfPage_Idx].(*PageConfig)
+	if ok && pageConfig != nil {
+		trav.height = pageConfig.height
 	}
 
-	trav.indentStr = indentConfig.units
+	decoratorConfig, ok := config[ConfDecor_Idx].(*DecoratorConfig)
+	if ok && decoratorConfig != nil {
+		trav.prefix = decoratorConfig.prefix
+		trav.suffix = decoratorConfig.suffix
+	}
 
 	return trav
 }
 
 // writeIndent writes the indentation string to the traversor if
 // the traversor has indentation and the traversor is at the first
-// of the line.
+// of the line. The same goes for the prefix of the decorator, which
+// is written before the indentation, and for its suffix, which is
+// written once the line is accepted. The source is also made to lay
+// out lines and pages as configured for the traversor, since it may
+// be shared with other traversors.
 func (trav *Traversor) writeIndent() {
-	if trav.hasIndent && trav.source.isFirstOfLine() {
-		trav.source.writeIndent(trav.indentStr)
+	trav.source.setLayout(trav.layout, trav.height)
+
+	if !trav.hasIndent && len(trav.prefix) == 0 && len(trav.suffix) == 0 {
+		return
+	}
+
+	if !trav.source.isFirstOfLine() {
+		return
 	}
+
+	indent := make([]*Unit, 0, len(trav.prefix)+len(trav.indentation))
+	indent = append(indent, trav.prefix...)
+	indent = append(indent, trav.indentation...)
+
+	trav.source.writeIndent(ReduceUnitSequence(indent), trav.suffix)
 }
 
 // writeRune appends a rune to the current, in-progress line of the traversor.
@@ -188,26 +240,115 @@ func (trav *Traversor) writeString(str string, style tcell.Style) error {
 // Behaviors:
 //   - If line is empty, then an empty line is added to the source.
 func (trav *Traversor) writeLine(line string, style tcell.Style) error {
-	trav.source.acceptLine() // Accept the current line if any.
+	if !trav.source.isFirstOfLine() {
+		trav.source.acceptLine() // Accept the current line if any.
+	}
 
 	trav.writeIndent()
 
 	if line == "" {
 		trav.source.writeEmptyLine()
-	} else {
-		n := checkString(line)
-		if n != -1 {
-			return gcers.NewErrAt(humanize.Ordinal(n+1)+" rune", errors.New("not proper UTF-8 encoding"))
-		}
+		return nil
+	}
 
-		trav.source.writeString(line, style)
+	n := checkString(line)
+	if n != -1 {
+		return gcers.NewErrAt(humanize.Ordinal(n+1)+" rune", errors.New("not proper UTF-8 encoding"))
 	}
 
+	trav.source.writeString(line, style)
+
 	trav.source.acceptLine() // Accept the line.
 
 	return nil
 }
 
+// writeUnitLine writes a line of units to the traversor as a single word, so
+// that its spaces are kept as they are. Any in-progress line is accepted first.
+//
+// Parameters:
+//   - units: The units of the line.
+func (trav *Traversor) writeUnitLine(units []*Unit) {
+	if !trav.source.isFirstOfLine() {
+		trav.source.acceptLine() // Accept the current line if any.
+	}
+
+	trav.writeIndent()
+
+	if len(units) == 0 {
+		trav.source.writeEmptyLine()
+		return
+	}
+
+	trav.source.writeUnits(units)
+
+	trav.source.acceptLine() // Accept the line.
+}
+
+// writeWordLine writes a line of words to the traversor, keeping the words
+// apart so that they can still be wrapped. Any in-progress line is accepted
+// first.
+//
+// Parameters:
+//   - words: The words of the line.
+func (trav *Traversor) writeWordLine(words [][]*Unit) {
+	if !trav.source.isFirstOfLine() {
+		trav.source.acceptLine() // Accept the current line if any.
+	}
+
+	trav.writeIndent()
+
+	if len(words) == 0 {
+		trav.source.writeEmptyLine()
+		return
+	}
+
+	for _, word := range words {
+		trav.source.writeUnits(word)
+		trav.source.acceptWord()
+	}
+
+	trav.source.acceptLine() // Accept the line.
+}
+
+// Embed splices the pages of another printer into the traversor, as lines
+// written at the current position. The lines are indented as the traversor
+// is, and every page after the first starts a new page.
+//
+// Parameters:
+//   - sub: The printer to embed. Like GetPages, it is reset afterwards.
+//
+// Returns:
+//   - error: An error of type *errors.ErrNilParameter if sub is nil.
+//
+// Behaviors:
+//   - Any in-progress line is accepted first.
+func (trav *Traversor) Embed(sub *Printer) error {
+	if sub == nil {
+		return gcers.NewErrNilParameter("sub")
+	}
+
+	pages := sub.GetPages()
+
+	if trav.source == nil {
+		return nil
+	}
+
+	for i, page := range pages {
+		if i > 0 {
+			trav.source.write('\f', tcell.StyleDefault)
+		}
+
+		for _, section := range page {
+			for _, line := range section {
+				trav.writeWordLine(line)
+			}
+		}
+	}
+
+	return nil
+}
+
 // AppendRune appends a rune to the half-line of the traversor.
 //
 // Parameters:
@@ -399,7 +540,9 @@ func (trav *Traversor) EmptyLine() {
 		return
 	}
 
-	trav.source.acceptLine() // Accept the current line if any.
+	if !trav.source.isFirstOfLine() {
+		trav.source.acceptLine() // Accept the current line if any.
+	}
 
 	trav.writeIndent()
 
@@ -421,6 +564,8 @@ func (trav *Traversor) Write(p []byte) (int, error) {
 		style = config.defaultStyle
 	}
 
+	trav.source.setLayout(trav.layout, trav.height)
+
 	trav.source.writeString(string(p), style)
 
 	return len(p), nil
@@ -469,6 +614,29 @@ func (trav *Traversor) Println(a ...interface{}) error {
 // ConfigOption is a type that represents a configuration option for a formatter.
 type ConfigOption func(FormatConfig)
 
+// getIndentConfig returns the indentation configuration of a formatter.
+//
+// Parameters:
+//   - f: The formatter.
+//
+// Returns:
+//   - *IndentConfig: The indentation configuration. Nil if there is none.
+//
+// Behaviors:
+//   - The function panics if the configuration is not an *IndentConfig.
+func getIndentConfig(f FormatConfig) *IndentConfig {
+	if f[ConfInd_Idx] == nil {
+		return nil
+	}
+
+	config, ok := f[ConfInd_Idx].(*IndentConfig)
+	if !ok {
+		panic(fmt.Errorf("invalid configuration type for indentation: %T", f[ConfInd_Idx]))
+	}
+
+	return config
+}
+
 // WithIncreasedIndent is a function that increases the indentation level of the formatter
 // by one.
 //
@@ -476,10 +644,7 @@ type ConfigOption func(FormatConfig)
 //   - ConfigOption: The configuration option.
 func WithIncreasedIndent() ConfigOption {
 	return func(f FormatConfig) {
-		config, ok := f[ConfInd_Idx].(*IndentConfig)
-		if !ok {
-			panic(fmt.Errorf("invalid configuration type for indentation: %T", f[ConfInd_Idx]))
-		}
+		config := getIndentConfig(f)
 
 		if config != nil {
 			config.level++
@@ -497,10 +662,7 @@ func WithIncreasedIndent() ConfigOption {
 //   - If the indentation level is already 0, it is not decreased.
 func WithDecreasedIndent() ConfigOption {
 	return func(f FormatConfig) {
-		config, ok := f[ConfInd_Idx].(*IndentConfig)
-		if !ok {
-			panic(fmt.Errorf("invalid configuration type for indentation: %T", f[ConfInd_Idx]))
-		}
+		config := getIndentConfig(f)
 
 		if config != nil && config.level > 0 {
 			config.level--
@@ -526,10 +688,7 @@ func WithModifiedIndent(by int) ConfigOption {
 		return func(f FormatConfig) {}
 	} else {
 		return func(f FormatConfig) {
-			config, ok := f[ConfInd_Idx].(*IndentConfig)
-			if !ok {
-				panic(fmt.Errorf("invalid configuration type for indentation: %T", f[ConfInd_Idx]))
-			}
+			config := getIndentConfig(f)
 
 			if config == nil {
 				return
@@ -550,13 +709,12 @@ func WithModifiedIndent(by int) ConfigOption {
 //
 // Returns:
 //   - FormatConfig: A copy of the configuration of the traversor.
+//
+// Behaviors:
+//   - The options are applied to the copy only, so that a derived configuration
+//     (e.g., WithIncreasedIndent for nested elements) does not affect the traversor.
 func (trav *Traversor) GetConfig(options ...ConfigOption) FormatConfig {
-	var configCopy FormatConfig
-
-	// FIXME: This is a hack.
-	/* for i := 0; i < 4; i++ {
-		configCopy[i] = trav.config[i].Copy()
-	} */
+	configCopy := trav.config.Copy()
 
 	for _, option := range options {
 		option(configCopy)
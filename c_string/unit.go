@@ -0,0 +1,289 @@
+package c_string
+
+import (
+	"unicode/utf8"
+
+	"github.com/gdamore/tcell"
+	"github.com/mattn/go-runewidth"
+)
+
+// Unit is a unit of content that can be displayed.
+type Unit struct {
+	// Content is the content of the unit.
+	Content string
+
+	// Style is the style of the unit.
+	Style tcell.Style
+
+	// URL is the target of the unit when it is a hyperlink. Empty if the unit
+	// is not a hyperlink.
+	URL string
+}
+
+// Copy is a method that creates a copy of the unit.
+//
+// Returns:
+//   - *Unit: The copy of the unit.
+func (u *Unit) Copy() *Unit {
+	return &Unit{
+		Content: u.Content,
+		Style:   u.Style,
+		URL:     u.URL,
+	}
+}
+
+// NewUnit is a function that creates a new unit.
+//
+// Parameters:
+//   - content: The content of the new unit.
+//   - style: The style of the new unit.
+//
+// Returns:
+//   - *Unit: The new unit.
+func NewUnit(content string, style tcell.Style) *Unit {
+	return &Unit{
+		Content: content,
+		Style:   style,
+	}
+}
+
+// NewLinkUnit is a function that creates a new hyperlink unit.
+//
+// Parameters:
+//   - content: The content of the new unit.
+//   - url: The target of the hyperlink.
+//   - style: The style of the new unit.
+//
+// Returns:
+//   - *Unit: The new unit.
+func NewLinkUnit(content, url string, style tcell.Style) *Unit {
+	return &Unit{
+		Content: content,
+		Style:   style,
+		URL:     url,
+	}
+}
+
+// Merge is a method that merges the content of the unit with another unit
+// if the styles and the URLs are the same.
+//
+// Parameters:
+//   - other: The other unit to merge with.
+//
+// Returns:
+//   - bool: True if the merge was successful, false otherwise.
+func (u *Unit) Merge(other *Unit) bool {
+	if other == nil {
+		return true
+	}
+
+	if u.Style != other.Style || u.URL != other.URL {
+		return false
+	}
+
+	u.Content += other.Content
+
+	return true
+}
+
+// removeLastRune removes the last rune from the content of the unit.
+//
+// Returns:
+//   - bool: True if the last rune was removed, false otherwise.
+func (u *Unit) removeLastRune() bool {
+	if len(u.Content) == 0 {
+		return false
+	}
+
+	_, size := utf8.DecodeLastRuneInString(u.Content)
+	u.Content = u.Content[:len(u.Content)-size]
+
+	return true
+}
+
+// ReduceUnitSequence is a function that removes the nil units of a sequence
+// and merges the consecutive units that have the same style.
+//
+// Parameters:
+//   - units: The units to reduce. They are not modified.
+//
+// Returns:
+//   - []*Unit: The reduced units. They are copies of the given ones.
+func ReduceUnitSequence(units []*Unit) []*Unit {
+	if len(units) == 0 {
+		return units
+	}
+
+	newSequence := make([]*Unit, 0, len(units))
+
+	for _, unit := range units {
+		// 1. Remove any nil units
+		if unit == nil {
+			continue
+		}
+
+		// 2. Merge units with the same style
+		if len(newSequence) > 0 {
+			last := newSequence[len(newSequence)-1]
+
+			if last.Merge(unit) {
+				continue
+			}
+		}
+
+		newSequence = append(newSequence, unit.Copy())
+	}
+
+	return newSequence
+}
+
+// wordColumn returns the column reached after writing a word. Tabs move to
+// the next tab stop and wide runes take two columns.
+//
+// Parameters:
+//   - col: The column where the word starts.
+//   - word: The units of the word. Nil units are skipped.
+//
+// Returns:
+//   - int: The column after the last rune of the word.
+func wordColumn(col int, word []*Unit) int {
+	for _, unit := range word {
+		if unit == nil {
+			continue
+		}
+
+		for _, r := range unit.Content {
+			col = runeColumn(col, r)
+		}
+	}
+
+	return col
+}
+
+// runeColumn returns the column reached after writing a rune: wide runes,
+// such as CJK ideographs, take two columns, zero-width ones, such as
+// combining marks, take none, and tabs move to the next tab stop.
+//
+// Parameters:
+//   - col: The column where the rune starts.
+//   - r: The rune.
+//
+// Returns:
+//   - int: The column after the rune.
+func runeColumn(col int, r rune) int {
+	if r == '\t' {
+		return col + TabWidth - col%TabWidth
+	}
+
+	return col + runewidth.RuneWidth(r)
+}
+
+// Width is a function that returns the number of display columns that units
+// take, which is neither their number of bytes nor of runes.
+//
+// Parameters:
+//   - units: The units. Nil units are skipped.
+//
+// Returns:
+//   - int: The number of columns. Tabs move to the next multiple of TabWidth.
+func Width(units []*Unit) int {
+	return wordColumn(0, units)
+}
+
+// Truncate is a function that shortens units so that they fit in a number of
+// display columns.
+//
+// Parameters:
+//   - units: The units to truncate. They are not modified.
+//   - width: The number of columns.
+//   - tail: The text that ends the units when they are truncated, such as
+//     "…". It takes the style of the last unit that is kept.
+//
+// Returns:
+//   - []*Unit: The truncated units. They are copies of the given ones.
+//
+// Behaviors:
+//   - A wide rune that does not fit entirely is dropped rather than split,
+//     and the zero-width runes that follow a kept rune are kept with it.
+//   - If the tail does not fit in the width, it is dropped.
+func Truncate(units []*Unit, width int, tail string) []*Unit {
+	if Width(units) <= width {
+		return ReduceUnitSequence(units)
+	}
+
+	limit := width
+
+	tailWidth := Width([]*Unit{{Content: tail}})
+	if tailWidth <= width {
+		limit -= tailWidth
+	} else {
+		tail = ""
+	}
+
+	var truncated []*Unit
+
+	col := 0
+
+	for _, unit := range units {
+		if unit == nil {
+			continue
+		}
+
+		end := len(unit.Content)
+
+		for i, r := range unit.Content {
+			next := runeColumn(col, r)
+			if next > limit {
+				end = i
+				break
+			}
+
+			col = next
+		}
+
+		if end > 0 {
+			kept := unit.Copy()
+			kept.Content = unit.Content[:end]
+
+			truncated = append(truncated, kept)
+		}
+
+		if end < len(unit.Content) {
+			break
+		}
+	}
+
+	if tail != "" {
+		style := tcell.StyleDefault
+
+		if len(truncated) > 0 {
+			style = truncated[len(truncated)-1].Style
+		}
+
+		truncated = append(truncated, NewUnit(tail, style))
+	}
+
+	return ReduceUnitSequence(truncated)
+}
+
+// lineWidth returns the number of columns a line takes once its words are
+// separated by one space.
+//
+// Parameters:
+//   - line: The words of the line.
+//
+// Returns:
+//   - int: The number of columns.
+func lineWidth(line [][]*Unit) int {
+	col := 0
+
+	for i, word := range line {
+		if i > 0 {
+			col++
+		}
+
+		col = wordColumn(col, word)
+	}
+
+	return col
+}
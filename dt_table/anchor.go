@@ -0,0 +1,67 @@
+package dt_table
+
+// Anchor is the edge of a table where the content is kept when the table is
+// resized.
+type Anchor int
+
+const (
+	// AnchorTopLeft keeps the content at the top or left edge; lines are added
+	// or removed at the bottom or right edge.
+	AnchorTopLeft Anchor = iota
+
+	// AnchorCenter keeps the content centered; lines are added or removed at
+	// both edges, the extra one at the bottom or right edge.
+	AnchorCenter
+
+	// AnchorBottomRight keeps the content at the bottom or right edge; lines
+	// are added or removed at the top or left edge.
+	AnchorBottomRight
+)
+
+// String implements the fmt.Stringer interface.
+func (a Anchor) String() string {
+	switch a {
+	case AnchorTopLeft:
+		return "top-left"
+	case AnchorCenter:
+		return "center"
+	case AnchorBottomRight:
+		return "bottom-right"
+	default:
+		return "unknown"
+	}
+}
+
+// offset returns how far the content moves when a dimension is resized.
+//
+// Parameters:
+//   - oldSize: The size before the resize.
+//   - newSize: The size after the resize.
+//
+// Returns:
+//   - int: The offset; negative if the content moves up or left.
+func (a Anchor) offset(oldSize, newSize int) int {
+	switch a {
+	case AnchorCenter:
+		return (newSize - oldSize) / 2
+	case AnchorBottomRight:
+		return newSize - oldSize
+	default:
+		return 0
+	}
+}
+
+// firstAnchor returns the first of the optional anchors.
+//
+// Parameters:
+//   - anchors: The anchors.
+//
+// Returns:
+//   - Anchor: The first anchor. AnchorTopLeft if there is none.
+func firstAnchor(anchors []Anchor) Anchor {
+	if len(anchors) == 0 {
+		return AnchorTopLeft
+	}
+
+	return anchors[0]
+}
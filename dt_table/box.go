@@ -0,0 +1,139 @@
+package dt_table
+
+import (
+	"fmt"
+
+	"github.com/gdamore/tcell"
+)
+
+// BorderSet is the set of runes used to draw a box.
+type BorderSet struct {
+	// TopLeft, TopRight, BottomLeft and BottomRight are the corners.
+	TopLeft, TopRight, BottomLeft, BottomRight rune
+
+	// Horizontal is the rune of the top and bottom sides.
+	Horizontal rune
+
+	// Vertical is the rune of the left and right sides.
+	Vertical rune
+}
+
+var (
+	// BorderSingle draws boxes with single lines.
+	BorderSingle = BorderSet{'┌', '┐', '└', '┘', '─', '│'}
+
+	// BorderDouble draws boxes with double lines.
+	BorderDouble = BorderSet{'╔', '╗', '╚', '╝', '═', '║'}
+
+	// BorderRounded draws boxes with single lines and rounded corners.
+	BorderRounded = BorderSet{'╭', '╮', '╰', '╯', '─', '│'}
+
+	// BorderHeavy draws boxes with heavy lines.
+	BorderHeavy = BorderSet{'┏', '┓', '┗', '┛', '━', '┃'}
+
+	// BorderASCII draws boxes with ASCII characters only, for terminals that
+	// cannot display the box-drawing characters.
+	BorderASCII = BorderSet{'+', '+', '+', '+', '-', '|'}
+)
+
+// withFallback returns the border set where every unset rune is replaced by
+// its BorderASCII counterpart.
+//
+// Returns:
+//   - BorderSet: The complete border set.
+func (b BorderSet) withFallback() BorderSet {
+	fallback := func(r *rune, def rune) {
+		if *r == 0 {
+			*r = def
+		}
+	}
+
+	fallback(&b.TopLeft, BorderASCII.TopLeft)
+	fallback(&b.TopRight, BorderASCII.TopRight)
+	fallback(&b.BottomLeft, BorderASCII.BottomLeft)
+	fallback(&b.BottomRight, BorderASCII.BottomRight)
+	fallback(&b.Horizontal, BorderASCII.Horizontal)
+	fallback(&b.Vertical, BorderASCII.Vertical)
+
+	return b
+}
+
+// DrawBox draws the outline of a box. The cells inside the box are left as
+// they are, and the parts of the box outside of the table are clipped.
+//
+// Parameters:
+//   - x: The x-coordinate of the top-left corner.
+//   - y: The y-coordinate of the top-left corner.
+//   - w: The width of the box, borders included.
+//   - h: The height of the box, borders included.
+//   - style: The style of the borders.
+//   - border: The runes of the borders. Unset runes fall back to those of
+//     BorderASCII, so the zero value draws an ASCII box.
+//
+// Nothing is drawn if w or h is less than 2.
+func (dt *DtTable) DrawBox(x, y, w, h int, style tcell.Style, border BorderSet) {
+	if w < 2 || h < 2 {
+		return
+	}
+
+	border = border.withFallback()
+
+	dt.mu.Lock()
+	defer dt.mu.Unlock()
+
+	set := func(cx, cy int, r rune) {
+		// Out of bounds cells are clipped.
+		_ = dt.setCellAt(cx, cy, NewDtCell(r, style))
+	}
+
+	right, bottom := x+w-1, y+h-1
+
+	set(x, y, border.TopLeft)
+	set(right, y, border.TopRight)
+	set(x, bottom, border.BottomLeft)
+	set(right, bottom, border.BottomRight)
+
+	for cx := x + 1; cx < right; cx++ {
+		set(cx, y, border.Horizontal)
+		set(cx, bottom, border.Horizontal)
+	}
+
+	for cy := y + 1; cy < bottom; cy++ {
+		set(x, cy, border.Vertical)
+		set(right, cy, border.Vertical)
+	}
+}
+
+// Frame returns a copy of the table wrapped in a box with a title.
+//
+// Parameters:
+//   - title: The title, shown on the top border. It is truncated to fit; if
+//     empty, no title is shown.
+//   - style: The style of the borders and of the title.
+//   - border: The runes of the borders. See DrawBox.
+//
+// Returns:
+//   - *DtTable: A new table, two cells wider and taller than the table.
+func (dt *DtTable) Frame(title string, style tcell.Style, border BorderSet) *DtTable {
+	src := dt.snapshot()
+	width := src.width
+
+	out, err := NewDtTable(src.height+2, width+2)
+	if err != nil {
+		panic(fmt.Errorf("error creating table: %w", err))
+	}
+
+	out.blit(src, 1, 1, nil)
+
+	out.DrawBox(0, 0, width+2, src.height+2, style, border)
+
+	if title == "" {
+		return out
+	}
+
+	// The title is truncated by display columns, so wide runes take two
+	// cells and combining marks stay on their rune.
+	out.WriteAlignedIn(1, 0, width, " "+title+" ", AlignLeft, style)
+
+	return out
+}
@@ -0,0 +1,78 @@
+package dt_table
+
+import (
+	"slices"
+	"sync/atomic"
+)
+
+// Clone returns a copy of the table that shares its cells with the table
+// until either of them changes, so that per-frame snapshots cost nothing
+// until the next change, which then copies the cells at once.
+//
+// Since the cells are stored in one slice, the first change copies all of
+// them, not only the row that changed as in earlier versions where every row
+// was shared on its own. The copy is one contiguous copy of values, with no
+// allocation per row or per cell.
+//
+// Returns:
+//   - *DtTable: The copy, scrollback included. It has no observers.
+func (dt *DtTable) Clone() *DtTable {
+	dt.mu.Lock()
+	defer dt.mu.Unlock()
+
+	if dt.owners == nil {
+		dt.owners = new(atomic.Int32)
+		dt.owners.Store(1)
+	}
+
+	dt.owners.Add(1)
+
+	return &DtTable{
+		height: dt.height,
+		width:  dt.width,
+		cells:  dt.cells,
+		owners: dt.owners,
+		spans:  slices.Clone(dt.spans),
+
+		// Rows in history are never modified, so they can be shared.
+		history:      slices.Clone(dt.history),
+		historyLimit: dt.historyLimit,
+	}
+}
+
+// own makes sure that the cells of the table are not shared with other
+// tables, copying them if they are. It must be called, with the lock held
+// for writing, before changing the cells in place.
+func (dt *DtTable) own() {
+	if dt.owners == nil {
+		return
+	}
+
+	// Only tables that share the cells can add owners, so the table is the
+	// only one left if the count is 1.
+	if dt.owners.Load() > 1 {
+		// The copy is made before leaving, so that the last owner, which
+		// writes in place, never writes while others are copying.
+		cells := slices.Clone(dt.cells)
+
+		if dt.owners.Add(-1) > 0 {
+			dt.cells = cells
+		}
+	}
+
+	dt.owners = nil
+}
+
+// replace replaces the cells of the table with new ones. It must be called,
+// with the lock held for writing, once the old cells are no longer read.
+//
+// Parameters:
+//   - cells: The new cells.
+func (dt *DtTable) replace(cells []DtCell) {
+	if dt.owners != nil {
+		dt.owners.Add(-1)
+		dt.owners = nil
+	}
+
+	dt.cells = cells
+}
@@ -0,0 +1,95 @@
+package dt_table
+
+import (
+	"fmt"
+	"iter"
+
+	gcers "github.com/PlayerR9/go-errors"
+)
+
+// GetColumn returns the cells of a column, taken as one frame.
+//
+// Parameters:
+//   - x: The x-coordinate of the column.
+//
+// Returns:
+//   - []*DtCell: A copy of the cells, from top to bottom. Nil if x is out of
+//     bounds.
+func (dt *DtTable) GetColumn(x int) []*DtCell {
+	dt.mu.RLock()
+	defer dt.mu.RUnlock()
+
+	if x < 0 || x >= dt.width {
+		return nil
+	}
+
+	cells := make([]*DtCell, 0, dt.height)
+
+	for y := range dt.height {
+		cells = append(cells, cellPointer(dt.cells[y*dt.width+x]))
+	}
+
+	return cells
+}
+
+// SetColumn sets the cells of a column, starting from the top.
+//
+// Parameters:
+//   - x: The x-coordinate of the column.
+//   - cells: The cells to set. If there are fewer cells than rows, the
+//     cells of the remaining rows are left as they are.
+//
+// Returns:
+//   - error: An error of type *errors.ErrInvalidParameter if x is out of
+//     bounds or if there are more cells than rows.
+func (dt *DtTable) SetColumn(x int, cells []*DtCell) error {
+	dt.mu.Lock()
+	defer dt.mu.Unlock()
+
+	if x < 0 || x >= dt.width {
+		return gcers.NewErrInvalidParameter(fmt.Sprintf("x must be in [0, %d), got %d", dt.width, x))
+	} else if len(cells) > dt.height {
+		return gcers.NewErrInvalidParameter(fmt.Sprintf("expected at most %d cells, got %d", dt.height, len(cells)))
+	}
+
+	dt.own()
+
+	for y, cell := range cells {
+		dt.cells[y*dt.width+x] = cellValue(cell)
+	}
+
+	return nil
+}
+
+// Columns is a method that returns an iterator over the columns of the
+// table.
+//
+// Returns:
+//   - iter.Seq2[int, []*DtCell]: An iterator over the index and the cells of
+//     each column, from top to bottom. Never returns nil.
+//
+// The cells are taken as one frame when the iteration starts, so the table
+// may be modified while iterating.
+func (dt *DtTable) Columns() iter.Seq2[int, []*DtCell] {
+	if dt == nil {
+		return func(yield func(int, []*DtCell) bool) {}
+	}
+
+	fn := func(yield func(int, []*DtCell) bool) {
+		f := dt.snapshot()
+
+		for x := range f.width {
+			column := make([]*DtCell, 0, f.height)
+
+			for y := range f.height {
+				column = append(column, cellPointer(f.cells[y*f.width+x]))
+			}
+
+			if !yield(x, column) {
+				return
+			}
+		}
+	}
+
+	return fn
+}
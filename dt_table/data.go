@@ -0,0 +1,67 @@
+package dt_table
+
+import (
+	"fmt"
+
+	gcers "github.com/PlayerR9/go-errors"
+	"github.com/gdamore/tcell"
+)
+
+// GetDataAt returns the user data of the cell at the given coordinates, so
+// that clicks and hovers can be mapped to what is under them. Cells hidden by
+// a span return the data of the span.
+//
+// Parameters:
+//   - x: The x-coordinate.
+//   - y: The y-coordinate.
+//
+// Returns:
+//   - any: The data. Nil if the coordinates are out of bounds, the cell is
+//     empty or it has no data.
+func (dt *DtTable) GetDataAt(x, y int) any {
+	if s, ok := dt.GetSpanAt(x, y); ok {
+		x, y = s.X, s.Y
+	}
+
+	cell := dt.GetCellAt(x, y)
+	if cell == nil {
+		return nil
+	}
+
+	return cell.Data
+}
+
+// SetDataAt sets the user data of the cell at the given coordinates. An empty
+// cell becomes a space in the default style.
+//
+// Parameters:
+//   - x: The x-coordinate.
+//   - y: The y-coordinate.
+//   - data: The data.
+//
+// Returns:
+//   - error: An error of type *errors.ErrInvalidParameter if x and y are out
+//     of bounds.
+func (dt *DtTable) SetDataAt(x, y int, data any) error {
+	dt.mu.Lock()
+	defer dt.mu.Unlock()
+
+	if y < 0 || y >= dt.height {
+		return gcers.NewErrInvalidParameter(fmt.Sprintf("y must be in [0, %d), got %d", dt.height, y))
+	} else if x < 0 || x >= dt.width {
+		return gcers.NewErrInvalidParameter(fmt.Sprintf("x must be in [0, %d), got %d", dt.width, x))
+	}
+
+	dt.own()
+
+	cell := &dt.cells[y*dt.width+x]
+
+	if cell.Content == 0 {
+		cell.Content = ' '
+		cell.Style = tcell.StyleDefault
+	}
+
+	cell.Data = data
+
+	return nil
+}
@@ -0,0 +1,93 @@
+package dt_table
+
+import (
+	"slices"
+)
+
+// CellChange is a cell of a table that differs from the previous frame.
+type CellChange struct {
+	// X is the x-coordinate of the cell.
+	X int
+
+	// Y is the y-coordinate of the cell.
+	Y int
+
+	// Cell is the new cell. Nil if the cell was cleared. Its Content is
+	// Continuation if it is the right half of a wide rune, which must not be
+	// drawn.
+	Cell *DtCell
+}
+
+// sameCell checks whether two cells look the same.
+//
+// Parameters:
+//   - a: The first cell.
+//   - b: The second cell.
+//
+// Returns:
+//   - bool: True if both are empty or have the same content, combining marks
+//     and style, false otherwise.
+func sameCell(a, b DtCell) bool {
+	if a.Content == 0 || b.Content == 0 {
+		return a.Content == b.Content
+	}
+
+	return a.Content == b.Content && a.Style == b.Style && slices.Equal(a.Combining, b.Combining)
+}
+
+// Diff lists the cells of the table whose content or style differ from those
+// of a previous frame, so that renderers can do minimal terminal updates
+// instead of redrawing the whole grid.
+//
+// Parameters:
+//   - prev: The previous frame. If nil, every non-empty cell is listed.
+//
+// Returns:
+//   - []CellChange: The changes, row by row. Nil if nothing changed.
+//
+// Cells that are outside of prev are compared against empty cells, and cells
+// of prev that are outside of the table are ignored.
+func (dt *DtTable) Diff(prev *DtTable) []CellChange {
+	var before frame
+
+	// Both tables are never locked at the same time.
+	if prev != nil {
+		before = prev.snapshot()
+	}
+
+	return diffFrames(dt.snapshot(), before)
+}
+
+// diffFrames lists the cells that differ between two frames. See Diff.
+//
+// Parameters:
+//   - after: The new frame.
+//   - before: The previous frame.
+//
+// Returns:
+//   - []CellChange: The changes, row by row. Nil if nothing changed.
+func diffFrames(after, before frame) []CellChange {
+	var changes []CellChange
+
+	for y := range after.height {
+		for x, cell := range after.row(y) {
+			var was DtCell
+
+			if x < before.width && y < before.height {
+				was = before.cells[y*before.width+x]
+			}
+
+			if sameCell(cell, was) {
+				continue
+			}
+
+			changes = append(changes, CellChange{
+				X:    x,
+				Y:    y,
+				Cell: cellPointer(cell),
+			})
+		}
+	}
+
+	return changes
+}
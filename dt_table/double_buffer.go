@@ -0,0 +1,159 @@
+package dt_table
+
+import (
+	"fmt"
+	"sync"
+
+	gcers "github.com/PlayerR9/go-errors"
+)
+
+// Renderer is what a DoubleBuffer flushes its frames to, such as a terminal.
+type Renderer interface {
+	// Flush draws the cells that changed since the previous frame.
+	//
+	// Parameters:
+	//   - changes: The changes, row by row. Never empty.
+	//
+	// Returns:
+	//   - error: An error if the changes could not be drawn.
+	Flush(changes []CellChange) error
+}
+
+// DoubleBuffer holds a front table, the frame last flushed to a renderer,
+// and a back table where the next frame is drawn. Only the cells that differ
+// between the two are flushed, so animation loops neither tear nor redraw
+// what did not change. It is safe for concurrent use.
+type DoubleBuffer struct {
+	// front is the frame last flushed.
+	front *DtTable
+
+	// back is the frame being drawn.
+	back *DtTable
+
+	// renderer is where the frames are flushed.
+	renderer Renderer
+
+	// mu serializes the swaps.
+	mu sync.Mutex
+}
+
+// NewDoubleBuffer creates a new DoubleBuffer with empty frames.
+//
+// Parameters:
+//   - width: The width of the frames.
+//   - height: The height of the frames.
+//   - renderer: The renderer the frames are flushed to.
+//
+// Returns:
+//   - *DoubleBuffer: The new DoubleBuffer.
+//   - error: An error of type *errors.ErrNilParameter if renderer is nil, or
+//     of type *errors.ErrInvalidParameter if width or height is less than 0.
+func NewDoubleBuffer(width, height int, renderer Renderer) (*DoubleBuffer, error) {
+	if renderer == nil {
+		return nil, gcers.NewErrNilParameter("renderer")
+	}
+
+	front, err := NewDtTable(height, width)
+	if err != nil {
+		return nil, err
+	}
+
+	back, err := NewDtTable(height, width)
+	if err != nil {
+		panic(fmt.Errorf("error creating table: %w", err))
+	}
+
+	return &DoubleBuffer{
+		front:    front,
+		back:     back,
+		renderer: renderer,
+	}, nil
+}
+
+// Back returns the table where the next frame is drawn. After a swap, it
+// holds a copy of the frame that was flushed, so frames can be drawn
+// incrementally.
+//
+// Returns:
+//   - *DtTable: The back table. Always the same one.
+func (db *DoubleBuffer) Back() *DtTable {
+	return db.back
+}
+
+// Front returns the frame last flushed. It must not be modified.
+//
+// Returns:
+//   - *DtTable: The front table. Always the same one.
+func (db *DoubleBuffer) Front() *DtTable {
+	return db.front
+}
+
+// Swap flushes the cells of the back table that differ from the front table
+// to the renderer, then copies the back table into the front one.
+//
+// Returns:
+//   - error: The error of the renderer, if any. The front table is then left
+//     as it was, so the next swap flushes the same changes again.
+//
+// Frames of different sizes are supported: cells out of the previous frame
+// are compared against empty cells.
+func (db *DoubleBuffer) Swap() error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	// Taken once so that the frame that is flushed is also the one that is
+	// kept, even if the back table is drawn into meanwhile.
+	frame := db.back.snapshot()
+
+	changes := diffFrames(frame, db.front.snapshot())
+
+	if len(changes) > 0 {
+		err := db.renderer.Flush(changes)
+		if err != nil {
+			return err
+		}
+	}
+
+	db.front.mu.Lock()
+	defer db.front.mu.Unlock()
+
+	// The frame is a copy, so it can be taken as is.
+	db.front.replace(frame.cells)
+
+	db.front.width = frame.width
+	db.front.height = frame.height
+
+	return nil
+}
+
+// Resize resizes both frames. The content of the front table is kept, so
+// only the cells that the resize reveals or changes are flushed next.
+//
+// Parameters:
+//   - width: The new width.
+//   - height: The new height.
+//
+// Returns:
+//   - error: An error of type *errors.ErrInvalidParameter if width or height
+//     is less than 0.
+func (db *DoubleBuffer) Resize(width, height int) error {
+	if width < 0 {
+		return gcers.NewErrInvalidParameter("width must be non-negative")
+	} else if height < 0 {
+		return gcers.NewErrInvalidParameter("height must be non-negative")
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	for _, table := range []*DtTable{db.front, db.back} {
+		table.mu.Lock()
+
+		_ = table.resizeHeight(height, AnchorTopLeft)
+		_ = table.resizeWidth(width, AnchorTopLeft)
+
+		table.mu.Unlock()
+	}
+
+	return nil
+}
@@ -0,0 +1,191 @@
+package dt_table
+
+import (
+	"slices"
+	"unicode"
+
+	"github.com/gdamore/tcell"
+	"github.com/mattn/go-runewidth"
+)
+
+// Continuation is the content of the cell to the right of a wide rune, such
+// as a CJK character or an emoji, which takes two columns. Renderers print
+// nothing for it.
+const Continuation rune = -1
+
+// DtCell represents a cell in a data table.
+type DtCell struct {
+	// Content is the content of the cell. A cell whose content is 0 is empty.
+	Content rune
+
+	// Style is the style of the cell.
+	Style tcell.Style
+
+	// Combining are the combining marks, such as accents, drawn over the
+	// content.
+	Combining []rune
+
+	// Data is arbitrary user data, such as a link target or an entity ID,
+	// for hit-testing. It is not rendered, compared by Diff nor encoded.
+	Data any
+}
+
+// NewDtCell creates a new DtCell with the given content and style.
+//
+// Parameters:
+//   - content: The content of the cell.
+//   - style: The style of the cell.
+//
+// Returns:
+//   - *DtCell: A pointer to the new DtCell.
+func NewDtCell(content rune, style tcell.Style) *DtCell {
+	return &DtCell{
+		Content: content,
+		Style:   style,
+	}
+}
+
+// cellValue returns the value of a cell.
+//
+// Parameters:
+//   - cell: The cell.
+//
+// Returns:
+//   - DtCell: A copy of the cell. The zero value, which is empty, if cell is
+//     nil.
+func cellValue(cell *DtCell) DtCell {
+	if cell == nil {
+		return DtCell{}
+	}
+
+	return *cell
+}
+
+// cellPointer returns a pointer to a copy of a cell.
+//
+// Parameters:
+//   - cell: The cell.
+//
+// Returns:
+//   - *DtCell: The pointer. Nil if the cell is empty.
+func cellPointer(cell DtCell) *DtCell {
+	if cell.Content == 0 {
+		return nil
+	}
+
+	return &cell
+}
+
+// Width returns the number of columns the cell takes when printed.
+//
+// Returns:
+//   - int: 2 for a wide rune, 0 for a continuation cell and 1 otherwise.
+func (c DtCell) Width() int {
+	if c.Content == Continuation {
+		return 0
+	} else if runewidth.RuneWidth(c.Content) == 2 {
+		return 2
+	}
+
+	return 1
+}
+
+// Text returns the content of the cell followed by its combining marks.
+//
+// Returns:
+//   - string: The text. Empty for a continuation cell.
+func (c DtCell) Text() string {
+	if c.Content == Continuation {
+		return ""
+	}
+
+	return string(c.Content) + string(c.Combining)
+}
+
+// isCombining checks whether a rune is a combining mark, which is drawn over
+// the previous rune instead of taking a cell.
+//
+// Parameters:
+//   - r: The rune.
+//
+// Returns:
+//   - bool: True if the rune is a combining mark, false otherwise.
+func isCombining(r rune) bool {
+	return unicode.In(r, unicode.Mn, unicode.Me)
+}
+
+// withMark returns a copy of a cell with one more combining mark.
+//
+// Parameters:
+//   - cell: The cell. Must not be nil.
+//   - mark: The combining mark.
+//
+// Returns:
+//   - *DtCell: The copy.
+func withMark(cell *DtCell, mark rune) *DtCell {
+	marked := *cell
+	marked.Combining = append(slices.Clip(cell.Combining), mark)
+
+	return &marked
+}
+
+// markLast adds a combining mark to the last cell of a row that is not a
+// continuation cell.
+//
+// Parameters:
+//   - cells: The cells of the row.
+//   - mark: The combining mark.
+//
+// Returns:
+//   - bool: False if the last such cell is empty or there is none, in which
+//     case the mark is dropped; true otherwise.
+func markLast(cells []*DtCell, mark rune) bool {
+	for i := len(cells) - 1; i >= 0; i-- {
+		if cells[i] != nil && cells[i].Content == Continuation {
+			continue
+		}
+
+		if cells[i] == nil {
+			return false
+		}
+
+		cells[i] = withMark(cells[i], mark)
+
+		return true
+	}
+
+	return false
+}
+
+// glyph returns what to print for a cell of a row, so that wide runes take
+// exactly two columns even when their continuation cell was overwritten.
+//
+// Parameters:
+//   - row: The cells of the row.
+//   - x: The index of the cell. Must be in bounds.
+//
+// Returns:
+//   - string: The text to print. A space for empty cells and for wide runes
+//     or continuation cells that lost their other half.
+func glyph(row []DtCell, x int) string {
+	cell := row[x]
+
+	switch {
+	case cell.Content == 0:
+		return " "
+	case cell.Content == Continuation:
+		if x > 0 && row[x-1].Width() == 2 {
+			return ""
+		}
+
+		return " "
+	case cell.Width() == 2:
+		if x+1 < len(row) && row[x+1].Content == Continuation {
+			return cell.Text()
+		}
+
+		return " "
+	default:
+		return cell.Text()
+	}
+}
@@ -1,14 +1,18 @@
 package dt_table
 
 import (
-	"errors"
+	"fmt"
+	"slices"
 	"sync"
 
-	gcers "github.com/PlayerR9/go-commons/errors"
+	gcers "github.com/PlayerR9/go-errors"
 )
 
 // DtRow represents a row in a data table.
 // It is safe for concurrent use.
+//
+// Deprecated: DtTable stores its cells in a single slice and no longer uses
+// DtRow. It is only kept so that existing code still compiles.
 type DtRow struct {
 	// cells is a slice of cells in the row.
 	cells []*DtCell
@@ -31,10 +35,7 @@ type DtRow struct {
 //     is less than 0.
 func NewDtRow(width int) (*DtRow, error) {
 	if width < 0 {
-		return nil, gcers.NewErrInvalidParameter(
-			"width",
-			errors.New("value must be non-negative"),
-		)
+		return nil, gcers.NewErrInvalidParameter("width must be non-negative")
 	}
 
 	return &DtRow{
@@ -68,10 +69,7 @@ func (r *DtRow) SetCell(cell *DtCell, x int) error {
 	defer r.mu.Unlock()
 
 	if x < 0 || x >= r.width {
-		return gcers.NewErrInvalidParameter(
-			"x",
-			gcers.NewErrOutOfBounds(x, 0, r.width),
-		)
+		return gcers.NewErrInvalidParameter(fmt.Sprintf("x must be in [0, %d), got %d", r.width, x))
 	}
 
 	r.cells[x] = cell
@@ -93,10 +91,7 @@ func (r *DtRow) SetCells(cells []*DtCell, from int) error {
 	defer r.mu.Unlock()
 
 	if from < 0 || from+len(cells) > r.width {
-		return gcers.NewErrInvalidParameter(
-			"from",
-			gcers.NewErrOutOfBounds(from, 0, r.width-len(cells)),
-		)
+		return gcers.NewErrInvalidParameter(fmt.Sprintf("from must be in [0, %d), got %d", r.width-len(cells), from))
 	}
 
 	for i, cell := range cells {
@@ -150,10 +145,7 @@ func (r *DtRow) Resize(newWidth int) error {
 	defer r.mu.Unlock()
 
 	if newWidth < 0 {
-		return gcers.NewErrInvalidParameter(
-			"newWidth",
-			errors.New("value must be greater than 0"),
-		)
+		return gcers.NewErrInvalidParameter("newWidth must be greater than 0")
 	}
 
 	if newWidth == r.width {
@@ -170,3 +162,49 @@ func (r *DtRow) Resize(newWidth int) error {
 
 	return nil
 }
+
+// Insert inserts an empty cell at the given index, shifting the following
+// cells to the right. The width of the row grows by one.
+//
+// Parameters:
+//   - x: The index of the new cell.
+//
+// Returns:
+//   - error: An error of type *errors.ErrInvalidParameter if x is not in
+//     [0, width].
+func (r *DtRow) Insert(x int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if x < 0 || x > r.width {
+		return gcers.NewErrInvalidParameter(fmt.Sprintf("x must be in [0, %d], got %d", r.width, x))
+	}
+
+	r.cells = slices.Insert(r.cells, x, nil)
+	r.width++
+
+	return nil
+}
+
+// Delete deletes the cell at the given index, shifting the following cells
+// to the left. The width of the row shrinks by one.
+//
+// Parameters:
+//   - x: The index of the cell.
+//
+// Returns:
+//   - error: An error of type *errors.ErrInvalidParameter if x is out of
+//     bounds.
+func (r *DtRow) Delete(x int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if x < 0 || x >= r.width {
+		return gcers.NewErrInvalidParameter(fmt.Sprintf("x must be in [0, %d), got %d", r.width, x))
+	}
+
+	r.cells = slices.Delete(r.cells, x, x+1)
+	r.width--
+
+	return nil
+}
@@ -0,0 +1,426 @@
+package dt_table
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	gcers "github.com/PlayerR9/go-errors"
+	sbj "github.com/PlayerR9/safe/subject"
+)
+
+// DtTable represents a table of cells.
+// It is safe for concurrent use.
+type DtTable struct {
+	// height and width represent the height and width of the table, respectively.
+	height, width int
+
+	// cells are the cells of the table, row by row: the cell at (x, y) is at
+	// index y*width+x. Cells whose Content is 0 are empty.
+	cells []DtCell
+
+	// owners counts the tables that share cells with this one. Nil if cells
+	// are not shared. See Clone.
+	owners *atomic.Int32
+
+	// spans are the merged cells of the table.
+	spans []Span
+
+	// history are the rows scrolled off the top of the table, oldest first.
+	// Rows are never modified once in history.
+	history [][]DtCell
+
+	// historyLimit is the maximum number of rows in history. 0 if scrollback
+	// is disabled.
+	historyLimit int
+
+	// changes is notified whenever a change is signaled.
+	changes sbj.Subject[uint64]
+
+	// mu protects the fields above except changes. It is held for reading by
+	// the operations that only read the table, and for writing by the others.
+	mu sync.RWMutex
+}
+
+// GetCellAt returns the cell at the given coordinates.
+// If the coordinates are out of bounds, it returns nil.
+//
+// Parameters:
+//   - x: The x-coordinate.
+//   - y: The y-coordinate.
+//
+// Returns:
+//   - *DtCell: A copy of the cell at the given coordinates. Nil if the cell
+//     is empty.
+//
+// Behaviors:
+//   - Since the cells are stored by value, modifying the returned cell does
+//     not modify the table, unlike in earlier versions where the stored
+//     pointer was returned. Use UpdateCellAt to change a cell in place, as
+//     in dt.UpdateCellAt(x, y, func(c *DtCell) { c.Style = s }), or
+//     SetCellAt to replace it.
+func (dt *DtTable) GetCellAt(x, y int) *DtCell {
+	dt.mu.RLock()
+	defer dt.mu.RUnlock()
+
+	if !dt.inBounds(x, y) {
+		return nil
+	}
+
+	return cellPointer(dt.cells[y*dt.width+x])
+}
+
+// GetWidth returns the width of the table.
+//
+// Returns:
+//   - int: The width of the table.
+func (dt *DtTable) GetWidth() int {
+	dt.mu.RLock()
+	defer dt.mu.RUnlock()
+
+	return dt.width
+}
+
+// GetHeight returns the height of the table.
+//
+// Returns:
+//   - int: The height of the table.
+func (dt *DtTable) GetHeight() int {
+	dt.mu.RLock()
+	defer dt.mu.RUnlock()
+
+	return dt.height
+}
+
+// SetCellAt sets the cell at the given coordinates.
+//
+// Parameters:
+//   - x: The x-coordinate.
+//   - y: The y-coordinate.
+//   - cell: The cell to set. It is copied. If nil, the cell is emptied.
+//
+// Returns:
+//   - error: An error of type *uc.ErrInvalidParameter if x and y are out of bounds.
+func (dt *DtTable) SetCellAt(x, y int, cell *DtCell) error {
+	dt.mu.Lock()
+	defer dt.mu.Unlock()
+
+	return dt.setCellAt(x, y, cell)
+}
+
+// UpdateCellAt changes the cell at the given coordinates in place.
+//
+// Parameters:
+//   - x: The x-coordinate.
+//   - y: The y-coordinate.
+//   - fn: The function that changes the cell. It is called with the lock
+//     held, so it must not use the table. The cell is empty if its Content
+//     is 0, before and after the call. If nil, nothing is done.
+//
+// Returns:
+//   - error: An error of type *uc.ErrInvalidParameter if x and y are out of bounds.
+func (dt *DtTable) UpdateCellAt(x, y int, fn func(cell *DtCell)) error {
+	dt.mu.Lock()
+	defer dt.mu.Unlock()
+
+	if y < 0 || y >= dt.height {
+		return gcers.NewErrInvalidParameter(fmt.Sprintf("y must be in [0, %d), got %d", dt.height, y))
+	} else if x < 0 || x >= dt.width {
+		return gcers.NewErrInvalidParameter(fmt.Sprintf("x must be in [0, %d), got %d", dt.width, x))
+	}
+
+	if fn == nil {
+		return nil
+	}
+
+	dt.own()
+
+	fn(&dt.cells[y*dt.width+x])
+
+	return nil
+}
+
+// setCellAt is the same as SetCellAt but the caller must hold the lock.
+func (dt *DtTable) setCellAt(x, y int, cell *DtCell) error {
+	if y < 0 || y >= dt.height {
+		return gcers.NewErrInvalidParameter(fmt.Sprintf("y must be in [0, %d), got %d", dt.height, y))
+	} else if x < 0 || x >= dt.width {
+		return gcers.NewErrInvalidParameter(fmt.Sprintf("x must be in [0, %d), got %d", dt.width, x))
+	}
+
+	dt.own()
+
+	dt.cells[y*dt.width+x] = cellValue(cell)
+
+	return nil
+}
+
+// inBounds checks whether the given coordinates are in the table. The lock
+// must be held.
+//
+// Parameters:
+//   - x: The x-coordinate.
+//   - y: The y-coordinate.
+//
+// Returns:
+//   - bool: True if the coordinates are in bounds, false otherwise.
+func (dt *DtTable) inBounds(x, y int) bool {
+	return x >= 0 && x < dt.width && y >= 0 && y < dt.height
+}
+
+// NewDtTable creates a new table with the given height and width.
+//
+// Parameters:
+//   - height: The height of the table.
+//   - width: The width of the table.
+//
+// Returns:
+//   - *DtTable: A pointer to the new table.
+//   - error: An error of type *uc.ErrInvalidParameter if height or
+//     width is less than 0.
+func NewDtTable(height, width int) (*DtTable, error) {
+	if height < 0 {
+		return nil, gcers.NewErrInvalidParameter("height must be non-negative")
+	} else if width < 0 {
+		return nil, gcers.NewErrInvalidParameter("width must be non-negative")
+	}
+
+	return &DtTable{
+		height: height,
+		width:  width,
+		cells:  make([]DtCell, width*height),
+	}, nil
+}
+
+// newDtTableFromRows creates a new table from its rows.
+//
+// Parameters:
+//   - width: The width of the table. Shorter rows are padded with empty
+//     cells and longer ones are truncated.
+//   - rows: The cells of the table, row by row. Nil cells are empty.
+//
+// Returns:
+//   - *DtTable: The new table. Its height is the number of rows.
+func newDtTableFromRows(width int, rows [][]*DtCell) *DtTable {
+	table, err := NewDtTable(len(rows), width)
+	if err != nil {
+		panic(fmt.Errorf("error creating table: %w", err))
+	}
+
+	for y, row := range rows {
+		if len(row) > width {
+			row = row[:width]
+		}
+
+		for x, cell := range row {
+			table.cells[y*width+x] = cellValue(cell)
+		}
+	}
+
+	return table
+}
+
+// TransformIntoTable transforms a slice of cells into a table.
+// Wide runes take two cells, the second of which is a Continuation cell, and
+// combining marks are added to the cell before them.
+//
+// Parameters:
+//   - highlights: The slice of cells to transform.
+//
+// Returns:
+//   - *DtTable: A pointer to the new table.
+//   - error: An error of type *ErrInvalidCharacter if an invalid character is found.
+func TransformIntoTable(highlights []DtCell) (*DtTable, error) {
+	var rows [][]*DtCell
+
+	var row []*DtCell
+
+	for _, hl := range highlights {
+		switch hl.Content {
+		case '\n':
+			rows = append(rows, row)
+
+			row = nil
+		// case '\t', '\r', '\b', '\f', '\v', '\a':
+		// 	return nil, NewErrInvalidCharacter(hl.Content)
+		case ' ':
+			row = append(row, nil)
+		default:
+			if isCombining(hl.Content) {
+				_ = markLast(row, hl.Content)
+				continue
+			}
+
+			row = append(row, &hl)
+
+			if hl.Width() == 2 {
+				row = append(row, NewDtCell(Continuation, hl.Style))
+			}
+		}
+	}
+
+	if len(row) > 0 {
+		rows = append(rows, row)
+	}
+
+	// Fix the sizes of the table.
+	width := 0
+
+	for _, row := range rows {
+		width = max(width, len(row))
+	}
+
+	return newDtTableFromRows(width, rows), nil
+}
+
+// ResizeHeight resizes the height of the table.
+//
+// Parameters:
+//   - newHeight: The new height of the table.
+//   - anchor: The edge where the content is kept. Rows are added or removed
+//     at the other edge, or at both for AnchorCenter. Optional; defaults to
+//     AnchorTopLeft. Only the first one is used.
+//
+// Returns:
+//   - error: An error of type *uc.ErrInvalidParameter if newHeight is less than 0.
+func (dt *DtTable) ResizeHeight(newHeight int, anchor ...Anchor) error {
+	dt.mu.Lock()
+	defer dt.mu.Unlock()
+
+	return dt.resizeHeight(newHeight, firstAnchor(anchor))
+}
+
+// resizeHeight is the same as ResizeHeight but the caller must hold the lock.
+func (dt *DtTable) resizeHeight(newHeight int, anchor Anchor) error {
+	if newHeight < 0 {
+		return gcers.NewErrInvalidParameter("newHeight must be non-negative")
+	}
+
+	if newHeight == dt.height {
+		return nil
+	}
+
+	offset := anchor.offset(dt.height, newHeight)
+
+	cells := make([]DtCell, dt.width*newHeight)
+
+	for y := range newHeight {
+		if from := y - offset; from >= 0 && from < dt.height {
+			copy(cells[y*dt.width:(y+1)*dt.width], dt.cells[from*dt.width:])
+		}
+	}
+
+	dt.replace(cells)
+	dt.height = newHeight
+
+	dt.moveSpans(0, offset)
+	dt.clipSpans(dt.width, newHeight)
+
+	return nil
+}
+
+// ResizeWidth resizes the width of the table.
+//
+// Parameters:
+//   - newWidth: The new width of the table.
+//   - anchor: The edge where the content is kept. Columns are added or
+//     removed at the other edge, or at both for AnchorCenter. Optional;
+//     defaults to AnchorTopLeft. Only the first one is used.
+//
+// Returns:
+//   - error: An error of type *uc.ErrInvalidParameter if newWidth is less than 0.
+func (dt *DtTable) ResizeWidth(newWidth int, anchor ...Anchor) error {
+	dt.mu.Lock()
+	defer dt.mu.Unlock()
+
+	return dt.resizeWidth(newWidth, firstAnchor(anchor))
+}
+
+// resizeWidth is the same as ResizeWidth but the caller must hold the lock.
+func (dt *DtTable) resizeWidth(newWidth int, anchor Anchor) error {
+	if newWidth < 0 {
+		return gcers.NewErrInvalidParameter("newWidth must be non-negative")
+	}
+
+	if newWidth == dt.width {
+		return nil
+	}
+
+	offset := anchor.offset(dt.width, newWidth)
+
+	cells := make([]DtCell, newWidth*dt.height)
+
+	for y := range dt.height {
+		for x := range dt.width {
+			if to := x + offset; to >= 0 && to < newWidth {
+				cells[y*newWidth+to] = dt.cells[y*dt.width+x]
+			}
+		}
+	}
+
+	dt.replace(cells)
+	dt.width = newWidth
+
+	dt.moveSpans(offset, 0)
+	dt.clipSpans(newWidth, dt.height)
+
+	return nil
+}
+
+// frame is a copy of the cells of a table, taken as one frame.
+type frame struct {
+	// width and height are the size of the table.
+	width, height int
+
+	// cells are the cells, row by row. Cells whose Content is 0 are empty.
+	cells []DtCell
+}
+
+// row returns the cells of a row of the frame.
+//
+// Parameters:
+//   - y: The y-coordinate of the row. Must be in bounds.
+//
+// Returns:
+//   - []DtCell: The cells of the row. They must not be modified.
+func (f frame) row(y int) []DtCell {
+	return f.cells[y*f.width : (y+1)*f.width]
+}
+
+// pointers returns the cells of a row of the frame as pointers.
+//
+// Parameters:
+//   - y: The y-coordinate of the row. Must be in bounds.
+//
+// Returns:
+//   - []*DtCell: The cells of the row, nil for the empty ones. They point
+//     into the frame.
+func (f frame) pointers(y int) []*DtCell {
+	cells := make([]*DtCell, f.width)
+
+	for x := range cells {
+		if i := y*f.width + x; f.cells[i].Content != 0 {
+			cells[x] = &f.cells[i]
+		}
+	}
+
+	return cells
+}
+
+// snapshot returns a copy of the cells of the table, taken as one frame.
+//
+// Returns:
+//   - frame: The copy.
+func (dt *DtTable) snapshot() frame {
+	dt.mu.RLock()
+	defer dt.mu.RUnlock()
+
+	cells := make([]DtCell, len(dt.cells))
+	copy(cells, dt.cells)
+
+	return frame{
+		width:  dt.width,
+		height: dt.height,
+		cells:  cells,
+	}
+}
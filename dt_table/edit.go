@@ -0,0 +1,154 @@
+package dt_table
+
+import (
+	"fmt"
+	"slices"
+
+	gcers "github.com/PlayerR9/go-errors"
+)
+
+// InsertRow inserts an empty row at the given index, shifting the following
+// rows down. The height of the table grows by one.
+//
+// Parameters:
+//   - y: The index of the new row.
+//
+// Returns:
+//   - error: An error of type *errors.ErrInvalidParameter if y is not in
+//     [0, height].
+func (dt *DtTable) InsertRow(y int) error {
+	dt.mu.Lock()
+	defer dt.mu.Unlock()
+
+	return dt.insertRow(y)
+}
+
+// insertRow is the same as InsertRow but the caller must hold the lock.
+func (dt *DtTable) insertRow(y int) error {
+	if y < 0 || y > dt.height {
+		return gcers.NewErrInvalidParameter(fmt.Sprintf("y must be in [0, %d], got %d", dt.height, y))
+	}
+
+	dt.own()
+
+	dt.cells = slices.Insert(dt.cells, y*dt.width, make([]DtCell, dt.width)...)
+	dt.height++
+
+	dt.shiftSpans(y, 1, false)
+
+	return nil
+}
+
+// DeleteRow deletes the row at the given index, shifting the following rows
+// up. The height of the table shrinks by one.
+//
+// Parameters:
+//   - y: The index of the row.
+//
+// Returns:
+//   - error: An error of type *errors.ErrInvalidParameter if y is out of
+//     bounds.
+func (dt *DtTable) DeleteRow(y int) error {
+	dt.mu.Lock()
+	defer dt.mu.Unlock()
+
+	return dt.deleteRow(y)
+}
+
+// deleteRow is the same as DeleteRow but the caller must hold the lock.
+func (dt *DtTable) deleteRow(y int) error {
+	if y < 0 || y >= dt.height {
+		return gcers.NewErrInvalidParameter(fmt.Sprintf("y must be in [0, %d), got %d", dt.height, y))
+	}
+
+	dt.own()
+
+	dt.cells = slices.Delete(dt.cells, y*dt.width, (y+1)*dt.width)
+	dt.height--
+
+	dt.shiftSpans(y, -1, false)
+
+	return nil
+}
+
+// InsertColumn inserts an empty column at the given index, shifting the
+// following columns to the right. The width of the table grows by one.
+//
+// Parameters:
+//   - x: The index of the new column.
+//
+// Returns:
+//   - error: An error of type *errors.ErrInvalidParameter if x is not in
+//     [0, width].
+func (dt *DtTable) InsertColumn(x int) error {
+	dt.mu.Lock()
+	defer dt.mu.Unlock()
+
+	return dt.insertColumn(x)
+}
+
+// insertColumn is the same as InsertColumn but the caller must hold the lock.
+func (dt *DtTable) insertColumn(x int) error {
+	if x < 0 || x > dt.width {
+		return gcers.NewErrInvalidParameter(fmt.Sprintf("x must be in [0, %d], got %d", dt.width, x))
+	}
+
+	width := dt.width + 1
+
+	cells := make([]DtCell, width*dt.height)
+
+	for y := range dt.height {
+		row := dt.cells[y*dt.width : (y+1)*dt.width]
+
+		copy(cells[y*width:], row[:x])
+		copy(cells[y*width+x+1:], row[x:])
+	}
+
+	dt.replace(cells)
+	dt.width = width
+
+	dt.shiftSpans(x, 1, true)
+
+	return nil
+}
+
+// DeleteColumn deletes the column at the given index, shifting the following
+// columns to the left. The width of the table shrinks by one.
+//
+// Parameters:
+//   - x: The index of the column.
+//
+// Returns:
+//   - error: An error of type *errors.ErrInvalidParameter if x is out of
+//     bounds.
+func (dt *DtTable) DeleteColumn(x int) error {
+	dt.mu.Lock()
+	defer dt.mu.Unlock()
+
+	return dt.deleteColumn(x)
+}
+
+// deleteColumn is the same as DeleteColumn but the caller must hold the lock.
+func (dt *DtTable) deleteColumn(x int) error {
+	if x < 0 || x >= dt.width {
+		return gcers.NewErrInvalidParameter(fmt.Sprintf("x must be in [0, %d), got %d", dt.width, x))
+	}
+
+	width := dt.width - 1
+
+	cells := make([]DtCell, width*dt.height)
+
+	for y := range dt.height {
+		row := dt.cells[y*dt.width : (y+1)*dt.width]
+
+		copy(cells[y*width:], row[:x])
+		copy(cells[y*width+x:], row[x+1:])
+	}
+
+	dt.replace(cells)
+	dt.width = width
+
+	dt.shiftSpans(x, -1, true)
+
+	return nil
+}
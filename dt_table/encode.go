@@ -0,0 +1,319 @@
+package dt_table
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"unicode/utf8"
+
+	"github.com/gdamore/tcell"
+)
+
+// cellData is the encoded form of a DtCell.
+type cellData struct {
+	// Rune is the content of the cell, as a string of one rune. Empty for a
+	// continuation cell.
+	Rune string `json:"rune"`
+
+	// Combining are the combining marks of the cell.
+	Combining string `json:"combining,omitempty"`
+
+	// Fg is the foreground color. tcell.ColorDefault if unset.
+	Fg tcell.Color `json:"fg"`
+
+	// Bg is the background color. tcell.ColorDefault if unset.
+	Bg tcell.Color `json:"bg"`
+
+	// Attr are the attributes, such as bold or underline.
+	Attr tcell.AttrMask `json:"attr,omitempty"`
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+//
+// A cell is encoded as an object with its rune and its combining marks as
+// strings, its foreground and background colors as tcell.Color values and
+// its attributes as a tcell.AttrMask. The rune of a continuation cell is an
+// empty string.
+func (c DtCell) MarshalJSON() ([]byte, error) {
+	fg, bg, attr := c.Style.Decompose()
+
+	data := cellData{
+		Combining: string(c.Combining),
+		Fg:        fg,
+		Bg:        bg,
+		Attr:      attr,
+	}
+
+	if c.Content != Continuation {
+		data.Rune = string(c.Content)
+	}
+
+	return json.Marshal(data)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+//
+// See DtCell.MarshalJSON for the format.
+func (c *DtCell) UnmarshalJSON(data []byte) error {
+	decoded := cellData{
+		Fg: tcell.ColorDefault,
+		Bg: tcell.ColorDefault,
+	}
+
+	err := json.Unmarshal(data, &decoded)
+	if err != nil {
+		return err
+	}
+
+	r, size := utf8.DecodeRuneInString(decoded.Rune)
+
+	switch {
+	case size == 0:
+		r = Continuation
+	case size != len(decoded.Rune):
+		return fmt.Errorf("rune must be a string of at most one rune, got %q", decoded.Rune)
+	}
+
+	c.Content = r
+	c.Style = newStyle(decoded.Fg, decoded.Bg, decoded.Attr)
+	c.Combining = nil
+
+	if decoded.Combining != "" {
+		c.Combining = []rune(decoded.Combining)
+	}
+
+	return nil
+}
+
+// newStyle builds a style from its components.
+//
+// Parameters:
+//   - fg: The foreground color.
+//   - bg: The background color.
+//   - attr: The attributes.
+//
+// Returns:
+//   - tcell.Style: The style.
+func newStyle(fg, bg tcell.Color, attr tcell.AttrMask) tcell.Style {
+	return tcell.StyleDefault.
+		Foreground(fg).
+		Background(bg).
+		Bold(attr&tcell.AttrBold != 0).
+		Blink(attr&tcell.AttrBlink != 0).
+		Reverse(attr&tcell.AttrReverse != 0).
+		Underline(attr&tcell.AttrUnderline != 0).
+		Dim(attr&tcell.AttrDim != 0).
+		Italic(attr&tcell.AttrItalic != 0)
+}
+
+// tableData is the encoded form of a DtTable.
+type tableData struct {
+	// Width is the width of the table.
+	Width int `json:"width"`
+
+	// Height is the height of the table.
+	Height int `json:"height"`
+
+	// Rows are the cells of the table, row by row. Empty cells are nil.
+	Rows [][]*DtCell `json:"rows"`
+
+	// Spans are the merged cells of the table.
+	Spans []Span `json:"spans,omitempty"`
+}
+
+// data returns the encoded form of the table, taken as one frame.
+//
+// Returns:
+//   - tableData: The encoded form.
+func (dt *DtTable) data() tableData {
+	dt.mu.RLock()
+	defer dt.mu.RUnlock()
+
+	rows := make([][]*DtCell, 0, dt.height)
+
+	for y := range dt.height {
+		row := make([]*DtCell, 0, dt.width)
+
+		for _, cell := range dt.cells[y*dt.width : (y+1)*dt.width] {
+			row = append(row, cellPointer(cell))
+		}
+
+		rows = append(rows, row)
+	}
+
+	return tableData{
+		Width:  dt.width,
+		Height: dt.height,
+		Rows:   rows,
+		Spans:  slices.Clone(dt.spans),
+	}
+}
+
+// load replaces the content of the table with the given encoded form.
+//
+// Parameters:
+//   - data: The encoded form.
+//
+// Returns:
+//   - error: An error if the encoded form is not consistent.
+func (dt *DtTable) load(data tableData) error {
+	if data.Width < 0 || data.Height < 0 {
+		return fmt.Errorf("size must be non-negative, got %dx%d", data.Width, data.Height)
+	} else if len(data.Rows) != data.Height {
+		return fmt.Errorf("expected %d rows, got %d", data.Height, len(data.Rows))
+	}
+
+	for y, cells := range data.Rows {
+		if len(cells) != data.Width {
+			return fmt.Errorf("row %d: expected %d cells, got %d", y, data.Width, len(cells))
+		}
+	}
+
+	for i, s := range data.Spans {
+		if s.W <= 0 || s.H <= 0 || s.X < 0 || s.Y < 0 || s.X+s.W > data.Width || s.Y+s.H > data.Height {
+			return fmt.Errorf("span %d: %+v is out of the table", i, s)
+		}
+	}
+
+	table := newDtTableFromRows(data.Width, data.Rows)
+
+	dt.mu.Lock()
+	defer dt.mu.Unlock()
+
+	dt.replace(table.cells)
+
+	dt.width = data.Width
+	dt.height = data.Height
+	dt.spans = data.Spans
+
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+//
+// A table is encoded as an object with its width, its height, its rows as
+// arrays of cells (null for empty cells) and its spans. See
+// DtCell.MarshalJSON for the format of the cells.
+func (dt *DtTable) MarshalJSON() ([]byte, error) {
+	return json.Marshal(dt.data())
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface. The content of the
+// table is replaced; the observers are kept.
+//
+// See DtTable.MarshalJSON for the format.
+func (dt *DtTable) UnmarshalJSON(data []byte) error {
+	var decoded tableData
+
+	err := json.Unmarshal(data, &decoded)
+	if err != nil {
+		return err
+	}
+
+	return dt.load(decoded)
+}
+
+// gobCell is the gob form of a DtCell. The data is left out, since gob can
+// only encode the types registered with it.
+type gobCell struct {
+	Content   rune
+	Style     tcell.Style
+	Combining []rune
+}
+
+// gobTable is the gob form of a DtTable. gob cannot encode nil pointers, so
+// the cells are stored by value with a mask of the empty ones.
+type gobTable struct {
+	Width, Height int
+	Cells         []gobCell
+	Empty         []bool
+	Spans         []Span
+}
+
+// GobEncode implements the gob.GobEncoder interface.
+func (dt *DtTable) GobEncode() ([]byte, error) {
+	data := dt.data()
+
+	out := gobTable{
+		Width:  data.Width,
+		Height: data.Height,
+		Cells:  make([]gobCell, 0, data.Width*data.Height),
+		Empty:  make([]bool, 0, data.Width*data.Height),
+		Spans:  data.Spans,
+	}
+
+	for _, row := range data.Rows {
+		for _, cell := range row {
+			if cell == nil {
+				out.Cells = append(out.Cells, gobCell{})
+				out.Empty = append(out.Empty, true)
+			} else {
+				out.Cells = append(out.Cells, gobCell{
+					Content:   cell.Content,
+					Style:     cell.Style,
+					Combining: cell.Combining,
+				})
+				out.Empty = append(out.Empty, false)
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+
+	err := gob.NewEncoder(&buf).Encode(out)
+	if err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// GobDecode implements the gob.GobDecoder interface. The content of the table
+// is replaced; the observers are kept.
+func (dt *DtTable) GobDecode(data []byte) error {
+	var in gobTable
+
+	err := gob.NewDecoder(bytes.NewReader(data)).Decode(&in)
+	if err != nil {
+		return err
+	}
+
+	if in.Width < 0 || in.Height < 0 {
+		return fmt.Errorf("size must be non-negative, got %dx%d", in.Width, in.Height)
+	}
+
+	size := in.Width * in.Height
+
+	if len(in.Cells) != size || len(in.Empty) != size {
+		return fmt.Errorf("expected %d cells, got %d", size, len(in.Cells))
+	}
+
+	rows := make([][]*DtCell, 0, in.Height)
+
+	for y := 0; y < in.Height; y++ {
+		row := make([]*DtCell, in.Width)
+
+		for x := range row {
+			i := y*in.Width + x
+
+			if !in.Empty[i] {
+				row[x] = &DtCell{
+					Content:   in.Cells[i].Content,
+					Style:     in.Cells[i].Style,
+					Combining: in.Cells[i].Combining,
+				}
+			}
+		}
+
+		rows = append(rows, row)
+	}
+
+	return dt.load(tableData{
+		Width:  in.Width,
+		Height: in.Height,
+		Rows:   rows,
+		Spans:  in.Spans,
+	})
+}
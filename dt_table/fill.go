@@ -0,0 +1,152 @@
+package dt_table
+
+// FillRegion sets all the cells of a region to the same cell. The table is
+// locked once, instead of once per cell as with SetCellAt. The parts of the
+// region outside of the table are clipped.
+//
+// Parameters:
+//   - x: The x-coordinate of the top-left corner.
+//   - y: The y-coordinate of the top-left corner.
+//   - w: The width of the region.
+//   - h: The height of the region.
+//   - cell: The cell to set. It is copied into all the cells of the region.
+func (dt *DtTable) FillRegion(x, y, w, h int, cell *DtCell) {
+	dt.mu.Lock()
+	defer dt.mu.Unlock()
+
+	dt.fillRegion(x, y, w, h, cell)
+}
+
+// fillRegion is the same as FillRegion but the caller must hold the lock.
+func (dt *DtTable) fillRegion(x, y, w, h int, cell *DtCell) {
+	left, top, right, bottom, ok := dt.clipRegion(x, y, w, h)
+	if !ok {
+		return
+	}
+
+	dt.own()
+
+	value := cellValue(cell)
+
+	for cy := top; cy < bottom; cy++ {
+		for cx := left; cx < right; cx++ {
+			dt.cells[cy*dt.width+cx] = value
+		}
+	}
+}
+
+// clipRegion clips a region to the table. The lock must be held.
+//
+// Parameters:
+//   - x: The x-coordinate of the top-left corner.
+//   - y: The y-coordinate of the top-left corner.
+//   - w: The width of the region.
+//   - h: The height of the region.
+//
+// Returns:
+//   - int: The x-coordinate of the left edge.
+//   - int: The y-coordinate of the top edge.
+//   - int: The x-coordinate after the right edge.
+//   - int: The y-coordinate after the bottom edge.
+//   - bool: False if nothing is left of the region, true otherwise.
+func (dt *DtTable) clipRegion(x, y, w, h int) (int, int, int, int, bool) {
+	if w <= 0 || h <= 0 {
+		return 0, 0, 0, 0, false
+	}
+
+	left, top := max(x, 0), max(y, 0)
+	right, bottom := min(x+w, dt.width), min(y+h, dt.height)
+
+	if left >= right || top >= bottom {
+		return 0, 0, 0, 0, false
+	}
+
+	return left, top, right, bottom, true
+}
+
+// ClearRegion empties all the cells of a region. See FillRegion.
+//
+// Parameters:
+//   - x: The x-coordinate of the top-left corner.
+//   - y: The y-coordinate of the top-left corner.
+//   - w: The width of the region.
+//   - h: The height of the region.
+func (dt *DtTable) ClearRegion(x, y, w, h int) {
+	dt.FillRegion(x, y, w, h, nil)
+}
+
+// FloodFill transforms the region of contiguous cells that match, starting
+// from the given cell, such as to expand a selection or to paint an area.
+// Cells are contiguous when they share an edge. The whole region is
+// transformed at once: readers never see it half done.
+//
+// Parameters:
+//   - x: The x-coordinate of the starting cell.
+//   - y: The y-coordinate of the starting cell.
+//   - match: The function that tells whether a cell belongs to the region.
+//     It receives nil for empty cells.
+//   - apply: The function that returns the new cell given the current one.
+//
+// Nothing is done if a function is nil, the coordinates are out of bounds or
+// the starting cell does not match. Each cell is tested against its content
+// before the fill, so apply may return cells that match.
+func (dt *DtTable) FloodFill(x, y int, match func(*DtCell) bool, apply func(*DtCell) *DtCell) {
+	if match == nil || apply == nil {
+		return
+	}
+
+	dt.mu.Lock()
+	defer dt.mu.Unlock()
+
+	height, width := dt.height, dt.width
+
+	if !dt.inBounds(x, y) {
+		return
+	}
+
+	visited := make([]bool, width*height)
+
+	var region []Position
+
+	stack := []Position{{X: x, Y: y}}
+	visited[y*width+x] = true
+
+	for len(stack) > 0 {
+		pos := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		if !match(cellPointer(dt.cells[pos.Y*width+pos.X])) {
+			continue
+		}
+
+		region = append(region, pos)
+
+		for _, next := range [4]Position{
+			{X: pos.X - 1, Y: pos.Y},
+			{X: pos.X + 1, Y: pos.Y},
+			{X: pos.X, Y: pos.Y - 1},
+			{X: pos.X, Y: pos.Y + 1},
+		} {
+			if next.X < 0 || next.X >= width || next.Y < 0 || next.Y >= height {
+				continue
+			}
+
+			if i := next.Y*width + next.X; !visited[i] {
+				visited[i] = true
+				stack = append(stack, next)
+			}
+		}
+	}
+
+	if len(region) == 0 {
+		return
+	}
+
+	dt.own()
+
+	for _, pos := range region {
+		i := pos.Y*width + pos.X
+
+		dt.cells[i] = cellValue(apply(cellPointer(dt.cells[i])))
+	}
+}
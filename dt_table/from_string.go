@@ -0,0 +1,97 @@
+package dt_table
+
+import (
+	"strings"
+
+	"github.com/gdamore/tcell"
+	"github.com/mattn/go-runewidth"
+)
+
+// TabWidth is the distance between two tab stops.
+const TabWidth int = 8
+
+// NewDtTableFromString lays out plain text into a table, ready to render.
+//
+// Parameters:
+//   - s: The text. Lines are separated by "\n"; "\r" is ignored.
+//   - style: The style of the cells.
+//   - maxWidth: The maximum width of the table. Longer lines are wrapped at
+//     that width. If less than 1, lines are never wrapped.
+//
+// Returns:
+//   - *DtTable: The new table. Its width is that of the longest line, and
+//     its height is the number of lines after wrapping.
+//
+// Tabs are expanded to spaces up to the next multiple of TabWidth. Wide runes
+// take two cells, the second of which is a Continuation cell; a wide rune
+// that does not fit at the end of a line is moved to the next one. Combining
+// marks are added to the cell before them, or dropped at the start of a line.
+// Other runes of width zero, such as control characters, are dropped.
+func NewDtTableFromString(s string, style tcell.Style, maxWidth int) *DtTable {
+	var rows [][]*DtCell
+
+	var current []*DtCell
+
+	put := func(cell *DtCell, w int) {
+		if maxWidth > 0 && len(current)+w > maxWidth && len(current) > 0 {
+			rows = append(rows, current)
+			current = nil
+		}
+
+		current = append(current, cell)
+
+		for ; w > 1; w-- {
+			current = append(current, NewDtCell(Continuation, cell.Style))
+		}
+	}
+
+	for i, line := range strings.Split(s, "\n") {
+		if i > 0 {
+			rows = append(rows, current)
+			current = nil
+		}
+
+		for _, r := range line {
+			if r == '\t' {
+				n := TabWidth - len(current)%TabWidth
+
+				if maxWidth > 0 {
+					// Tabs stop at the end of the line instead of wrapping.
+					n = max(min(n, maxWidth-len(current)), 1)
+				}
+
+				for ; n > 0; n-- {
+					put(NewDtCell(' ', style), 1)
+				}
+
+				continue
+			}
+
+			if isCombining(r) {
+				_ = markLast(current, r)
+				continue
+			}
+
+			w := runewidth.RuneWidth(r)
+			if w == 0 {
+				continue
+			}
+
+			if maxWidth > 0 {
+				w = min(w, maxWidth)
+			}
+
+			put(NewDtCell(r, style), w)
+		}
+	}
+
+	rows = append(rows, current)
+
+	width := 0
+
+	for _, row := range rows {
+		width = max(width, len(row))
+	}
+
+	return newDtTableFromRows(width, rows)
+}
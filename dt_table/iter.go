@@ -0,0 +1,69 @@
+package dt_table
+
+import (
+	"iter"
+)
+
+// Position is the coordinates of a cell in a table.
+type Position struct {
+	// X is the x-coordinate.
+	X int
+
+	// Y is the y-coordinate.
+	Y int
+}
+
+// Rows is a method that returns an iterator over the rows of the table.
+//
+// Returns:
+//   - iter.Seq2[int, []*DtCell]: An iterator over the index and the cells of
+//     each row. Never returns nil.
+//
+// The cells are taken as one frame when the iteration starts, so the table
+// may be modified while iterating.
+func (dt *DtTable) Rows() iter.Seq2[int, []*DtCell] {
+	if dt == nil {
+		return func(yield func(int, []*DtCell) bool) {}
+	}
+
+	fn := func(yield func(int, []*DtCell) bool) {
+		f := dt.snapshot()
+
+		for y := range f.height {
+			if !yield(y, f.pointers(y)) {
+				return
+			}
+		}
+	}
+
+	return fn
+}
+
+// Cells is a method that returns an iterator over the cells of the table,
+// row by row.
+//
+// Returns:
+//   - iter.Seq2[Position, *DtCell]: An iterator over the position and the
+//     cell of each cell, empty ones included. Never returns nil.
+//
+// The cells are taken as one frame when the iteration starts, so the table
+// may be modified while iterating.
+func (dt *DtTable) Cells() iter.Seq2[Position, *DtCell] {
+	if dt == nil {
+		return func(yield func(Position, *DtCell) bool) {}
+	}
+
+	fn := func(yield func(Position, *DtCell) bool) {
+		f := dt.snapshot()
+
+		for y := range f.height {
+			for x, cell := range f.pointers(y) {
+				if !yield(Position{X: x, Y: y}, cell) {
+					return
+				}
+			}
+		}
+	}
+
+	return fn
+}
@@ -0,0 +1,98 @@
+package dt_table
+
+import (
+	"fmt"
+
+	gcers "github.com/PlayerR9/go-errors"
+)
+
+// JoinHorizontal places two tables side by side, so that split-pane layouts
+// can be assembled from independent tables.
+//
+// Parameters:
+//   - a: The table on the left.
+//   - b: The table on the right.
+//   - gap: The number of empty columns between them.
+//
+// Returns:
+//   - *DtTable: The new table, as tall as the tallest of a and b. The shorter
+//     one is padded with empty cells at the bottom.
+//   - error: An error of type *errors.ErrNilParameter if a or b is nil, or of
+//     type *errors.ErrInvalidParameter if gap is less than 0.
+//
+// The spans are kept.
+func JoinHorizontal(a, b *DtTable, gap int) (*DtTable, error) {
+	return join(a, b, gap, true)
+}
+
+// JoinVertical places a table above another. See JoinHorizontal.
+//
+// Parameters:
+//   - a: The table on the top.
+//   - b: The table on the bottom.
+//   - gap: The number of empty rows between them.
+//
+// Returns:
+//   - *DtTable: The new table, as wide as the widest of a and b. The
+//     narrower one is padded with empty cells on the right.
+//   - error: An error of type *errors.ErrNilParameter if a or b is nil, or of
+//     type *errors.ErrInvalidParameter if gap is less than 0.
+func JoinVertical(a, b *DtTable, gap int) (*DtTable, error) {
+	return join(a, b, gap, false)
+}
+
+// join is the implementation of JoinHorizontal and JoinVertical.
+//
+// Parameters:
+//   - a: The first table.
+//   - b: The second table.
+//   - gap: The number of empty lines between them.
+//   - horizontal: True to join side by side, false to join top to bottom.
+//
+// Returns:
+//   - *DtTable: The new table.
+//   - error: An error if the parameters are invalid.
+func join(a, b *DtTable, gap int, horizontal bool) (*DtTable, error) {
+	if a == nil {
+		return nil, gcers.NewErrNilParameter("a")
+	} else if b == nil {
+		return nil, gcers.NewErrNilParameter("b")
+	} else if gap < 0 {
+		return nil, gcers.NewErrInvalidParameter(fmt.Sprintf("gap must be non-negative, got %d", gap))
+	}
+
+	// Both tables are never locked at the same time.
+	first, firstSpans := a.snapshotWithSpans()
+	second, secondSpans := b.snapshotWithSpans()
+
+	var width, height, dx, dy int
+
+	if horizontal {
+		dx = first.width + gap
+		width = dx + second.width
+		height = max(first.height, second.height)
+	} else {
+		dy = first.height + gap
+		width = max(first.width, second.width)
+		height = dy + second.height
+	}
+
+	out, err := NewDtTable(height, width)
+	if err != nil {
+		panic(fmt.Errorf("error creating table: %w", err))
+	}
+
+	out.blit(first, 0, 0, nil)
+	out.blit(second, dx, dy, nil)
+
+	out.spans = append(out.spans, firstSpans...)
+
+	for _, s := range secondSpans {
+		s.X += dx
+		s.Y += dy
+
+		out.spans = append(out.spans, s)
+	}
+
+	return out, nil
+}
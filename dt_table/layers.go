@@ -0,0 +1,277 @@
+package dt_table
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"sync"
+
+	gcers "github.com/PlayerR9/go-errors"
+)
+
+// layer is a table of a LayerStack.
+type layer struct {
+	// name is the name of the layer.
+	name string
+
+	// table is the content of the layer.
+	table *DtTable
+
+	// x and y are the position of the top-left corner of the layer.
+	x, y int
+
+	// z is the z-index of the layer.
+	z int
+
+	// hidden tells whether the layer is left out of the frames.
+	hidden bool
+}
+
+// LayerStack holds named tables stacked by z-index, so that UI chrome,
+// content and overlays can be managed independently and composited into one
+// frame. It is safe for concurrent use.
+type LayerStack struct {
+	// width and height are the size of the frames.
+	width, height int
+
+	// layers are the layers, in the order they were added.
+	layers []*layer
+
+	// mu protects the fields above.
+	mu sync.RWMutex
+}
+
+// NewLayerStack creates a new LayerStack without layers.
+//
+// Parameters:
+//   - width: The width of the frames. Negative values are treated as 0.
+//   - height: The height of the frames. Negative values are treated as 0.
+//
+// Returns:
+//   - *LayerStack: The new LayerStack. Never returns nil.
+func NewLayerStack(width, height int) *LayerStack {
+	return &LayerStack{
+		width:  max(width, 0),
+		height: max(height, 0),
+	}
+}
+
+// find returns the layer with the given name. The lock must be held.
+//
+// Parameters:
+//   - name: The name of the layer.
+//
+// Returns:
+//   - *layer: The layer. Nil if there is none.
+func (ls *LayerStack) find(name string) *layer {
+	for _, l := range ls.layers {
+		if l.name == name {
+			return l
+		}
+	}
+
+	return nil
+}
+
+// Add adds a visible layer at the top-left corner of the frames.
+//
+// Parameters:
+//   - name: The name of the layer.
+//   - table: The content of the layer. It is shared, so later changes to it
+//     show in the next frames.
+//   - z: The z-index of the layer. Layers with a higher z-index are drawn
+//     over those with a lower one; layers with the same z-index are drawn in
+//     the order they were added.
+//
+// Returns:
+//   - error: An error of type *errors.ErrNilParameter if table is nil, or of
+//     type *errors.ErrInvalidParameter if a layer with the same name exists.
+func (ls *LayerStack) Add(name string, table *DtTable, z int) error {
+	if table == nil {
+		return gcers.NewErrNilParameter("table")
+	}
+
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+
+	if ls.find(name) != nil {
+		return gcers.NewErrInvalidParameter(fmt.Sprintf("layer %q already exists", name))
+	}
+
+	ls.layers = append(ls.layers, &layer{
+		name:  name,
+		table: table,
+		z:     z,
+	})
+
+	return nil
+}
+
+// Remove removes a layer.
+//
+// Parameters:
+//   - name: The name of the layer.
+//
+// Returns:
+//   - bool: True if the layer was removed, false if there is none.
+func (ls *LayerStack) Remove(name string) bool {
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+
+	n := len(ls.layers)
+
+	ls.layers = slices.DeleteFunc(ls.layers, func(l *layer) bool {
+		return l.name == name
+	})
+
+	return len(ls.layers) < n
+}
+
+// Get returns the content of a layer.
+//
+// Parameters:
+//   - name: The name of the layer.
+//
+// Returns:
+//   - *DtTable: The content of the layer. Nil if there is none.
+//   - bool: True if the layer exists, false otherwise.
+func (ls *LayerStack) Get(name string) (*DtTable, bool) {
+	ls.mu.RLock()
+	defer ls.mu.RUnlock()
+
+	l := ls.find(name)
+	if l == nil {
+		return nil, false
+	}
+
+	return l.table, true
+}
+
+// update applies a function to a layer.
+//
+// Parameters:
+//   - name: The name of the layer.
+//   - fn: The function.
+//
+// Returns:
+//   - bool: True if the layer exists, false otherwise.
+func (ls *LayerStack) update(name string, fn func(l *layer)) bool {
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+
+	l := ls.find(name)
+	if l == nil {
+		return false
+	}
+
+	fn(l)
+
+	return true
+}
+
+// SetZ changes the z-index of a layer.
+//
+// Parameters:
+//   - name: The name of the layer.
+//   - z: The new z-index.
+//
+// Returns:
+//   - bool: True if the layer exists, false otherwise.
+func (ls *LayerStack) SetZ(name string, z int) bool {
+	return ls.update(name, func(l *layer) {
+		l.z = z
+	})
+}
+
+// SetVisible shows or hides a layer.
+//
+// Parameters:
+//   - name: The name of the layer.
+//   - visible: Whether the layer is drawn.
+//
+// Returns:
+//   - bool: True if the layer exists, false otherwise.
+func (ls *LayerStack) SetVisible(name string, visible bool) bool {
+	return ls.update(name, func(l *layer) {
+		l.hidden = !visible
+	})
+}
+
+// MoveTo moves a layer.
+//
+// Parameters:
+//   - name: The name of the layer.
+//   - x: The x-coordinate of the top-left corner. May be negative.
+//   - y: The y-coordinate of the top-left corner. May be negative.
+//
+// Returns:
+//   - bool: True if the layer exists, false otherwise.
+func (ls *LayerStack) MoveTo(name string, x, y int) bool {
+	return ls.update(name, func(l *layer) {
+		l.x, l.y = x, y
+	})
+}
+
+// Resize changes the size of the frames.
+//
+// Parameters:
+//   - width: The new width. Negative values are treated as 0.
+//   - height: The new height. Negative values are treated as 0.
+func (ls *LayerStack) Resize(width, height int) {
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+
+	ls.width = max(width, 0)
+	ls.height = max(height, 0)
+}
+
+// Compose composites the visible layers into a frame, from the lowest
+// z-index to the highest. The empty cells of a layer let the layers below
+// show through.
+//
+// Returns:
+//   - *DtTable: The frame.
+func (ls *LayerStack) Compose() *DtTable {
+	ls.mu.RLock()
+
+	width, height := ls.width, ls.height
+
+	layers := make([]layer, 0, len(ls.layers))
+
+	for _, l := range ls.layers {
+		if !l.hidden {
+			layers = append(layers, *l)
+		}
+	}
+
+	ls.mu.RUnlock()
+
+	slices.SortStableFunc(layers, func(a, b layer) int {
+		return cmp.Compare(a.z, b.z)
+	})
+
+	frame, err := NewDtTable(height, width)
+	if err != nil {
+		panic(fmt.Errorf("error creating table: %w", err))
+	}
+
+	for _, l := range layers {
+		err := frame.Overlay(l.table, l.x, l.y, isEmptyCell)
+		if err != nil {
+			panic(fmt.Errorf("error drawing layer %q: %w", l.name, err))
+		}
+	}
+
+	return frame
+}
+
+// isEmptyCell checks whether a cell is empty.
+//
+// Parameters:
+//   - cell: The cell.
+//
+// Returns:
+//   - bool: True if the cell is nil, false otherwise.
+func isEmptyCell(cell *DtCell) bool {
+	return cell == nil
+}
@@ -0,0 +1,64 @@
+package dt_table
+
+import (
+	gcers "github.com/PlayerR9/go-errors"
+)
+
+// Overlay blits another table onto the table, so that dialogs, popups and
+// sprites can be composed from multiple tables. The parts of other that fall
+// outside of the table are clipped.
+//
+// Parameters:
+//   - other: The table to blit.
+//   - x: The x-coordinate of the top-left corner of other. May be negative.
+//   - y: The y-coordinate of the top-left corner of other. May be negative.
+//   - transparent: The function that tells which cells of other let the
+//     cells below show through. If nil, every cell of other is copied,
+//     including the empty ones.
+//
+// Returns:
+//   - error: An error of type *errors.ErrNilParameter if other is nil.
+func (dt *DtTable) Overlay(other *DtTable, x, y int, transparent func(*DtCell) bool) error {
+	if other == nil {
+		return gcers.NewErrNilParameter("other")
+	}
+
+	// The source is copied first so that both tables are never locked at the
+	// same time.
+	src := other.snapshot()
+
+	dt.mu.Lock()
+	defer dt.mu.Unlock()
+
+	dt.blit(src, x, y, transparent)
+
+	return nil
+}
+
+// blit copies a frame onto the table, clipped to the table. The lock must be
+// held for writing.
+//
+// Parameters:
+//   - src: The frame to copy.
+//   - x: The x-coordinate of the top-left corner of src. May be negative.
+//   - y: The y-coordinate of the top-left corner of src. May be negative.
+//   - transparent: The function that tells which cells of src to skip. If
+//     nil, no cell is skipped.
+func (dt *DtTable) blit(src frame, x, y int, transparent func(*DtCell) bool) {
+	left, top, right, bottom, ok := dt.clipRegion(x, y, src.width, src.height)
+	if !ok {
+		return
+	}
+
+	dt.own()
+
+	for cy := top; cy < bottom; cy++ {
+		for cx := left; cx < right; cx++ {
+			cell := src.cells[(cy-y)*src.width+cx-x]
+
+			if transparent == nil || !transparent(cellPointer(cell)) {
+				dt.cells[cy*dt.width+cx] = cell
+			}
+		}
+	}
+}
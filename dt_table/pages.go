@@ -0,0 +1,132 @@
+package dt_table
+
+import (
+	"fmt"
+
+	gcers "github.com/PlayerR9/go-errors"
+	cs "github.com/PlayerR9/safe/c_string"
+	"github.com/gdamore/tcell"
+	"github.com/mattn/go-runewidth"
+)
+
+// NewDtTableFromPages lays out the pages of a c_string.Printer into a table,
+// so that formatted documents can be displayed.
+//
+// Parameters:
+//   - pages: The pages, as returned by Printer.GetPages.
+//   - width: The width of the table. Lines that are longer are wrapped
+//     between words; words that are longer are broken.
+//
+// Returns:
+//   - *DtTable: The new table. Its height is the number of rows needed.
+//   - error: An error of type *errors.ErrInvalidParameter if width is less
+//     than 1.
+//
+// Every line of every section takes at least one row, with its words
+// separated by one space in the default style. Pages are separated by an
+// empty row. Tabs are written as spaces. Wide runes take two cells, the
+// second of which is a Continuation cell, and are never split between rows;
+// combining marks are added to the cell before them.
+func NewDtTableFromPages(pages [][][][][]*cs.Unit, width int) (*DtTable, error) {
+	if width < 1 {
+		return nil, gcers.NewErrInvalidParameter(fmt.Sprintf("width must be positive, got %d", width))
+	}
+
+	var rows [][]*DtCell
+
+	for i, page := range pages {
+		if i > 0 {
+			rows = append(rows, nil)
+		}
+
+		for _, section := range page {
+			if len(section) == 0 {
+				rows = append(rows, nil)
+				continue
+			}
+
+			for _, line := range section {
+				rows = append(rows, layoutLine(line, width)...)
+			}
+		}
+	}
+
+	return newDtTableFromRows(width, rows), nil
+}
+
+// layoutLine lays out a line of words into rows of the given width.
+//
+// Parameters:
+//   - line: The words of the line.
+//   - width: The width of the rows. Must be positive.
+//
+// Returns:
+//   - [][]*DtCell: The rows. At least one.
+func layoutLine(line [][]*cs.Unit, width int) [][]*DtCell {
+	var rows [][]*DtCell
+
+	var current []*DtCell
+
+	for _, word := range line {
+		var cells []*DtCell
+
+		for _, unit := range word {
+			if unit == nil {
+				continue
+			}
+
+			for _, r := range unit.Content {
+				if r == '\t' {
+					r = ' '
+				}
+
+				if isCombining(r) {
+					_ = markLast(cells, r)
+					continue
+				}
+
+				w := runewidth.RuneWidth(r)
+				if w == 0 {
+					continue
+				}
+
+				cells = append(cells, NewDtCell(r, unit.Style))
+
+				if w == 2 && width > 1 {
+					cells = append(cells, NewDtCell(Continuation, unit.Style))
+				}
+			}
+		}
+
+		if len(cells) == 0 {
+			continue
+		}
+
+		if len(current) > 0 {
+			if len(current)+1+len(cells) > width {
+				rows = append(rows, current)
+				current = nil
+			} else {
+				current = append(current, NewDtCell(' ', tcell.StyleDefault))
+			}
+		}
+
+		for len(current)+len(cells) > width {
+			n := width - len(current)
+
+			// A wide rune is never split between two rows.
+			if cells[n].Content == Continuation {
+				n--
+			}
+
+			rows = append(rows, append(current, cells[:n]...))
+
+			current = nil
+			cells = cells[n:]
+		}
+
+		current = append(current, cells...)
+	}
+
+	return append(rows, current)
+}
@@ -0,0 +1,99 @@
+package dt_table
+
+import (
+	"strings"
+
+	cs "github.com/PlayerR9/safe/c_string"
+	"github.com/gdamore/tcell"
+)
+
+// Render returns the content of the table as plain text, one line per row.
+// Styles are dropped, and empty cells and cells hidden by spans are rendered
+// as spaces. Wide runes take two columns, their continuation cell included.
+//
+// Returns:
+//   - string: The text of the table.
+func (dt *DtTable) Render() string {
+	dt.mu.RLock()
+	defer dt.mu.RUnlock()
+
+	var builder strings.Builder
+
+	hidden := dt.hiddenCells()
+
+	for i := range dt.height {
+		if i > 0 {
+			builder.WriteRune('\n')
+		}
+
+		row := dt.cells[i*dt.width : (i+1)*dt.width]
+
+		for j := range row {
+			if _, ok := hidden[[2]int{j, i}]; ok {
+				builder.WriteRune(' ')
+			} else {
+				builder.WriteString(glyph(row, j))
+			}
+		}
+	}
+
+	return builder.String()
+}
+
+// RenderANSI is like Render but the styles of the cells are converted to SGR
+// escape codes, so that the table can be printed to any terminal without
+// tcell.
+//
+// Returns:
+//   - string: The text of the table.
+//
+// The attributes are reset at the end of every line that changed them, so
+// that the lines can be printed independently.
+func (dt *DtTable) RenderANSI() string {
+	dt.mu.RLock()
+	defer dt.mu.RUnlock()
+
+	var builder strings.Builder
+
+	hidden := dt.hiddenCells()
+
+	for i := range dt.height {
+		if i > 0 {
+			builder.WriteRune('\n')
+		}
+
+		current := tcell.StyleDefault
+
+		row := dt.cells[i*dt.width : (i+1)*dt.width]
+
+		for j, cell := range row {
+			style := tcell.StyleDefault
+			content := " "
+
+			if span, ok := hidden[[2]int{j, i}]; ok {
+				style = span
+			} else if cell.Content != 0 {
+				style = cell.Style
+				content = glyph(row, j)
+			}
+
+			if style != current {
+				if current != tcell.StyleDefault {
+					builder.WriteString(cs.SGRReset)
+				}
+
+				builder.WriteString(cs.SGR(style))
+
+				current = style
+			}
+
+			builder.WriteString(content)
+		}
+
+		if current != tcell.StyleDefault {
+			builder.WriteString(cs.SGRReset)
+		}
+	}
+
+	return builder.String()
+}
@@ -0,0 +1,145 @@
+package dt_table
+
+import (
+	"fmt"
+
+	gcers "github.com/PlayerR9/go-errors"
+)
+
+// SetScrollback enables or disables the scrollback: when enabled, the rows
+// that ScrollUp pushes off the top of the table are kept in a history, as in
+// a terminal emulator, and can be brought back into view with
+// ScrollbackFrame.
+//
+// Parameters:
+//   - limit: The maximum number of rows kept; the oldest ones are dropped
+//     first. 0 disables the scrollback and clears the history.
+//
+// Returns:
+//   - error: An error of type *errors.ErrInvalidParameter if limit is less
+//     than 0.
+func (dt *DtTable) SetScrollback(limit int) error {
+	if limit < 0 {
+		return gcers.NewErrInvalidParameter(fmt.Sprintf("limit must be non-negative, got %d", limit))
+	}
+
+	dt.mu.Lock()
+	defer dt.mu.Unlock()
+
+	dt.historyLimit = limit
+
+	dt.trimHistory()
+
+	return nil
+}
+
+// trimHistory drops the oldest rows of the history that exceed the limit.
+// The lock must be held for writing.
+func (dt *DtTable) trimHistory() {
+	excess := len(dt.history) - dt.historyLimit
+	if excess <= 0 {
+		return
+	}
+
+	// A new slice lets the dropped rows be collected.
+	dt.history = append([][]DtCell(nil), dt.history[excess:]...)
+}
+
+// ScrollUp moves the content of the table up, adding empty rows at the
+// bottom. The rows pushed off the top go to the scrollback, if enabled.
+//
+// Parameters:
+//   - n: The number of rows to scroll. Values less than 1 do nothing.
+//
+// Spans are moved along; those whose top-left corner is pushed off are
+// removed.
+func (dt *DtTable) ScrollUp(n int) {
+	if n < 1 {
+		return
+	}
+
+	dt.mu.Lock()
+	defer dt.mu.Unlock()
+
+	n = min(n, dt.height)
+	if n == 0 {
+		return
+	}
+
+	if dt.historyLimit > 0 {
+		for y := range n {
+			// Copied, since the cells are about to be overwritten.
+			row := make([]DtCell, dt.width)
+			copy(row, dt.cells[y*dt.width:])
+
+			dt.history = append(dt.history, row)
+		}
+
+		dt.trimHistory()
+	}
+
+	dt.own()
+
+	copy(dt.cells, dt.cells[n*dt.width:])
+	clear(dt.cells[(dt.height-n)*dt.width:])
+
+	dt.moveSpans(0, -n)
+}
+
+// HistoryLen returns the number of rows in the scrollback.
+//
+// Returns:
+//   - int: The number of rows.
+func (dt *DtTable) HistoryLen() int {
+	dt.mu.RLock()
+	defer dt.mu.RUnlock()
+
+	return len(dt.history)
+}
+
+// ClearScrollback empties the scrollback. It stays enabled.
+func (dt *DtTable) ClearScrollback() {
+	dt.mu.Lock()
+	defer dt.mu.Unlock()
+
+	dt.history = nil
+}
+
+// ScrollbackFrame returns what the table looks like when scrolled back into
+// its history.
+//
+// Parameters:
+//   - offset: The number of rows to scroll back. It is clamped to
+//     [0, HistoryLen()].
+//
+// Returns:
+//   - *DtTable: A new table of the size of the table: the last offset rows
+//     of the history, followed by the top rows of the table. Rows of the
+//     history that were pushed at another width are padded or truncated.
+//     Spans are left out.
+func (dt *DtTable) ScrollbackFrame(offset int) *DtTable {
+	dt.mu.RLock()
+	defer dt.mu.RUnlock()
+
+	offset = max(min(offset, len(dt.history)), 0)
+
+	out, err := NewDtTable(dt.height, dt.width)
+	if err != nil {
+		panic(fmt.Errorf("error creating table: %w", err))
+	}
+
+	for y := range dt.height {
+		var row []DtCell
+
+		if y < offset {
+			row = dt.history[len(dt.history)-offset+y]
+		} else {
+			from := (y - offset) * dt.width
+			row = dt.cells[from : from+dt.width]
+		}
+
+		copy(out.cells[y*dt.width:(y+1)*dt.width], row)
+	}
+
+	return out
+}
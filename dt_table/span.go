@@ -0,0 +1,286 @@
+package dt_table
+
+import (
+	"fmt"
+	"slices"
+
+	gcers "github.com/PlayerR9/go-errors"
+	"github.com/gdamore/tcell"
+)
+
+// Span is a rectangle of cells merged into one, such as a header over several
+// columns. The cell of the span is stored at its top-left corner; the other
+// cells it covers are hidden and rendered as blanks in the style of the span.
+type Span struct {
+	// X is the x-coordinate of the top-left corner.
+	X int `json:"x"`
+
+	// Y is the y-coordinate of the top-left corner.
+	Y int `json:"y"`
+
+	// W is the width of the span.
+	W int `json:"w"`
+
+	// H is the height of the span.
+	H int `json:"h"`
+}
+
+// contains checks whether the span covers the given coordinates.
+//
+// Parameters:
+//   - x: The x-coordinate.
+//   - y: The y-coordinate.
+//
+// Returns:
+//   - bool: True if the span covers the coordinates, false otherwise.
+func (s Span) contains(x, y int) bool {
+	return x >= s.X && x < s.X+s.W && y >= s.Y && y < s.Y+s.H
+}
+
+// overlaps checks whether two spans share at least one cell.
+//
+// Parameters:
+//   - other: The other span.
+//
+// Returns:
+//   - bool: True if the spans overlap, false otherwise.
+func (s Span) overlaps(other Span) bool {
+	return s.X < other.X+other.W && other.X < s.X+s.W && s.Y < other.Y+other.H && other.Y < s.Y+s.H
+}
+
+// SetSpanAt merges a rectangle of cells into one. The spans the rectangle
+// overlaps are removed.
+//
+// Parameters:
+//   - x: The x-coordinate of the top-left corner.
+//   - y: The y-coordinate of the top-left corner.
+//   - w: The width of the span.
+//   - h: The height of the span.
+//   - cell: The cell of the span.
+//
+// Returns:
+//   - error: An error of type *errors.ErrInvalidParameter if the rectangle is
+//     empty or not entirely inside the table.
+//
+// Spans are moved, stretched and shrunk by the insertion and deletion of rows
+// and columns, and clipped by resizes. A span is removed when its top-left
+// corner is deleted or clipped.
+func (dt *DtTable) SetSpanAt(x, y, w, h int, cell *DtCell) error {
+	if w <= 0 || h <= 0 {
+		return gcers.NewErrInvalidParameter(fmt.Sprintf("size must be positive, got %dx%d", w, h))
+	}
+
+	dt.mu.Lock()
+	defer dt.mu.Unlock()
+
+	if y < 0 || y+h > dt.height {
+		return gcers.NewErrInvalidParameter(fmt.Sprintf("rows [%d, %d) are out of [0, %d)", y, y+h, dt.height))
+	} else if x < 0 || x+w > dt.width {
+		return gcers.NewErrInvalidParameter(fmt.Sprintf("columns [%d, %d) are out of [0, %d)", x, x+w, dt.width))
+	}
+
+	err := dt.setCellAt(x, y, cell)
+	if err != nil {
+		panic(fmt.Errorf("error setting cell: %w", err))
+	}
+
+	span := Span{X: x, Y: y, W: w, H: h}
+
+	dt.spans = filterSpans(dt.spans, func(s Span) (Span, bool) {
+		return s, !s.overlaps(span)
+	})
+
+	dt.spans = append(dt.spans, span)
+
+	return nil
+}
+
+// GetSpanAt returns the span that covers the given coordinates.
+//
+// Parameters:
+//   - x: The x-coordinate.
+//   - y: The y-coordinate.
+//
+// Returns:
+//   - Span: The span. The zero value if there is none.
+//   - bool: True if a span covers the coordinates, false otherwise.
+func (dt *DtTable) GetSpanAt(x, y int) (Span, bool) {
+	dt.mu.RLock()
+	defer dt.mu.RUnlock()
+
+	for _, s := range dt.spans {
+		if s.contains(x, y) {
+			return s, true
+		}
+	}
+
+	return Span{}, false
+}
+
+// RemoveSpanAt removes the span that covers the given coordinates. Its cell
+// stays at its top-left corner and the other cells are shown again.
+//
+// Parameters:
+//   - x: The x-coordinate.
+//   - y: The y-coordinate.
+//
+// Returns:
+//   - bool: True if a span was removed, false otherwise.
+func (dt *DtTable) RemoveSpanAt(x, y int) bool {
+	dt.mu.Lock()
+	defer dt.mu.Unlock()
+
+	n := len(dt.spans)
+
+	dt.spans = filterSpans(dt.spans, func(s Span) (Span, bool) {
+		return s, !s.contains(x, y)
+	})
+
+	return len(dt.spans) < n
+}
+
+// hiddenCells returns the styles of the cells hidden by the spans. The lock
+// must be held.
+//
+// Returns:
+//   - map[[2]int]tcell.Style: The style of the span that hides each cell,
+//     keyed by {x, y}. Nil if there is no span.
+func (dt *DtTable) hiddenCells() map[[2]int]tcell.Style {
+	if len(dt.spans) == 0 {
+		return nil
+	}
+
+	hidden := make(map[[2]int]tcell.Style)
+
+	for _, s := range dt.spans {
+		style := tcell.StyleDefault
+
+		if cell := dt.cells[s.Y*dt.width+s.X]; cell.Content != 0 {
+			style = cell.Style
+		}
+
+		for y := s.Y; y < s.Y+s.H; y++ {
+			for x := s.X; x < s.X+s.W; x++ {
+				if x != s.X || y != s.Y {
+					hidden[[2]int{x, y}] = style
+				}
+			}
+		}
+	}
+
+	return hidden
+}
+
+// snapshotWithSpans is like snapshot but the spans are copied too, in the
+// same frame.
+//
+// Returns:
+//   - frame: The copy of the cells.
+//   - []Span: The copy of the spans.
+func (dt *DtTable) snapshotWithSpans() (frame, []Span) {
+	dt.mu.RLock()
+	defer dt.mu.RUnlock()
+
+	return frame{
+		width:  dt.width,
+		height: dt.height,
+		cells:  slices.Clone(dt.cells),
+	}, slices.Clone(dt.spans)
+}
+
+// adjustSpans is a private method of DtTable that applies a function to all
+// the spans. The lock must be held for writing.
+//
+// Parameters:
+//   - fn: The function that returns the updated span and whether to keep it.
+func (dt *DtTable) adjustSpans(fn func(s Span) (Span, bool)) {
+	dt.spans = filterSpans(dt.spans, fn)
+}
+
+// filterSpans updates spans in place.
+//
+// Parameters:
+//   - spans: The spans.
+//   - fn: The function that returns the updated span and whether to keep it.
+//
+// Returns:
+//   - []Span: The kept spans.
+func filterSpans(spans []Span, fn func(s Span) (Span, bool)) []Span {
+	kept := spans[:0]
+
+	for _, s := range spans {
+		s, ok := fn(s)
+		if ok && s.W > 0 && s.H > 0 {
+			kept = append(kept, s)
+		}
+	}
+
+	return kept
+}
+
+// clipSpans is a private method of DtTable that clips the spans to the given
+// size.
+//
+// Parameters:
+//   - width: The width of the table.
+//   - height: The height of the table.
+func (dt *DtTable) clipSpans(width, height int) {
+	dt.adjustSpans(func(s Span) (Span, bool) {
+		if s.X >= width || s.Y >= height {
+			return s, false
+		}
+
+		s.W = min(s.W, width-s.X)
+		s.H = min(s.H, height-s.Y)
+
+		return s, true
+	})
+}
+
+// moveSpans is a private method of DtTable that moves all the spans. The
+// spans whose top-left corner moves out of the table are removed.
+//
+// Parameters:
+//   - dx: The offset along the x-axis.
+//   - dy: The offset along the y-axis.
+func (dt *DtTable) moveSpans(dx, dy int) {
+	if dx == 0 && dy == 0 {
+		return
+	}
+
+	dt.adjustSpans(func(s Span) (Span, bool) {
+		s.X += dx
+		s.Y += dy
+
+		return s, s.X >= 0 && s.Y >= 0
+	})
+}
+
+// shiftSpans is a private method of DtTable that updates the spans after
+// lines were inserted or deleted.
+//
+// Parameters:
+//   - at: The index of the first line inserted or deleted.
+//   - delta: 1 if a line was inserted, -1 if it was deleted.
+//   - column: True for columns, false for rows.
+func (dt *DtTable) shiftSpans(at, delta int, column bool) {
+	dt.adjustSpans(func(s Span) (Span, bool) {
+		pos, size := &s.Y, &s.H
+
+		if column {
+			pos, size = &s.X, &s.W
+		}
+
+		switch {
+		case delta < 0 && *pos == at:
+			// The cell of the span was deleted.
+			return s, false
+		case *pos >= at:
+			*pos += delta
+		case at < *pos+*size:
+			*size += delta
+		}
+
+		return s, true
+	})
+}
@@ -0,0 +1,68 @@
+package dt_table
+
+import (
+	"github.com/gdamore/tcell"
+)
+
+// StyleRegion changes the style of all the cells of a region, such as to
+// highlight a selection or search hits, without changing their runes or data.
+// The parts of the region outside of the table are clipped.
+//
+// Parameters:
+//   - x: The x-coordinate of the top-left corner.
+//   - y: The y-coordinate of the top-left corner.
+//   - w: The width of the region.
+//   - h: The height of the region.
+//   - f: The function that returns the new style of a cell given its current
+//     one. If nil, nothing is changed.
+//
+// Empty cells become spaces in the style f returns for tcell.StyleDefault,
+// so that highlights cover them too.
+func (dt *DtTable) StyleRegion(x, y, w, h int, f func(tcell.Style) tcell.Style) {
+	dt.mu.Lock()
+	defer dt.mu.Unlock()
+
+	dt.styleRegion(x, y, w, h, f)
+}
+
+// styleRegion is the same as StyleRegion but the caller must hold the lock.
+func (dt *DtTable) styleRegion(x, y, w, h int, f func(tcell.Style) tcell.Style) {
+	if f == nil {
+		return
+	}
+
+	left, top, right, bottom, ok := dt.clipRegion(x, y, w, h)
+	if !ok {
+		return
+	}
+
+	dt.own()
+
+	for cy := top; cy < bottom; cy++ {
+		for cx := left; cx < right; cx++ {
+			cell := &dt.cells[cy*dt.width+cx]
+
+			if cell.Content == 0 {
+				cell.Content = ' '
+				cell.Style = tcell.StyleDefault
+			}
+
+			cell.Style = f(cell.Style)
+		}
+	}
+}
+
+// StyleRegion stages the change of the style of a region. See
+// DtTable.StyleRegion.
+//
+// Parameters:
+//   - x: The x-coordinate of the top-left corner.
+//   - y: The y-coordinate of the top-left corner.
+//   - w: The width of the region.
+//   - h: The height of the region.
+//   - f: The function that returns the new style of a cell.
+func (tx *TableTx) StyleRegion(x, y, w, h int, f func(tcell.Style) tcell.Style) {
+	tx.ops = append(tx.ops, func(dt *DtTable) {
+		dt.styleRegion(x, y, w, h, f)
+	})
+}
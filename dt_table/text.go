@@ -0,0 +1,247 @@
+package dt_table
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/gdamore/tcell"
+)
+
+// WriteStringAt writes a string into the table, one rune per cell. Newlines
+// start a new line at column x. Runes outside of the table are dropped. Wide
+// runes take two cells, the second of which is a Continuation cell, and
+// combining marks are added to the cell written before them.
+//
+// Parameters:
+//   - x: The x-coordinate of the first rune.
+//   - y: The y-coordinate of the first rune.
+//   - s: The string to write.
+//   - style: The style of the cells.
+//   - wrap: Whether to wrap the text at the right edge of the table. Lines
+//     are broken between words, back at column x; words that are longer than
+//     a line are broken too. If false, the end of the lines is clipped.
+//
+// Returns:
+//   - int: The x-coordinate after the last rune written.
+//   - int: The y-coordinate of the last line written.
+func (dt *DtTable) WriteStringAt(x, y int, s string, style tcell.Style, wrap bool) (int, int) {
+	width := dt.GetWidth()
+
+	if x >= width {
+		// No room to wrap into.
+		wrap = false
+	}
+
+	cx, cy := x, y
+
+	// last is the cell written last, where combining marks go.
+	var last *DtCell
+
+	lastX, lastY := 0, 0
+
+	put := func(r rune) {
+		if isCombining(r) {
+			if last != nil {
+				last = withMark(last, r)
+				_ = dt.SetCellAt(lastX, lastY, last)
+			}
+
+			return
+		}
+
+		last = NewDtCell(r, style)
+		lastX, lastY = cx, cy
+
+		_ = dt.SetCellAt(cx, cy, last)
+
+		cx++
+
+		if last.Width() == 2 {
+			_ = dt.SetCellAt(cx, cy, NewDtCell(Continuation, style))
+
+			cx++
+		}
+	}
+
+	newline := func() {
+		cx = x
+		cy++
+	}
+
+	for i, line := range strings.Split(s, "\n") {
+		if i > 0 {
+			newline()
+		}
+
+		if !wrap {
+			for _, r := range line {
+				put(r)
+			}
+
+			continue
+		}
+
+		for _, word := range splitWords(line) {
+			n := 0
+
+			for _, r := range word {
+				n += columns(r)
+			}
+
+			if unicode.IsSpace(word[0]) {
+				for _, r := range word {
+					if cx >= width {
+						// Spaces at the end of a wrapped line are dropped.
+						newline()
+						break
+					}
+
+					put(r)
+				}
+
+				continue
+			}
+
+			if cx+n > width && cx > x {
+				newline()
+			}
+
+			for _, r := range word {
+				if cx+columns(r) > width && cx > x {
+					newline()
+				}
+
+				put(r)
+			}
+		}
+	}
+
+	return cx, cy
+}
+
+// columns returns the number of columns a rune takes when written.
+//
+// Parameters:
+//   - r: The rune.
+//
+// Returns:
+//   - int: 0 for combining marks, 2 for wide runes and 1 otherwise.
+func columns(r rune) int {
+	if isCombining(r) {
+		return 0
+	}
+
+	return DtCell{Content: r}.Width()
+}
+
+// splitWords splits a line into runs of spaces and runs of other runes.
+//
+// Parameters:
+//   - line: The line.
+//
+// Returns:
+//   - [][]rune: The runs, in order.
+func splitWords(line string) [][]rune {
+	var words [][]rune
+
+	var current []rune
+
+	for _, r := range line {
+		if len(current) > 0 && unicode.IsSpace(current[0]) != unicode.IsSpace(r) {
+			words = append(words, current)
+			current = nil
+		}
+
+		current = append(current, r)
+	}
+
+	if len(current) > 0 {
+		words = append(words, current)
+	}
+
+	return words
+}
+
+// Alignment is the horizontal alignment of a text.
+type Alignment int
+
+const (
+	// AlignLeft aligns the text to the left edge.
+	AlignLeft Alignment = iota
+
+	// AlignCenter centers the text. When it cannot be centered exactly, the
+	// extra space is on the right.
+	AlignCenter
+
+	// AlignRight aligns the text to the right edge.
+	AlignRight
+)
+
+// String implements the fmt.Stringer interface.
+func (a Alignment) String() string {
+	switch a {
+	case AlignLeft:
+		return "left"
+	case AlignCenter:
+		return "center"
+	case AlignRight:
+		return "right"
+	default:
+		return "unknown"
+	}
+}
+
+// WriteAligned writes a line of text aligned within the width of the table,
+// such as a title or a status bar. See WriteAlignedIn.
+//
+// Parameters:
+//   - y: The y-coordinate of the line.
+//   - s: The text. It is written on one line.
+//   - align: The alignment of the text.
+//   - style: The style of the cells.
+func (dt *DtTable) WriteAligned(y int, s string, align Alignment, style tcell.Style) {
+	dt.WriteAlignedIn(0, y, dt.GetWidth(), s, align, style)
+}
+
+// WriteAlignedIn writes a line of text aligned within a region of a line.
+// Only the cells of the text are written; the rest of the region is left as
+// it is.
+//
+// Parameters:
+//   - x: The x-coordinate of the region.
+//   - y: The y-coordinate of the region.
+//   - w: The width of the region.
+//   - s: The text. It is written on one line, with newlines as spaces, and
+//     truncated to w columns.
+//   - align: The alignment of the text.
+//   - style: The style of the cells.
+func (dt *DtTable) WriteAlignedIn(x, y, w int, s string, align Alignment, style tcell.Style) {
+	if w <= 0 {
+		return
+	}
+
+	runes := []rune(strings.ReplaceAll(s, "\n", " "))
+
+	n := 0
+
+	for i, r := range runes {
+		c := columns(r)
+
+		if n+c > w {
+			runes = runes[:i]
+			break
+		}
+
+		n += c
+	}
+
+	switch align {
+	case AlignCenter:
+		x += (w - n) / 2
+	case AlignRight:
+		x += w - n
+	}
+
+	// Out of bounds cells are clipped.
+	_, _ = dt.WriteStringAt(x, y, string(runes), style, false)
+}
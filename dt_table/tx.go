@@ -0,0 +1,217 @@
+package dt_table
+
+import (
+	"fmt"
+
+	gcers "github.com/PlayerR9/go-errors"
+	sbj "github.com/PlayerR9/safe/subject"
+)
+
+// TableTx stages mutations of a DtTable that are applied all at once. See
+// DtTable.Update.
+type TableTx struct {
+	// width and height are the size the table will have once the staged
+	// mutations are applied.
+	width, height int
+
+	// ops are the staged mutations, in order.
+	ops []func(dt *DtTable)
+}
+
+// SetCellAt stages the setting of a cell.
+//
+// Parameters:
+//   - x: The x-coordinate.
+//   - y: The y-coordinate.
+//   - cell: The cell to set.
+//
+// Returns:
+//   - error: An error of type *errors.ErrInvalidParameter if x and y are out
+//     of bounds, given the mutations staged before. Nothing is staged then.
+func (tx *TableTx) SetCellAt(x, y int, cell *DtCell) error {
+	if y < 0 || y >= tx.height {
+		return gcers.NewErrInvalidParameter(fmt.Sprintf("y must be in [0, %d), got %d", tx.height, y))
+	} else if x < 0 || x >= tx.width {
+		return gcers.NewErrInvalidParameter(fmt.Sprintf("x must be in [0, %d), got %d", tx.width, x))
+	}
+
+	tx.ops = append(tx.ops, func(dt *DtTable) {
+		_ = dt.setCellAt(x, y, cell)
+	})
+
+	return nil
+}
+
+// FillRegion stages the filling of a region. See DtTable.FillRegion.
+//
+// Parameters:
+//   - x: The x-coordinate of the top-left corner.
+//   - y: The y-coordinate of the top-left corner.
+//   - w: The width of the region.
+//   - h: The height of the region.
+//   - cell: The cell to set.
+func (tx *TableTx) FillRegion(x, y, w, h int, cell *DtCell) {
+	tx.ops = append(tx.ops, func(dt *DtTable) {
+		dt.fillRegion(x, y, w, h, cell)
+	})
+}
+
+// ClearRegion stages the clearing of a region. See DtTable.ClearRegion.
+//
+// Parameters:
+//   - x: The x-coordinate of the top-left corner.
+//   - y: The y-coordinate of the top-left corner.
+//   - w: The width of the region.
+//   - h: The height of the region.
+func (tx *TableTx) ClearRegion(x, y, w, h int) {
+	tx.FillRegion(x, y, w, h, nil)
+}
+
+// InsertRow stages the insertion of a row. See DtTable.InsertRow.
+//
+// Parameters:
+//   - y: The index of the new row.
+//
+// Returns:
+//   - error: An error of type *errors.ErrInvalidParameter if y is not in
+//     [0, height], given the mutations staged before. Nothing is staged then.
+func (tx *TableTx) InsertRow(y int) error {
+	if y < 0 || y > tx.height {
+		return gcers.NewErrInvalidParameter(fmt.Sprintf("y must be in [0, %d], got %d", tx.height, y))
+	}
+
+	tx.height++
+
+	tx.ops = append(tx.ops, func(dt *DtTable) {
+		_ = dt.insertRow(y)
+	})
+
+	return nil
+}
+
+// DeleteRow stages the deletion of a row. See DtTable.DeleteRow.
+//
+// Parameters:
+//   - y: The index of the row.
+//
+// Returns:
+//   - error: An error of type *errors.ErrInvalidParameter if y is out of
+//     bounds, given the mutations staged before. Nothing is staged then.
+func (tx *TableTx) DeleteRow(y int) error {
+	if y < 0 || y >= tx.height {
+		return gcers.NewErrInvalidParameter(fmt.Sprintf("y must be in [0, %d), got %d", tx.height, y))
+	}
+
+	tx.height--
+
+	tx.ops = append(tx.ops, func(dt *DtTable) {
+		_ = dt.deleteRow(y)
+	})
+
+	return nil
+}
+
+// InsertColumn stages the insertion of a column. See DtTable.InsertColumn.
+//
+// Parameters:
+//   - x: The index of the new column.
+//
+// Returns:
+//   - error: An error of type *errors.ErrInvalidParameter if x is not in
+//     [0, width], given the mutations staged before. Nothing is staged then.
+func (tx *TableTx) InsertColumn(x int) error {
+	if x < 0 || x > tx.width {
+		return gcers.NewErrInvalidParameter(fmt.Sprintf("x must be in [0, %d], got %d", tx.width, x))
+	}
+
+	tx.width++
+
+	tx.ops = append(tx.ops, func(dt *DtTable) {
+		_ = dt.insertColumn(x)
+	})
+
+	return nil
+}
+
+// DeleteColumn stages the deletion of a column. See DtTable.DeleteColumn.
+//
+// Parameters:
+//   - x: The index of the column.
+//
+// Returns:
+//   - error: An error of type *errors.ErrInvalidParameter if x is out of
+//     bounds, given the mutations staged before. Nothing is staged then.
+func (tx *TableTx) DeleteColumn(x int) error {
+	if x < 0 || x >= tx.width {
+		return gcers.NewErrInvalidParameter(fmt.Sprintf("x must be in [0, %d), got %d", tx.width, x))
+	}
+
+	tx.width--
+
+	tx.ops = append(tx.ops, func(dt *DtTable) {
+		_ = dt.deleteColumn(x)
+	})
+
+	return nil
+}
+
+// Abort discards all the staged mutations. Mutations staged afterwards are
+// still applied.
+func (tx *TableTx) Abort() {
+	tx.ops = nil
+}
+
+// Update stages mutations of the table and applies them atomically: readers
+// never see a frame where only some of them are applied. Once applied, the
+// change is signaled once.
+//
+// Parameters:
+//   - fn: The function that stages the mutations. It runs while the table is
+//     locked, so it must not call the methods of the table.
+func (dt *DtTable) Update(fn func(tx *TableTx)) {
+	if fn == nil {
+		return
+	}
+
+	dt.mu.Lock()
+
+	tx := &TableTx{
+		width:  dt.width,
+		height: dt.height,
+	}
+
+	fn(tx)
+
+	for _, op := range tx.ops {
+		op(dt)
+	}
+
+	dt.mu.Unlock()
+
+	if len(tx.ops) > 0 {
+		dt.SignalChange()
+	}
+}
+
+// SignalChange notifies the observers of the table of a change. Single cell
+// writes do not signal by themselves, so that a batch of them can be
+// signaled once.
+func (dt *DtTable) SignalChange() {
+	dt.changes.ModifyState(func(version uint64) uint64 {
+		return version + 1
+	})
+}
+
+// OnChange registers a function that is called whenever a change of the
+// table is signaled.
+//
+// Parameters:
+//   - fn: The function. It receives the number of changes signaled so far.
+//     If nil, nothing is registered.
+func (dt *DtTable) OnChange(fn func(version uint64)) {
+	if fn == nil {
+		return
+	}
+
+	dt.changes.Attach(sbj.NewReactiveObserver(fn))
+}
@@ -0,0 +1,145 @@
+package dt_table
+
+import (
+	"fmt"
+
+	gcers "github.com/PlayerR9/go-errors"
+)
+
+// DtView is a live window over a region of a DtTable. Reads and writes pass
+// through to the table, clipped to the region, so that widgets can be given a
+// constrained drawing surface. It is safe for concurrent use.
+type DtView struct {
+	// table is the table the view is over.
+	table *DtTable
+
+	// x and y are the coordinates of the top-left corner of the region in
+	// the table.
+	x, y int
+
+	// width and height are the size of the region.
+	width, height int
+}
+
+// View returns a live window over a region of the table.
+//
+// Parameters:
+//   - x: The x-coordinate of the top-left corner of the region.
+//   - y: The y-coordinate of the top-left corner of the region.
+//   - w: The width of the region. Negative values are treated as 0.
+//   - h: The height of the region. Negative values are treated as 0.
+//
+// Returns:
+//   - *DtView: The view. Never returns nil.
+//
+// The region may extend past the table; the cells there read as nil and
+// cannot be set.
+func (dt *DtTable) View(x, y, w, h int) *DtView {
+	return &DtView{
+		table:  dt,
+		x:      x,
+		y:      y,
+		width:  max(w, 0),
+		height: max(h, 0),
+	}
+}
+
+// GetCellAt returns the cell at the given coordinates of the view.
+// If the coordinates are out of bounds, it returns nil.
+//
+// Parameters:
+//   - x: The x-coordinate.
+//   - y: The y-coordinate.
+//
+// Returns:
+//   - *DtCell: The cell at the given coordinates.
+func (v *DtView) GetCellAt(x, y int) *DtCell {
+	if y < 0 || y >= v.height {
+		return nil
+	} else if x < 0 || x >= v.width {
+		return nil
+	}
+
+	return v.table.GetCellAt(v.x+x, v.y+y)
+}
+
+// GetWidth returns the width of the view.
+//
+// Returns:
+//   - int: The width of the view.
+func (v *DtView) GetWidth() int {
+	return v.width
+}
+
+// GetHeight returns the height of the view.
+//
+// Returns:
+//   - int: The height of the view.
+func (v *DtView) GetHeight() int {
+	return v.height
+}
+
+// SetCellAt sets the cell at the given coordinates of the view.
+//
+// Parameters:
+//   - x: The x-coordinate.
+//   - y: The y-coordinate.
+//   - cell: The cell to set.
+//
+// Returns:
+//   - error: An error of type *errors.ErrInvalidParameter if x and y are out
+//     of the bounds of the view or of the table.
+func (v *DtView) SetCellAt(x, y int, cell *DtCell) error {
+	if y < 0 || y >= v.height {
+		return gcers.NewErrInvalidParameter(fmt.Sprintf("y must be in [0, %d), got %d", v.height, y))
+	} else if x < 0 || x >= v.width {
+		return gcers.NewErrInvalidParameter(fmt.Sprintf("x must be in [0, %d), got %d", v.width, x))
+	}
+
+	return v.table.SetCellAt(v.x+x, v.y+y, cell)
+}
+
+// UpdateCellAt changes the cell at the given coordinates of the view in
+// place. See DtTable.UpdateCellAt.
+//
+// Parameters:
+//   - x: The x-coordinate.
+//   - y: The y-coordinate.
+//   - fn: The function that changes the cell.
+//
+// Returns:
+//   - error: An error of type *errors.ErrInvalidParameter if x and y are out
+//     of the bounds of the view or of the table.
+func (v *DtView) UpdateCellAt(x, y int, fn func(cell *DtCell)) error {
+	if y < 0 || y >= v.height {
+		return gcers.NewErrInvalidParameter(fmt.Sprintf("y must be in [0, %d), got %d", v.height, y))
+	} else if x < 0 || x >= v.width {
+		return gcers.NewErrInvalidParameter(fmt.Sprintf("x must be in [0, %d), got %d", v.width, x))
+	}
+
+	return v.table.UpdateCellAt(v.x+x, v.y+y, fn)
+}
+
+// View returns a live window over a region of the view. The region is
+// clipped to the view.
+//
+// Parameters:
+//   - x: The x-coordinate of the top-left corner of the region.
+//   - y: The y-coordinate of the top-left corner of the region.
+//   - w: The width of the region. Negative values are treated as 0.
+//   - h: The height of the region. Negative values are treated as 0.
+//
+// Returns:
+//   - *DtView: The view. Never returns nil.
+func (v *DtView) View(x, y, w, h int) *DtView {
+	left, top := max(x, 0), max(y, 0)
+	right, bottom := min(x+max(w, 0), v.width), min(y+max(h, 0), v.height)
+
+	return &DtView{
+		table:  v.table,
+		x:      v.x + left,
+		y:      v.y + top,
+		width:  max(right-left, 0),
+		height: max(bottom-top, 0),
+	}
+}
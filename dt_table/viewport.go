@@ -0,0 +1,255 @@
+package dt_table
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/gdamore/tcell"
+)
+
+const (
+	// ScrollTrack is the rune of the track of the scrollbars.
+	ScrollTrack rune = '░'
+
+	// ScrollThumb is the rune of the thumb of the scrollbars.
+	ScrollThumb rune = '█'
+)
+
+// Viewport shows a window of a DtTable that may be larger than the screen,
+// with scroll offsets that are always clamped to the content. It is safe for
+// concurrent use.
+type Viewport struct {
+	// content is the table that is scrolled.
+	content *DtTable
+
+	// width and height are the size of the viewport, scrollbars included.
+	width, height int
+
+	// x and y are the scroll offsets.
+	x, y int
+
+	// vertical and horizontal tell whether the scrollbars are drawn.
+	vertical, horizontal bool
+
+	// barStyle is the style of the scrollbars.
+	barStyle tcell.Style
+
+	// mu protects the fields above except content.
+	mu sync.Mutex
+}
+
+// NewViewport creates a new Viewport without scrollbars, scrolled to the
+// top-left corner.
+//
+// Parameters:
+//   - content: The table to scroll. If nil, an empty table is used.
+//   - width: The width of the viewport. Negative values are treated as 0.
+//   - height: The height of the viewport. Negative values are treated as 0.
+//
+// Returns:
+//   - *Viewport: The new Viewport. Never returns nil.
+func NewViewport(content *DtTable, width, height int) *Viewport {
+	if content == nil {
+		var err error
+
+		content, err = NewDtTable(0, 0)
+		if err != nil {
+			panic(fmt.Errorf("error creating table: %w", err))
+		}
+	}
+
+	return &Viewport{
+		content: content,
+		width:   max(width, 0),
+		height:  max(height, 0),
+	}
+}
+
+// SetScrollbars sets which scrollbars are drawn. Each scrollbar takes one
+// column or row of the viewport, whether the content overflows or not.
+//
+// Parameters:
+//   - vertical: Whether the vertical scrollbar is drawn on the right.
+//   - horizontal: Whether the horizontal scrollbar is drawn at the bottom.
+//   - style: The style of the scrollbars.
+func (v *Viewport) SetScrollbars(vertical, horizontal bool, style tcell.Style) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	v.vertical = vertical
+	v.horizontal = horizontal
+	v.barStyle = style
+
+	v.clamp()
+}
+
+// Resize sets the size of the viewport. The offsets are clamped to the new
+// size.
+//
+// Parameters:
+//   - width: The width of the viewport. Negative values are treated as 0.
+//   - height: The height of the viewport. Negative values are treated as 0.
+func (v *Viewport) Resize(width, height int) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	v.width = max(width, 0)
+	v.height = max(height, 0)
+
+	v.clamp()
+}
+
+// ScrollTo scrolls the viewport so that the given cell of the content is at
+// its top-left corner, as far as the content allows.
+//
+// Parameters:
+//   - x: The x-coordinate in the content.
+//   - y: The y-coordinate in the content.
+func (v *Viewport) ScrollTo(x, y int) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	v.x, v.y = x, y
+
+	v.clamp()
+}
+
+// ScrollBy scrolls the viewport by the given amounts, as far as the content
+// allows.
+//
+// Parameters:
+//   - dx: The number of columns to scroll. Negative values scroll left.
+//   - dy: The number of rows to scroll. Negative values scroll up.
+func (v *Viewport) ScrollBy(dx, dy int) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	v.x += dx
+	v.y += dy
+
+	v.clamp()
+}
+
+// Offset returns the scroll offsets of the viewport.
+//
+// Returns:
+//   - int: The x-coordinate of the content at the left of the viewport.
+//   - int: The y-coordinate of the content at the top of the viewport.
+func (v *Viewport) Offset() (int, int) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	v.clamp()
+
+	return v.x, v.y
+}
+
+// Draw returns the table the viewport shows, scrollbars included.
+//
+// Returns:
+//   - *DtTable: A new table of the size of the viewport.
+func (v *Viewport) Draw() *DtTable {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	v.clamp()
+
+	out, err := NewDtTable(v.height, v.width)
+	if err != nil {
+		panic(fmt.Errorf("error creating table: %w", err))
+	}
+
+	innerW, innerH := v.inner()
+
+	src := v.content.snapshot()
+
+	for y := 0; y < innerH && v.y+y < src.height; y++ {
+		for x := 0; x < innerW && v.x+x < src.width; x++ {
+			out.cells[y*v.width+x] = src.cells[(v.y+y)*src.width+v.x+x]
+		}
+	}
+
+	if v.vertical && innerW < v.width {
+		start, size := thumb(v.y, innerH, src.height)
+
+		for y := 0; y < innerH; y++ {
+			out.cells[y*v.width+innerW] = v.barCell(y >= start && y < start+size)
+		}
+	}
+
+	if v.horizontal && innerH < v.height {
+		start, size := thumb(v.x, innerW, src.width)
+
+		for x := 0; x < innerW; x++ {
+			out.cells[innerH*v.width+x] = v.barCell(x >= start && x < start+size)
+		}
+	}
+
+	return out
+}
+
+// inner is a private method of Viewport that returns the size of the area
+// that shows the content. The lock must be held.
+//
+// Returns:
+//   - int: The width of the area.
+//   - int: The height of the area.
+func (v *Viewport) inner() (int, int) {
+	width, height := v.width, v.height
+
+	if v.vertical && width > 0 {
+		width--
+	}
+
+	if v.horizontal && height > 0 {
+		height--
+	}
+
+	return width, height
+}
+
+// clamp is a private method of Viewport that keeps the offsets within the
+// content. The lock must be held.
+func (v *Viewport) clamp() {
+	width, height := v.inner()
+
+	v.x = max(min(v.x, v.content.GetWidth()-width), 0)
+	v.y = max(min(v.y, v.content.GetHeight()-height), 0)
+}
+
+// barCell is a private method of Viewport that returns a cell of a
+// scrollbar. The lock must be held.
+//
+// Parameters:
+//   - isThumb: Whether the cell is part of the thumb.
+//
+// Returns:
+//   - DtCell: The cell.
+func (v *Viewport) barCell(isThumb bool) DtCell {
+	if isThumb {
+		return DtCell{Content: ScrollThumb, Style: v.barStyle}
+	}
+
+	return DtCell{Content: ScrollTrack, Style: v.barStyle}
+}
+
+// thumb computes the position and size of the thumb of a scrollbar.
+//
+// Parameters:
+//   - offset: The scroll offset.
+//   - visible: The length of the visible part of the content, which is also
+//     the length of the scrollbar.
+//   - total: The length of the content.
+//
+// Returns:
+//   - int: The position of the thumb.
+//   - int: The size of the thumb.
+func thumb(offset, visible, total int) (int, int) {
+	if total <= visible {
+		return 0, visible
+	}
+
+	size := max(visible*visible/total, 1)
+
+	return offset * (visible - size) / (total - visible), size
+}
@@ -0,0 +1,222 @@
+package dt_table
+
+import (
+	"fmt"
+	"sync"
+
+	gcers "github.com/PlayerR9/go-errors"
+)
+
+// Widget is a component that draws itself into a region of a table.
+type Widget interface {
+	// MinSize returns the smallest size the widget can be drawn in.
+	//
+	// Returns:
+	//   - int: The minimum width.
+	//   - int: The minimum height.
+	MinSize() (int, int)
+
+	// Draw draws the widget.
+	//
+	// Parameters:
+	//   - view: The region to draw into. Never nil. It may be smaller than
+	//     the minimum size when there is not enough room.
+	Draw(view *DtView)
+}
+
+// DrawWidget draws a widget over the whole table.
+//
+// Parameters:
+//   - w: The widget. If nil, nothing is drawn.
+func (dt *DtTable) DrawWidget(w Widget) {
+	if w == nil {
+		return
+	}
+
+	w.Draw(dt.View(0, 0, dt.GetWidth(), dt.GetHeight()))
+}
+
+// Orientation is the direction along which a Split places its children.
+type Orientation int
+
+const (
+	// Horizontal places the children side by side, from left to right.
+	Horizontal Orientation = iota
+
+	// Vertical places the children one above the other, from top to bottom.
+	Vertical
+)
+
+// String implements the fmt.Stringer interface.
+func (o Orientation) String() string {
+	switch o {
+	case Horizontal:
+		return "horizontal"
+	case Vertical:
+		return "vertical"
+	default:
+		return "unknown"
+	}
+}
+
+// splitChild is a child of a Split.
+type splitChild struct {
+	// widget is the child.
+	widget Widget
+
+	// weight is the share of the extra room the child gets.
+	weight int
+}
+
+// Split is a Widget that lays out its children along one direction. Every
+// child gets its minimum size, and the room left is shared between them in
+// proportion to their weights. It is safe for concurrent use.
+type Split struct {
+	// orientation is the direction along which the children are placed.
+	orientation Orientation
+
+	// children are the children, in order.
+	children []splitChild
+
+	// mu protects children.
+	mu sync.RWMutex
+}
+
+// NewSplit creates a new Split without children.
+//
+// Parameters:
+//   - orientation: The direction along which the children are placed.
+//
+// Returns:
+//   - *Split: The new Split. Never returns nil.
+func NewSplit(orientation Orientation) *Split {
+	return &Split{
+		orientation: orientation,
+	}
+}
+
+// Add adds a child after the others.
+//
+// Parameters:
+//   - w: The child.
+//   - weight: The share of the room left the child gets. 0 to keep the
+//     child at its minimum size.
+//
+// Returns:
+//   - error: An error of type *errors.ErrNilParameter if w is nil, or of
+//     type *errors.ErrInvalidParameter if weight is less than 0.
+func (s *Split) Add(w Widget, weight int) error {
+	if w == nil {
+		return gcers.NewErrNilParameter("w")
+	} else if weight < 0 {
+		return gcers.NewErrInvalidParameter(fmt.Sprintf("weight must be non-negative, got %d", weight))
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.children = append(s.children, splitChild{
+		widget: w,
+		weight: weight,
+	})
+
+	return nil
+}
+
+// MinSize implements the Widget interface.
+//
+// Along the orientation, the minimum size is the sum of those of the
+// children; across it, the largest of them.
+func (s *Split) MinSize() (int, int) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var along, across int
+
+	for _, c := range s.children {
+		w, h := c.widget.MinSize()
+
+		if s.orientation == Vertical {
+			w, h = h, w
+		}
+
+		along += w
+		across = max(across, h)
+	}
+
+	if s.orientation == Vertical {
+		return across, along
+	}
+
+	return along, across
+}
+
+// Draw implements the Widget interface.
+//
+// When there is not enough room for the minimum sizes, the children are
+// given theirs in order until the room runs out.
+func (s *Split) Draw(view *DtView) {
+	s.mu.RLock()
+
+	children := make([]splitChild, len(s.children))
+	copy(children, s.children)
+
+	s.mu.RUnlock()
+
+	if len(children) == 0 {
+		return
+	}
+
+	room := view.GetWidth()
+	if s.orientation == Vertical {
+		room = view.GetHeight()
+	}
+
+	sizes := make([]int, len(children))
+
+	var total, weights int
+
+	for i, c := range children {
+		w, h := c.widget.MinSize()
+
+		if s.orientation == Vertical {
+			w = h
+		}
+
+		sizes[i] = min(max(w, 0), room-total)
+		total += sizes[i]
+		weights += c.weight
+	}
+
+	if extra := room - total; extra > 0 && weights > 0 {
+		given := 0
+		last := -1
+
+		for i, c := range children {
+			if c.weight == 0 {
+				continue
+			}
+
+			share := extra * c.weight / weights
+
+			sizes[i] += share
+			given += share
+			last = i
+		}
+
+		// The rounding remainder goes to the last weighted child.
+		sizes[last] += extra - given
+	}
+
+	offset := 0
+
+	for i, c := range children {
+		if s.orientation == Vertical {
+			c.widget.Draw(view.View(0, offset, view.GetWidth(), sizes[i]))
+		} else {
+			c.widget.Draw(view.View(offset, 0, sizes[i], view.GetHeight()))
+		}
+
+		offset += sizes[i]
+	}
+}
@@ -0,0 +1,64 @@
+package keyboard
+
+import (
+	"errors"
+	"time"
+
+	"github.com/eiannone/keyboard"
+)
+
+var (
+	// BackendClosed is the error that is returned by a Backend that is read
+	// after it was closed.
+	BackendClosed error
+)
+
+func init() {
+	BackendClosed = errors.New("backend is closed")
+}
+
+// Backend is the source of the key events of a Keyboard.
+type Backend interface {
+	// Open prepares the backend for reading.
+	//
+	// Returns:
+	//   - error: An error if the backend could not be opened.
+	Open() error
+
+	// Close releases the backend. It must unblock a pending ReadKey.
+	//
+	// Returns:
+	//   - error: An error if the backend could not be closed.
+	Close() error
+
+	// ReadKey blocks until the next key is pressed.
+	//
+	// Returns:
+	//   - KeyEvent: The key event.
+	//   - error: An error if the key could not be read.
+	ReadKey() (KeyEvent, error)
+}
+
+// terminalBackend is the Backend that reads the terminal with the
+// eiannone/keyboard package.
+type terminalBackend struct{}
+
+// Open implements the Backend interface.
+func (terminalBackend) Open() error {
+	return keyboard.Open()
+}
+
+// Close implements the Backend interface.
+func (terminalBackend) Close() error {
+	return keyboard.Close()
+}
+
+// ReadKey implements the Backend interface.
+func (terminalBackend) ReadKey() (KeyEvent, error) {
+	ch, key, err := keyboard.GetKey()
+	if err != nil {
+		return KeyEvent{}, err
+	}
+
+	return newKeyEvent(ch, key, time.Now()), nil
+}
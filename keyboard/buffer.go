@@ -0,0 +1,164 @@
+package keyboard
+
+import (
+	"sync"
+)
+
+// Policy is what a bounded buffer does with an event when it is full.
+type Policy int
+
+const (
+	// DropOldest discards the oldest queued event to make room for the new
+	// one.
+	DropOldest Policy = iota
+
+	// Block waits until a receiver makes room. The backend is not read in the
+	// meantime.
+	Block
+)
+
+// String implements the fmt.Stringer interface.
+func (p Policy) String() string {
+	switch p {
+	case DropOldest:
+		return "drop oldest"
+	case Block:
+		return "block"
+	default:
+		return "unknown"
+	}
+}
+
+// buffer is a FIFO queue that is safe for concurrent use. It implements the
+// runner.Receiver interface.
+type buffer[T any] struct {
+	// items are the queued items.
+	items []T
+
+	// capacity is the maximum number of queued items. Non-positive values
+	// mean no limit.
+	capacity int
+
+	// policy is applied when the buffer is full.
+	policy Policy
+
+	// closed is true once the buffer is closed.
+	closed bool
+
+	// mu protects items and closed.
+	mu sync.Mutex
+
+	// notEmpty is signaled whenever an item is queued or the buffer is closed.
+	notEmpty *sync.Cond
+
+	// notFull is signaled whenever an item is received or the buffer is
+	// closed.
+	notFull *sync.Cond
+}
+
+// newBuffer creates a new unbounded buffer.
+//
+// Returns:
+//   - *buffer[T]: The new buffer. Never returns nil.
+func newBuffer[T any]() *buffer[T] {
+	return newBoundedBuffer[T](0, DropOldest)
+}
+
+// newBoundedBuffer creates a new buffer that holds at most capacity items.
+//
+// Parameters:
+//   - capacity: The maximum number of queued items. Non-positive values mean
+//     no limit.
+//   - policy: The policy applied when the buffer is full.
+//
+// Returns:
+//   - *buffer[T]: The new buffer. Never returns nil.
+func newBoundedBuffer[T any](capacity int, policy Policy) *buffer[T] {
+	b := &buffer[T]{
+		capacity: capacity,
+		policy:   policy,
+	}
+
+	b.notEmpty = sync.NewCond(&b.mu)
+	b.notFull = sync.NewCond(&b.mu)
+
+	return b
+}
+
+// Send implements the runner.Sender interface.
+//
+// It queues an item and blocks only if the buffer is full and its policy is
+// Block. It returns false if the buffer is closed.
+func (b *buffer[T]) Send(item T) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for b.policy == Block && b.full() && !b.closed {
+		b.notFull.Wait()
+	}
+
+	if b.closed {
+		return false
+	}
+
+	if b.full() {
+		var zero T
+		b.items[0] = zero
+
+		b.items = b.items[1:]
+	}
+
+	b.items = append(b.items, item)
+
+	b.notEmpty.Signal()
+
+	return true
+}
+
+// Receive implements the runner.Receiver interface.
+//
+// Items queued before the buffer was closed are still delivered.
+func (b *buffer[T]) Receive() (T, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for len(b.items) == 0 && !b.closed {
+		b.notEmpty.Wait()
+	}
+
+	if len(b.items) == 0 {
+		return *new(T), false
+	}
+
+	item := b.items[0]
+
+	var zero T
+	b.items[0] = zero
+
+	b.items = b.items[1:]
+
+	b.notFull.Signal()
+
+	return item, true
+}
+
+// Close is a method of buffer that closes the buffer and wakes up
+// all the waiting senders and receivers.
+func (b *buffer[T]) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.closed = true
+
+	b.notEmpty.Broadcast()
+	b.notFull.Broadcast()
+}
+
+// full is a private method of buffer that checks whether the buffer is full.
+// The lock must be held.
+//
+// Returns:
+//   - bool: True if the buffer is full, false otherwise.
+func (b *buffer[T]) full() bool {
+	return b.capacity > 0 && len(b.items) >= b.capacity
+}
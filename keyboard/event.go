@@ -0,0 +1,252 @@
+package keyboard
+
+import (
+	"strings"
+	"time"
+
+	"github.com/eiannone/keyboard"
+)
+
+// Key is the code of a special key. Character input uses KeyRune.
+type Key int
+
+const (
+	// KeyRune is the code of the events that carry a character.
+	KeyRune Key = iota
+
+	// The special keys.
+
+	KeyEnter
+	KeyTab
+	KeyBackspace
+	KeyEsc
+	KeyInsert
+	KeyDelete
+	KeyHome
+	KeyEnd
+	KeyPgUp
+	KeyPgDn
+	KeyUp
+	KeyDown
+	KeyLeft
+	KeyRight
+	KeyF1
+	KeyF2
+	KeyF3
+	KeyF4
+	KeyF5
+	KeyF6
+	KeyF7
+	KeyF8
+	KeyF9
+	KeyF10
+	KeyF11
+	KeyF12
+)
+
+// keyNames are the names of the special keys.
+var keyNames = map[Key]string{
+	KeyEnter:     "Enter",
+	KeyTab:       "Tab",
+	KeyBackspace: "Backspace",
+	KeyEsc:       "Esc",
+	KeyInsert:    "Insert",
+	KeyDelete:    "Delete",
+	KeyHome:      "Home",
+	KeyEnd:       "End",
+	KeyPgUp:      "PgUp",
+	KeyPgDn:      "PgDn",
+	KeyUp:        "Up",
+	KeyDown:      "Down",
+	KeyLeft:      "Left",
+	KeyRight:     "Right",
+	KeyF1:        "F1",
+	KeyF2:        "F2",
+	KeyF3:        "F3",
+	KeyF4:        "F4",
+	KeyF5:        "F5",
+	KeyF6:        "F6",
+	KeyF7:        "F7",
+	KeyF8:        "F8",
+	KeyF9:        "F9",
+	KeyF10:       "F10",
+	KeyF11:       "F11",
+	KeyF12:       "F12",
+}
+
+// String implements the fmt.Stringer interface.
+func (k Key) String() string {
+	if k == KeyRune {
+		return "Rune"
+	}
+
+	name, ok := keyNames[k]
+	if !ok {
+		return "Unknown"
+	}
+
+	return name
+}
+
+// Modifier is a set of modifier keys held during a key press.
+type Modifier int
+
+const (
+	// ModShift is set when Shift is held. Terminals usually report it by
+	// sending the upper case character instead.
+	ModShift Modifier = 1 << iota
+
+	// ModCtrl is set when Ctrl is held.
+	ModCtrl
+
+	// ModAlt is set when Alt is held.
+	ModAlt
+)
+
+// ModNone is the empty set of modifiers.
+const ModNone Modifier = 0
+
+// Has checks whether all the given modifiers are set.
+//
+// Parameters:
+//   - mod: The modifiers to check.
+//
+// Returns:
+//   - bool: True if all of them are set, false otherwise.
+func (m Modifier) Has(mod Modifier) bool {
+	return m&mod == mod
+}
+
+// String implements the fmt.Stringer interface.
+//
+// Modifiers are joined with '+' in the order Ctrl, Alt, Shift.
+func (m Modifier) String() string {
+	var parts []string
+
+	if m.Has(ModCtrl) {
+		parts = append(parts, "Ctrl")
+	}
+
+	if m.Has(ModAlt) {
+		parts = append(parts, "Alt")
+	}
+
+	if m.Has(ModShift) {
+		parts = append(parts, "Shift")
+	}
+
+	return strings.Join(parts, "+")
+}
+
+// KeyEvent is a key press.
+type KeyEvent struct {
+	// Rune is the character typed. Only meaningful if Key is KeyRune.
+	Rune rune
+
+	// Key is the code of the key pressed.
+	Key Key
+
+	// Mods are the modifiers held during the key press.
+	Mods Modifier
+
+	// Time is the time the key was pressed.
+	Time time.Time
+
+	// Repeat is true if the event is an auto-repeat of a held key. See
+	// Keyboard.SetRepeat.
+	Repeat bool
+}
+
+// String implements the fmt.Stringer interface.
+//
+// Format: "[Mods+]Key", such as "Ctrl+S", "q" or "Alt+Enter".
+func (e KeyEvent) String() string {
+	var name string
+
+	switch {
+	case e.Key != KeyRune:
+		name = e.Key.String()
+	case e.Rune == ' ':
+		name = "Space"
+	default:
+		name = string(e.Rune)
+	}
+
+	if e.Mods == ModNone {
+		return name
+	}
+
+	return e.Mods.String() + "+" + name
+}
+
+// specialKeys maps the special keys of eiannone/keyboard to their codes.
+var specialKeys = map[keyboard.Key]Key{
+	keyboard.KeyEnter:      KeyEnter,
+	keyboard.KeyTab:        KeyTab,
+	keyboard.KeyBackspace:  KeyBackspace,
+	keyboard.KeyBackspace2: KeyBackspace,
+	keyboard.KeyEsc:        KeyEsc,
+	keyboard.KeyInsert:     KeyInsert,
+	keyboard.KeyDelete:     KeyDelete,
+	keyboard.KeyHome:       KeyHome,
+	keyboard.KeyEnd:        KeyEnd,
+	keyboard.KeyPgup:       KeyPgUp,
+	keyboard.KeyPgdn:       KeyPgDn,
+	keyboard.KeyArrowUp:    KeyUp,
+	keyboard.KeyArrowDown:  KeyDown,
+	keyboard.KeyArrowLeft:  KeyLeft,
+	keyboard.KeyArrowRight: KeyRight,
+	keyboard.KeyF1:         KeyF1,
+	keyboard.KeyF2:         KeyF2,
+	keyboard.KeyF3:         KeyF3,
+	keyboard.KeyF4:         KeyF4,
+	keyboard.KeyF5:         KeyF5,
+	keyboard.KeyF6:         KeyF6,
+	keyboard.KeyF7:         KeyF7,
+	keyboard.KeyF8:         KeyF8,
+	keyboard.KeyF9:         KeyF9,
+	keyboard.KeyF10:        KeyF10,
+	keyboard.KeyF11:        KeyF11,
+	keyboard.KeyF12:        KeyF12,
+}
+
+// newKeyEvent converts the output of eiannone/keyboard into a KeyEvent.
+//
+// Parameters:
+//   - ch: The character read.
+//   - key: The key read.
+//   - at: The time the key was read.
+//
+// Returns:
+//   - KeyEvent: The converted event.
+func newKeyEvent(ch rune, key keyboard.Key, at time.Time) KeyEvent {
+	ev := KeyEvent{
+		Time: at,
+	}
+
+	switch {
+	case key == keyboard.KeyEsc && ch != 0:
+		// Escape followed by a character is how terminals send Alt combos.
+		ev.Rune = ch
+		ev.Mods = ModAlt
+	case key == 0 && ch != 0:
+		ev.Rune = ch
+	case key == keyboard.KeyCtrlSpace:
+		ev.Rune = ' '
+		ev.Mods = ModCtrl
+	case key == keyboard.KeySpace:
+		ev.Rune = ' '
+	default:
+		code, ok := specialKeys[key]
+		if ok {
+			ev.Key = code
+		} else if key >= keyboard.KeyCtrlA && key <= keyboard.KeyCtrlZ {
+			ev.Rune = 'a' + rune(key-keyboard.KeyCtrlA)
+			ev.Mods = ModCtrl
+		} else {
+			ev.Rune = rune(key)
+		}
+	}
+
+	return ev
+}
@@ -0,0 +1,81 @@
+package keyboard
+
+import (
+	"sync"
+)
+
+// fakeBackend is a Backend that never reports any event. Events reach a
+// Keyboard that uses it only through Inject.
+type fakeBackend struct {
+	// closed is closed once the backend is closed. Nil if it was never
+	// opened.
+	closed chan struct{}
+
+	// mu protects closed.
+	mu sync.Mutex
+}
+
+// Open implements the Backend interface.
+func (b *fakeBackend) Open() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.closed = make(chan struct{})
+
+	return nil
+}
+
+// Close implements the Backend interface.
+func (b *fakeBackend) Close() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed != nil {
+		select {
+		case <-b.closed:
+		default:
+			close(b.closed)
+		}
+	}
+
+	return nil
+}
+
+// ReadKey implements the Backend interface.
+//
+// It blocks until the backend is closed.
+func (b *fakeBackend) ReadKey() (KeyEvent, error) {
+	b.mu.Lock()
+	closed := b.closed
+	b.mu.Unlock()
+
+	if closed != nil {
+		<-closed
+	}
+
+	return KeyEvent{}, BackendClosed
+}
+
+// NewFakeKeyboard creates a Keyboard that does not depend on a terminal. It
+// never reads any input by itself; use Inject to feed it events.
+//
+// Returns:
+//   - *Keyboard: The new Keyboard.
+func NewFakeKeyboard() *Keyboard {
+	return NewKeyboardWithBackend(&fakeBackend{})
+}
+
+// Inject feeds key events to the Keyboard as if they were read from its
+// backend. The events are recorded and dispatched before Inject returns, so
+// the outcome is deterministic.
+//
+// Parameters:
+//   - events: The events to inject.
+//
+// Unlike the events of the backend, injected events are never treated as the
+// resends of a held key.
+func (k *Keyboard) Inject(events ...KeyEvent) {
+	for _, ev := range events {
+		k.dispatchKey(ev)
+	}
+}
@@ -0,0 +1,169 @@
+package keyboard
+
+import (
+	"sync"
+)
+
+// focusContext is a focus context of a FocusManager.
+type focusContext struct {
+	// name is the name of the context.
+	name string
+
+	// keymap holds the bindings of the context.
+	keymap *Keymap
+}
+
+// FocusManager routes the key events to the Keymap of the focused context
+// (dialog, list, editor, ...). Contexts are stacked: pushing a context
+// focuses it, and popping it gives the focus back to the previous one. It is
+// safe for concurrent use.
+type FocusManager struct {
+	// stack are the contexts, the focused one last.
+	stack []focusContext
+
+	// mu protects stack.
+	mu sync.RWMutex
+}
+
+// NewFocusManager creates a new FocusManager without any context.
+//
+// Returns:
+//   - *FocusManager: The new FocusManager. Never returns nil.
+func NewFocusManager() *FocusManager {
+	return &FocusManager{}
+}
+
+// Push is a method of FocusManager that focuses a new context.
+//
+// Parameters:
+//   - name: The name of the context.
+//   - km: The bindings of the context. If nil, the context handles no event.
+func (fm *FocusManager) Push(name string, km *Keymap) {
+	if fm == nil {
+		return
+	}
+
+	fm.mu.Lock()
+	defer fm.mu.Unlock()
+
+	fm.stack = append(fm.stack, focusContext{
+		name:   name,
+		keymap: km,
+	})
+}
+
+// Pop is a method of FocusManager that removes the focused context and gives
+// the focus back to the previous one.
+//
+// Returns:
+//   - string: The name of the removed context. Empty if there is none.
+//   - bool: True if a context was removed, false otherwise.
+func (fm *FocusManager) Pop() (string, bool) {
+	if fm == nil {
+		return "", false
+	}
+
+	fm.mu.Lock()
+	defer fm.mu.Unlock()
+
+	if len(fm.stack) == 0 {
+		return "", false
+	}
+
+	top := fm.stack[len(fm.stack)-1]
+
+	fm.stack[len(fm.stack)-1] = focusContext{}
+	fm.stack = fm.stack[:len(fm.stack)-1]
+
+	return top.name, true
+}
+
+// Remove is a method of FocusManager that removes the topmost context with
+// the given name, wherever it is in the stack. This is useful when a context
+// that is not focused goes away.
+//
+// Parameters:
+//   - name: The name of the context.
+//
+// Returns:
+//   - bool: True if a context was removed, false otherwise.
+func (fm *FocusManager) Remove(name string) bool {
+	if fm == nil {
+		return false
+	}
+
+	fm.mu.Lock()
+	defer fm.mu.Unlock()
+
+	for i := len(fm.stack) - 1; i >= 0; i-- {
+		if fm.stack[i].name != name {
+			continue
+		}
+
+		copy(fm.stack[i:], fm.stack[i+1:])
+
+		fm.stack[len(fm.stack)-1] = focusContext{}
+		fm.stack = fm.stack[:len(fm.stack)-1]
+
+		return true
+	}
+
+	return false
+}
+
+// Active is a method of FocusManager that returns the focused context.
+//
+// Returns:
+//   - string: The name of the focused context. Empty if there is none.
+//   - bool: True if a context is focused, false otherwise.
+func (fm *FocusManager) Active() (string, bool) {
+	if fm == nil {
+		return "", false
+	}
+
+	fm.mu.RLock()
+	defer fm.mu.RUnlock()
+
+	if len(fm.stack) == 0 {
+		return "", false
+	}
+
+	return fm.stack[len(fm.stack)-1].name, true
+}
+
+// Dispatch is a method of FocusManager that dispatches an event to the
+// Keymap of the focused context.
+//
+// Parameters:
+//   - ev: The event.
+//
+// Returns:
+//   - bool: True if the event was handled, false otherwise.
+func (fm *FocusManager) Dispatch(ev KeyEvent) bool {
+	if fm == nil {
+		return false
+	}
+
+	fm.mu.RLock()
+
+	var km *Keymap
+
+	if len(fm.stack) > 0 {
+		km = fm.stack[len(fm.stack)-1].keymap
+	}
+
+	fm.mu.RUnlock()
+
+	// The handler may push or pop contexts, so it is called without the lock.
+	return km.Dispatch(ev)
+}
+
+// SetFocusManager sets the FocusManager the events are dispatched to. Events
+// handled by the focused context are consumed; the others go on to the Keymap
+// set with SetKeymap, which thus holds the global shortcuts.
+//
+// Parameters:
+//   - fm: The FocusManager. If nil, no context is used.
+func (k *Keyboard) SetFocusManager(fm *FocusManager) {
+	k.focus.Store(fm)
+}
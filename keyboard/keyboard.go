@@ -0,0 +1,476 @@
+package keyboard
+
+import (
+	"context"
+	"errors"
+	_ "image/png"
+	"sync"
+	"sync/atomic"
+
+	"github.com/PlayerR9/safe/runner"
+)
+
+// Keyboard handles keyboard input. By default, the terminal is read with the
+// eiannone/keyboard package.
+type Keyboard struct {
+	// backend is the source of the key events.
+	backend Backend
+
+	// buffer is a safe buffer for the key events.
+	buffer *buffer[KeyEvent]
+
+	// mouse is a safe buffer for the mouse events.
+	mouse *buffer[MouseEvent]
+
+	// capacity is the capacity of the key and mouse buffers. Non-positive
+	// values mean no limit.
+	capacity int
+
+	// policy is applied when a key or mouse buffer is full.
+	policy Policy
+
+	// lines is a safe buffer for the lines assembled in ModeLine.
+	lines *buffer[string]
+
+	// mode is the input mode.
+	mode Mode
+
+	// line is the line being edited in ModeLine.
+	line []rune
+
+	// errChan is the error channel for the Keyboard.
+	errChan chan error
+
+	// ctx is the context for the Keyboard. Nil if it was never started.
+	ctx context.Context
+
+	// cancel is the cancel function for the Keyboard.
+	cancel context.CancelFunc
+
+	// done is closed once the Keyboard has shut down.
+	done chan struct{}
+
+	// closeErr is the error of the last shutdown. Only valid once done is closed.
+	closeErr error
+
+	// wg is the wait group for the Keyboard.
+	wg sync.WaitGroup
+
+	// subs are the receivers of the subscribers.
+	subs []*buffer[KeyEvent]
+
+	// chain are the interceptors the key events go through.
+	chain []Interceptor
+
+	// recording is true while the key events are recorded.
+	recording bool
+
+	// record are the key events recorded so far.
+	record []KeyEvent
+
+	// mu protects buffer, mouse, lines, mode, line, errChan, ctx, cancel,
+	// done, subs, chain, recording and record.
+	mu sync.Mutex
+
+	// keymap dispatches the events bound to an action. Nil if none was set.
+	keymap atomic.Pointer[Keymap]
+
+	// focus dispatches the events to the focused context. Nil if none was
+	// set.
+	focus atomic.Pointer[FocusManager]
+
+	// repeat emits the repeats of the held keys. Nil if auto-repeat is
+	// disabled.
+	repeat atomic.Pointer[repeater]
+
+	// recovery is the recovery configuration. Nil if the errors are only
+	// reported.
+	recovery atomic.Pointer[Recovery]
+}
+
+// NewKeyboard creates a new Keyboard.
+//
+// Returns:
+//   - *Keyboard: The new Keyboard.
+func NewKeyboard() *Keyboard {
+	return NewKeyboardWithBackend(terminalBackend{})
+}
+
+// NewKeyboardWithBackend creates a new Keyboard that reads its events from
+// the given backend.
+//
+// Parameters:
+//   - backend: The source of the key events. If nil, the terminal is read.
+//
+// Returns:
+//   - *Keyboard: The new Keyboard.
+func NewKeyboardWithBackend(backend Backend) *Keyboard {
+	return NewBoundedKeyboard(backend, 0, DropOldest)
+}
+
+// NewBoundedKeyboard creates a new Keyboard whose key and mouse receivers,
+// including those of the subscribers, hold a bounded number of events, so
+// that slow consumers cannot cause unbounded memory growth.
+//
+// Parameters:
+//   - backend: The source of the key events. If nil, the terminal is read.
+//   - capacity: The maximum number of events a receiver holds. Non-positive
+//     values mean no limit.
+//   - policy: The policy applied when a receiver is full.
+//
+// Returns:
+//   - *Keyboard: The new Keyboard.
+//
+// With Block, a receiver that is not drained stalls the whole Keyboard,
+// including Inject and Playback.
+func NewBoundedKeyboard(backend Backend, capacity int, policy Policy) *Keyboard {
+	if backend == nil {
+		backend = terminalBackend{}
+	}
+
+	k := &Keyboard{
+		backend:  backend,
+		capacity: capacity,
+		policy:   policy,
+	}
+
+	k.buffer = newBoundedBuffer[KeyEvent](capacity, policy)
+	k.mouse = newBoundedBuffer[MouseEvent](capacity, policy)
+	k.lines = newBuffer[string]()
+
+	return k
+}
+
+// GetErrorChannel returns the error channel for the Keyboard.
+//
+// Returns:
+//   - <-chan error: The error channel. Nil if the Keyboard was never started.
+func (k *Keyboard) GetErrorChannel() <-chan error {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	return k.errChan
+}
+
+// GetKeyReceiver returns the receiver of the key events of the Keyboard.
+//
+// Returns:
+//   - runner.Receiver[KeyEvent]: The key receiver.
+//
+// Every start after a shutdown uses a new receiver.
+func (k *Keyboard) GetKeyReceiver() runner.Receiver[KeyEvent] {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	return k.buffer
+}
+
+// GetMouseReceiver returns the receiver of the mouse events of the Keyboard.
+//
+// Returns:
+//   - runner.Receiver[MouseEvent]: The mouse receiver.
+//
+// Only backends that implement MouseBackend report mouse events. Every start
+// after a shutdown uses a new receiver.
+func (k *Keyboard) GetMouseReceiver() runner.Receiver[MouseEvent] {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	return k.mouse
+}
+
+// SetKeymap sets the Keymap the events are dispatched to. Events bound to an
+// action that has a handler are consumed by the handler; the others are
+// forwarded to the key receiver.
+//
+// Parameters:
+//   - km: The Keymap. If nil, all the events are forwarded.
+func (k *Keyboard) SetKeymap(km *Keymap) {
+	k.keymap.Store(km)
+}
+
+// Close closes the Keyboard and waits for it to shut down. Nothing is done if
+// it is not running.
+//
+// Returns:
+//   - error: An error if the backend could not be closed.
+func (k *Keyboard) Close() error {
+	k.mu.Lock()
+
+	if k.ctx == nil {
+		k.mu.Unlock()
+		return nil
+	}
+
+	cancel, done := k.cancel, k.done
+
+	k.mu.Unlock()
+
+	cancel()
+
+	<-done
+
+	return k.closeErr
+}
+
+// Start starts the Keyboard. Nothing is done if it is already running.
+//
+// Returns:
+//   - error: An error if the Keyboard could not be started.
+func (k *Keyboard) Start() error {
+	return k.StartCtx(context.Background())
+}
+
+// StartCtx is like Start but the Keyboard shuts down on its own once the
+// context is done, as if Close was called.
+//
+// Parameters:
+//   - ctx: The context that bounds the life of the Keyboard.
+//
+// Returns:
+//   - error: An error if the Keyboard could not be started.
+func (k *Keyboard) StartCtx(ctx context.Context) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	if k.ctx != nil {
+		select {
+		case <-k.done:
+		default:
+			// Do nothing as the Keyboard is already started.
+			return nil
+		}
+
+		// The previous receivers were closed by the shutdown.
+		k.buffer = newBoundedBuffer[KeyEvent](k.capacity, k.policy)
+		k.mouse = newBoundedBuffer[MouseEvent](k.capacity, k.policy)
+		k.lines = newBuffer[string]()
+		k.line = nil
+	}
+
+	err := k.backend.Open()
+	if err != nil {
+		return err
+	}
+
+	k.ctx, k.cancel = context.WithCancel(ctx)
+	k.errChan = make(chan error)
+	k.done = make(chan struct{})
+
+	k.wg.Add(1)
+
+	go k.keyListener(k.ctx, k.errChan)
+
+	go k.shutdown(k.ctx, k.buffer, k.mouse, k.lines, k.errChan, k.done)
+
+	return nil
+}
+
+// Healthy checks whether the Keyboard is listening for input.
+//
+// Returns:
+//   - error: Nil if the Keyboard is listening, the reason otherwise.
+func (k *Keyboard) Healthy() error {
+	k.mu.Lock()
+	ctx := k.ctx
+	k.mu.Unlock()
+
+	if ctx == nil {
+		return errors.New("keyboard is not started")
+	}
+
+	select {
+	case <-ctx.Done():
+		return errors.New("keyboard is closed")
+	default:
+		return nil
+	}
+}
+
+// Wait waits for the Keyboard to finish.
+// It may cause a deadlock if the Keyboard is not closed.
+func (k *Keyboard) Wait() {
+	k.wg.Wait()
+}
+
+// shutdown is a private method of Keyboard that, once the context is done,
+// releases the backend, waits for the listener and closes the channels.
+//
+// Parameters:
+//   - ctx: The context of the run.
+//   - buf: The key buffer of the run.
+//   - mouse: The mouse buffer of the run.
+//   - lines: The line buffer of the run.
+//   - errChan: The error channel of the run.
+//   - done: The channel to close once the shutdown is complete.
+func (k *Keyboard) shutdown(ctx context.Context, buf *buffer[KeyEvent], mouse *buffer[MouseEvent], lines *buffer[string], errChan chan error, done chan struct{}) {
+	<-ctx.Done()
+
+	// Closing the backend unblocks the pending read of the listener.
+	k.closeErr = k.backend.Close()
+
+	// Closing the buffers unblocks the pending send of the listener, if any.
+	buf.Close()
+	mouse.Close()
+	lines.Close()
+
+	k.closeSubscribers()
+
+	k.wg.Wait()
+
+	k.repeat.Load().stop()
+
+	close(errChan)
+
+	close(done)
+}
+
+// keyListener is an helper function that listens for keyboard input.
+//
+// Parameters:
+//   - ctx: The context of the run.
+//   - errChan: The channel the errors are sent to.
+func (k *Keyboard) keyListener(ctx context.Context, errChan chan<- error) {
+	defer k.wg.Done()
+
+	var rec recoverer
+
+	for ctx.Err() == nil {
+		ev, err := k.read()
+		if err == nil {
+			rec.failures = 0
+
+			k.dispatch(ev)
+			continue
+		}
+
+		if ctx.Err() != nil {
+			// The error is caused by the shutdown.
+			return
+		}
+
+		cfg := k.recovery.Load()
+		if cfg != nil {
+			err = k.reopen(ctx, cfg, &rec, err)
+			if err == nil {
+				continue
+			}
+		}
+
+		select {
+		case errChan <- err:
+		case <-ctx.Done():
+			return
+		}
+
+		if cfg != nil {
+			// The Keyboard gave up.
+			k.mu.Lock()
+			cancel := k.cancel
+			k.mu.Unlock()
+
+			cancel()
+
+			return
+		}
+	}
+}
+
+// read is a private method of Keyboard that reads the next event of the
+// backend.
+//
+// Returns:
+//   - Event: The event read.
+//   - error: An error if the event could not be read.
+func (k *Keyboard) read() (Event, error) {
+	mb, ok := k.backend.(MouseBackend)
+	if ok {
+		return mb.ReadInput()
+	}
+
+	ev, err := k.backend.ReadKey()
+	if err != nil {
+		return nil, err
+	}
+
+	return ev, nil
+}
+
+// dispatch is a private method of Keyboard that sends a key event to the
+// Keymap or, if it is not handled there, to the key receiver. Mouse events
+// are sent to the mouse receiver.
+//
+// Parameters:
+//   - ev: The event.
+func (k *Keyboard) dispatch(ev Event) {
+	switch ev := ev.(type) {
+	case KeyEvent:
+		ev, ok := k.repeat.Load().press(ev)
+		if ok {
+			k.dispatchKey(ev)
+		}
+	case MouseEvent:
+		k.mu.Lock()
+		mouse := k.mouse
+		k.mu.Unlock()
+
+		mouse.Send(ev)
+	}
+}
+
+// dispatchKey is a private method of Keyboard that records a key event, if a
+// recording is in progress, and delivers it.
+//
+// Parameters:
+//   - ev: The key event.
+func (k *Keyboard) dispatchKey(ev KeyEvent) {
+	k.mu.Lock()
+
+	if k.recording {
+		k.record = append(k.record, ev)
+	}
+
+	k.mu.Unlock()
+
+	k.deliver(ev)
+}
+
+// deliver is a private method of Keyboard that passes a key event through
+// the interceptors and sends it to the focused context and the Keymap or, if
+// it is not handled there, to the key receiver and the subscribers or the
+// line being edited, depending on the mode.
+//
+// Parameters:
+//   - ev: The key event.
+func (k *Keyboard) deliver(ev KeyEvent) {
+	ev, ok := k.intercept(ev)
+	if !ok {
+		return
+	}
+
+	if k.focus.Load().Dispatch(ev) || k.keymap.Load().Dispatch(ev) {
+		return
+	}
+
+	k.mu.Lock()
+
+	if k.mode == ModeLine {
+		k.edit(ev)
+		k.mu.Unlock()
+
+		return
+	}
+
+	buf, subs := k.buffer, k.subs
+	k.mu.Unlock()
+
+	buf.Send(ev)
+
+	for _, sub := range subs {
+		sub.Send(ev)
+	}
+}
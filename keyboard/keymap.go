@@ -0,0 +1,281 @@
+package keyboard
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+)
+
+// chord is the normalized form of a key combination, used to match events
+// against bindings.
+type chord struct {
+	// key is the code of the key.
+	key Key
+
+	// r is the character. Only meaningful if key is KeyRune.
+	r rune
+
+	// mods are the modifiers.
+	mods Modifier
+}
+
+// chordOf returns the normalized chord of an event.
+//
+// Parameters:
+//   - ev: The event.
+//
+// Returns:
+//   - chord: The normalized chord.
+//
+// Shift on a letter is folded into its upper case, and Ctrl on a letter uses
+// its lower case, which is how terminals report them.
+func chordOf(ev KeyEvent) chord {
+	c := chord{
+		key:  ev.Key,
+		mods: ev.Mods,
+	}
+
+	if ev.Key != KeyRune {
+		return c
+	}
+
+	c.r = ev.Rune
+
+	switch {
+	case c.mods.Has(ModCtrl):
+		c.r = unicode.ToLower(c.r)
+	case c.mods.Has(ModShift) && unicode.IsLetter(c.r):
+		c.r = unicode.ToUpper(c.r)
+		c.mods &^= ModShift
+	}
+
+	return c
+}
+
+// ParseKey parses a key combination such as "Ctrl+S", "q", "Alt+Enter" or
+// "Space".
+//
+// Parameters:
+//   - spec: The key combination. Modifiers (Ctrl, Alt, Shift) and key names
+//     are case-insensitive; single characters are case-sensitive.
+//
+// Returns:
+//   - KeyEvent: The event the combination describes. Its Time is zero.
+//   - error: An error if the combination is invalid.
+func ParseKey(spec string) (KeyEvent, error) {
+	var ev KeyEvent
+
+	if spec == "" {
+		return ev, fmt.Errorf("invalid key %q: empty", spec)
+	}
+
+	parts := strings.Split(spec, "+")
+
+	// Two trailing empty parts mean the key itself is '+', as in "Ctrl++".
+	last := parts[len(parts)-1]
+	mods := parts[:len(parts)-1]
+
+	if last == "" && len(parts) > 1 && parts[len(parts)-2] == "" {
+		last = "+"
+		mods = parts[:len(parts)-2]
+	} else if last == "" {
+		return KeyEvent{}, fmt.Errorf("invalid key %q: missing key after modifiers", spec)
+	}
+
+	for _, mod := range mods {
+		switch strings.ToLower(mod) {
+		case "ctrl", "control":
+			ev.Mods |= ModCtrl
+		case "alt", "meta":
+			ev.Mods |= ModAlt
+		case "shift":
+			ev.Mods |= ModShift
+		default:
+			return KeyEvent{}, fmt.Errorf("invalid key %q: unknown modifier %q", spec, mod)
+		}
+	}
+
+	if utf8.RuneCountInString(last) == 1 {
+		ev.Rune, _ = utf8.DecodeRuneInString(last)
+		return ev, nil
+	}
+
+	if strings.EqualFold(last, "space") {
+		ev.Rune = ' '
+		return ev, nil
+	}
+
+	for code, name := range keyNames {
+		if strings.EqualFold(last, name) {
+			ev.Key = code
+			return ev, nil
+		}
+	}
+
+	return KeyEvent{}, fmt.Errorf("invalid key %q: unknown key %q", spec, last)
+}
+
+// Keymap maps key combinations to named actions, and actions to the
+// functions that handle them. It is safe for concurrent use.
+//
+// Binding and handling are separate so that bindings can be changed (e.g.,
+// loaded from user configuration) without touching the code that implements
+// the actions.
+type Keymap struct {
+	// bindings maps the key combinations to the names of the actions.
+	bindings map[chord]string
+
+	// handlers maps the names of the actions to their handlers.
+	handlers map[string]func(ev KeyEvent)
+
+	// mu protects bindings and handlers.
+	mu sync.RWMutex
+}
+
+// NewKeymap creates a new Keymap.
+//
+// Returns:
+//   - *Keymap: The new Keymap. Never returns nil.
+func NewKeymap() *Keymap {
+	return &Keymap{
+		bindings: make(map[chord]string),
+		handlers: make(map[string]func(ev KeyEvent)),
+	}
+}
+
+// Bind is a method of Keymap that binds a key combination to an action. It
+// replaces any previous binding of the combination.
+//
+// Parameters:
+//   - spec: The key combination. See ParseKey.
+//   - action: The name of the action.
+//
+// Returns:
+//   - error: An error if the combination is invalid.
+func (km *Keymap) Bind(spec, action string) error {
+	if km == nil {
+		return errors.New("receiver must not be nil")
+	}
+
+	ev, err := ParseKey(spec)
+	if err != nil {
+		return err
+	}
+
+	km.mu.Lock()
+	defer km.mu.Unlock()
+
+	km.bindings[chordOf(ev)] = action
+
+	return nil
+}
+
+// Unbind is a method of Keymap that removes the binding of a key combination.
+//
+// Parameters:
+//   - spec: The key combination. See ParseKey.
+//
+// Returns:
+//   - bool: True if the combination was bound, false otherwise.
+func (km *Keymap) Unbind(spec string) bool {
+	if km == nil {
+		return false
+	}
+
+	ev, err := ParseKey(spec)
+	if err != nil {
+		return false
+	}
+
+	c := chordOf(ev)
+
+	km.mu.Lock()
+	defer km.mu.Unlock()
+
+	_, ok := km.bindings[c]
+	if ok {
+		delete(km.bindings, c)
+	}
+
+	return ok
+}
+
+// Handle is a method of Keymap that sets the function that handles an
+// action. It replaces any previous handler of the action.
+//
+// Parameters:
+//   - action: The name of the action.
+//   - fn: The function to call with the event that triggered the action. If
+//     nil, the handler is removed.
+func (km *Keymap) Handle(action string, fn func(ev KeyEvent)) {
+	if km == nil {
+		return
+	}
+
+	km.mu.Lock()
+	defer km.mu.Unlock()
+
+	if fn == nil {
+		delete(km.handlers, action)
+	} else {
+		km.handlers[action] = fn
+	}
+}
+
+// Lookup is a method of Keymap that returns the action bound to an event.
+//
+// Parameters:
+//   - ev: The event.
+//
+// Returns:
+//   - string: The name of the action. Empty if none is bound.
+//   - bool: True if an action is bound to the event, false otherwise.
+func (km *Keymap) Lookup(ev KeyEvent) (string, bool) {
+	if km == nil {
+		return "", false
+	}
+
+	km.mu.RLock()
+	defer km.mu.RUnlock()
+
+	action, ok := km.bindings[chordOf(ev)]
+	return action, ok
+}
+
+// Dispatch is a method of Keymap that calls the handler of the action bound
+// to an event.
+//
+// Parameters:
+//   - ev: The event.
+//
+// Returns:
+//   - bool: True if the event was handled, false if no action is bound to it
+//     or the action has no handler.
+func (km *Keymap) Dispatch(ev KeyEvent) bool {
+	if km == nil {
+		return false
+	}
+
+	km.mu.RLock()
+
+	action, ok := km.bindings[chordOf(ev)]
+
+	var fn func(ev KeyEvent)
+
+	if ok {
+		fn = km.handlers[action]
+	}
+
+	km.mu.RUnlock()
+
+	if fn == nil {
+		return false
+	}
+
+	fn(ev)
+
+	return true
+}
@@ -0,0 +1,344 @@
+package keyboard
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// LoadKeymap creates a Keymap from a configuration. See Keymap.Load for the
+// formats.
+//
+// Parameters:
+//   - r: The reader of the configuration.
+//
+// Returns:
+//   - *Keymap: The new Keymap. Nil if an error occurred.
+//   - error: An error if the configuration could not be read or is invalid.
+func LoadKeymap(r io.Reader) (*Keymap, error) {
+	km := NewKeymap()
+
+	err := km.Load(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return km, nil
+}
+
+// Load is a method of Keymap that binds the key combinations of a
+// configuration, so that end users can customize the shortcuts. Handlers are
+// kept, and bindings that are not in the configuration are left as they are.
+//
+// Parameters:
+//   - r: The reader of the configuration.
+//
+// Returns:
+//   - error: An error if the configuration could not be read, is invalid, or
+//     binds the same key combination to several actions. In that case, no
+//     binding is changed.
+//
+// The configuration maps the names of the actions to a key combination or a
+// list of them (see ParseKey), either as a JSON object:
+//
+//	{"save": "Ctrl+S", "quit": ["q", "Ctrl+C"]}
+//
+// or as TOML key/value pairs, without tables:
+//
+//	# Shortcuts
+//	save = "Ctrl+S"
+//	quit = ["q", "Ctrl+C"]
+func (km *Keymap) Load(r io.Reader) error {
+	if km == nil {
+		return errors.New("receiver must not be nil")
+	} else if r == nil {
+		return errors.New("reader must not be nil")
+	}
+
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+
+	var entries map[string][]string
+
+	trimmed := bytes.TrimSpace(data)
+
+	if len(trimmed) > 0 && trimmed[0] == '{' {
+		entries, err = parseJSONKeymap(trimmed)
+	} else {
+		entries, err = parseTOMLKeymap(data)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	actions := make([]string, 0, len(entries))
+
+	for action := range entries {
+		actions = append(actions, action)
+	}
+
+	slices.Sort(actions)
+
+	// Validate everything before binding anything.
+	bound := make(map[chord]string)
+
+	for _, action := range actions {
+		for _, spec := range entries[action] {
+			ev, err := ParseKey(spec)
+			if err != nil {
+				return fmt.Errorf("action %q: %w", action, err)
+			}
+
+			prev, ok := bound[chordOf(ev)]
+			if ok && prev != action {
+				return fmt.Errorf("action %q: key %q is already bound to action %q", action, spec, prev)
+			}
+
+			bound[chordOf(ev)] = action
+		}
+	}
+
+	for _, action := range actions {
+		for _, spec := range entries[action] {
+			_ = km.Bind(spec, action)
+		}
+	}
+
+	return nil
+}
+
+// parseJSONKeymap parses a JSON keymap configuration.
+//
+// Parameters:
+//   - data: The configuration.
+//
+// Returns:
+//   - map[string][]string: The key combinations of each action.
+//   - error: An error if the configuration is invalid.
+func parseJSONKeymap(data []byte) (map[string][]string, error) {
+	var raw map[string]json.RawMessage
+
+	err := json.Unmarshal(data, &raw)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make(map[string][]string, len(raw))
+
+	for action, value := range raw {
+		var spec string
+
+		err := json.Unmarshal(value, &spec)
+		if err == nil {
+			entries[action] = []string{spec}
+			continue
+		}
+
+		var specs []string
+
+		err = json.Unmarshal(value, &specs)
+		if err != nil {
+			return nil, fmt.Errorf("action %q: expected a string or a list of strings", action)
+		}
+
+		entries[action] = specs
+	}
+
+	return entries, nil
+}
+
+// parseTOMLKeymap parses a TOML keymap configuration. Only key/value pairs
+// whose values are strings or single-line arrays of strings are supported.
+//
+// Parameters:
+//   - data: The configuration.
+//
+// Returns:
+//   - map[string][]string: The key combinations of each action.
+//   - error: An error if the configuration is invalid.
+func parseTOMLKeymap(data []byte) (map[string][]string, error) {
+	entries := make(map[string][]string)
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+
+	for lineno := 1; scanner.Scan(); lineno++ {
+		line := strings.TrimSpace(scanner.Text())
+
+		if line == "" || line[0] == '#' {
+			continue
+		} else if line[0] == '[' {
+			return nil, fmt.Errorf("line %d: tables are not supported", lineno)
+		}
+
+		action, rest, err := scanTOMLKey(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineno, err)
+		}
+
+		rest = strings.TrimSpace(rest)
+
+		if rest == "" || rest[0] != '=' {
+			return nil, fmt.Errorf("line %d: expected '=' after %q", lineno, action)
+		}
+
+		specs, err := scanTOMLValue(strings.TrimSpace(rest[1:]))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineno, err)
+		}
+
+		_, ok := entries[action]
+		if ok {
+			return nil, fmt.Errorf("line %d: action %q is defined twice", lineno, action)
+		}
+
+		entries[action] = specs
+	}
+
+	err := scanner.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+// scanTOMLKey scans the key at the start of a TOML line.
+//
+// Parameters:
+//   - line: The line.
+//
+// Returns:
+//   - string: The key.
+//   - string: The rest of the line.
+//   - error: An error if the key is invalid.
+func scanTOMLKey(line string) (string, string, error) {
+	if line[0] == '"' || line[0] == '\'' {
+		return scanTOMLString(line)
+	}
+
+	end := strings.IndexFunc(line, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_' && r != '-'
+	})
+
+	if end == 0 {
+		return "", "", fmt.Errorf("invalid key at %q", line)
+	} else if end < 0 {
+		end = len(line)
+	}
+
+	return line[:end], line[end:], nil
+}
+
+// scanTOMLValue scans the value of a TOML key/value pair.
+//
+// Parameters:
+//   - s: The text after the '='.
+//
+// Returns:
+//   - []string: The strings of the value.
+//   - error: An error if the value is not a string or an array of strings.
+func scanTOMLValue(s string) ([]string, error) {
+	var values []string
+
+	if s == "" || s[0] != '[' {
+		value, rest, err := scanTOMLString(s)
+		if err != nil {
+			return nil, err
+		}
+
+		values = append(values, value)
+
+		return values, checkTOMLEnd(rest)
+	}
+
+	s = strings.TrimSpace(s[1:])
+
+	for s == "" || s[0] != ']' {
+		value, rest, err := scanTOMLString(s)
+		if err != nil {
+			return nil, err
+		}
+
+		values = append(values, value)
+
+		s = strings.TrimSpace(rest)
+
+		if s != "" && s[0] == ',' {
+			s = strings.TrimSpace(s[1:])
+		} else if s == "" || s[0] != ']' {
+			return nil, errors.New("expected ',' or ']' in array")
+		}
+	}
+
+	return values, checkTOMLEnd(s[1:])
+}
+
+// scanTOMLString scans a basic ("...") or literal ('...') TOML string.
+//
+// Parameters:
+//   - s: The text starting with the string.
+//
+// Returns:
+//   - string: The value of the string.
+//   - string: The rest of the text.
+//   - error: An error if s does not start with a valid string.
+func scanTOMLString(s string) (string, string, error) {
+	if s == "" {
+		return "", "", errors.New("expected a string")
+	}
+
+	switch s[0] {
+	case '\'':
+		end := strings.IndexByte(s[1:], '\'')
+		if end < 0 {
+			return "", "", errors.New("unterminated string")
+		}
+
+		return s[1 : end+1], s[end+2:], nil
+	case '"':
+		for i := 1; i < len(s); i++ {
+			switch s[i] {
+			case '\\':
+				i++
+			case '"':
+				value, err := strconv.Unquote(s[:i+1])
+				if err != nil {
+					return "", "", fmt.Errorf("invalid string %s", s[:i+1])
+				}
+
+				return value, s[i+1:], nil
+			}
+		}
+
+		return "", "", errors.New("unterminated string")
+	default:
+		return "", "", fmt.Errorf("expected a string at %q", s)
+	}
+}
+
+// checkTOMLEnd checks that only a comment follows a TOML value.
+//
+// Parameters:
+//   - rest: The text after the value.
+//
+// Returns:
+//   - error: An error if anything else follows the value.
+func checkTOMLEnd(rest string) error {
+	rest = strings.TrimSpace(rest)
+
+	if rest != "" && rest[0] != '#' {
+		return fmt.Errorf("unexpected %q after value", rest)
+	}
+
+	return nil
+}
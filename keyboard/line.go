@@ -0,0 +1,82 @@
+package keyboard
+
+import (
+	"github.com/PlayerR9/safe/runner"
+)
+
+// Mode is the input mode of a Keyboard.
+type Mode int
+
+const (
+	// ModeRaw delivers every key event to the key receiver. This is the
+	// default.
+	ModeRaw Mode = iota
+
+	// ModeLine assembles the key events into lines that are delivered to the
+	// line receiver once Enter is pressed. Backspace deletes the last
+	// character and Ctrl+U deletes the whole line; other special keys and
+	// combinations are discarded.
+	ModeLine
+)
+
+// String implements the fmt.Stringer interface.
+func (m Mode) String() string {
+	switch m {
+	case ModeRaw:
+		return "raw"
+	case ModeLine:
+		return "line"
+	default:
+		return "unknown"
+	}
+}
+
+// SetMode sets the input mode of the Keyboard. The line being edited, if
+// any, is discarded.
+//
+// Parameters:
+//   - mode: The input mode.
+//
+// Events bound in the Keymap are dispatched to their handlers in both modes.
+func (k *Keyboard) SetMode(mode Mode) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	k.mode = mode
+	k.line = nil
+}
+
+// GetLineReceiver returns the receiver of the lines assembled in ModeLine.
+//
+// Returns:
+//   - runner.Receiver[string]: The line receiver.
+//
+// Every start after a shutdown uses a new receiver.
+func (k *Keyboard) GetLineReceiver() runner.Receiver[string] {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	return k.lines
+}
+
+// edit is a private method of Keyboard that applies a key event to the line
+// being edited. The lock must be held.
+//
+// Parameters:
+//   - ev: The key event.
+func (k *Keyboard) edit(ev KeyEvent) {
+	switch {
+	case ev.Key == KeyEnter && ev.Mods == ModNone:
+		k.lines.Send(string(k.line))
+
+		k.line = nil
+	case ev.Key == KeyBackspace && ev.Mods == ModNone:
+		if len(k.line) > 0 {
+			k.line = k.line[:len(k.line)-1]
+		}
+	case ev.Key == KeyRune && ev.Mods == ModCtrl && ev.Rune == 'u':
+		k.line = nil
+	case ev.Key == KeyRune && (ev.Mods == ModNone || ev.Mods == ModShift):
+		k.line = append(k.line, ev.Rune)
+	}
+}
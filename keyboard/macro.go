@@ -0,0 +1,86 @@
+package keyboard
+
+import (
+	"errors"
+	"time"
+)
+
+// StartRecording starts recording the key events of the Keyboard. Any
+// recording in progress is discarded.
+//
+// Events injected by Playback are not recorded.
+func (k *Keyboard) StartRecording() {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	k.recording = true
+	k.record = nil
+}
+
+// StopRecording stops the recording of the key events.
+//
+// Returns:
+//   - []KeyEvent: The events recorded since StartRecording, in order. Nil if
+//     no recording is in progress.
+func (k *Keyboard) StopRecording() []KeyEvent {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	record := k.record
+
+	k.recording = false
+	k.record = nil
+
+	return record
+}
+
+// Playback injects the given events as if they were read from the backend.
+// Events bound in the Keymap are dispatched to their handlers. It blocks until
+// all the events are injected.
+//
+// Parameters:
+//   - events: The events to inject, usually returned by StopRecording.
+//   - speed: The speed factor of the playback. The delays between the events
+//     are those of their times divided by speed. If not positive, the events
+//     are injected at once.
+//
+// Returns:
+//   - error: An error if the Keyboard is not running or is closed before all
+//     the events are injected.
+//
+// The injected events carry the time they are injected at.
+func (k *Keyboard) Playback(events []KeyEvent, speed float64) error {
+	k.mu.Lock()
+	ctx := k.ctx
+	k.mu.Unlock()
+
+	if ctx == nil {
+		return errors.New("keyboard is not started")
+	}
+
+	for i, ev := range events {
+		if i > 0 && speed > 0 {
+			delay := time.Duration(float64(ev.Time.Sub(events[i-1].Time)) / speed)
+
+			if delay > 0 {
+				timer := time.NewTimer(delay)
+
+				select {
+				case <-timer.C:
+				case <-ctx.Done():
+					timer.Stop()
+				}
+			}
+		}
+
+		if ctx.Err() != nil {
+			return errors.New("keyboard is closed")
+		}
+
+		ev.Time = time.Now()
+
+		k.deliver(ev)
+	}
+
+	return nil
+}
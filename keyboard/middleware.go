@@ -0,0 +1,63 @@
+package keyboard
+
+// Interceptor transforms or swallows a key event before it is dispatched.
+//
+// Parameters:
+//   - ev: The key event.
+//
+// Returns:
+//   - KeyEvent: The event to pass on. Ignored if the bool is false.
+//   - bool: True to pass the event on, false to swallow it.
+type Interceptor func(ev KeyEvent) (KeyEvent, bool)
+
+// Use appends interceptors to the chain of the Keyboard. Every key event
+// goes through the chain, in the order the interceptors were added, before
+// it reaches the Keymap and the receivers.
+//
+// Parameters:
+//   - fns: The interceptors. Nil ones are ignored.
+//
+// Events injected by Playback go through the chain too, since recordings
+// hold the events as they were read.
+func (k *Keyboard) Use(fns ...Interceptor) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	// Copy on write so that intercept can iterate without the lock.
+	chain := make([]Interceptor, 0, len(k.chain)+len(fns))
+	chain = append(chain, k.chain...)
+
+	for _, fn := range fns {
+		if fn != nil {
+			chain = append(chain, fn)
+		}
+	}
+
+	k.chain = chain
+}
+
+// intercept is a private method of Keyboard that passes a key event through
+// the chain of interceptors.
+//
+// Parameters:
+//   - ev: The key event.
+//
+// Returns:
+//   - KeyEvent: The transformed event.
+//   - bool: False if an interceptor swallowed the event, true otherwise.
+func (k *Keyboard) intercept(ev KeyEvent) (KeyEvent, bool) {
+	k.mu.Lock()
+	chain := k.chain
+	k.mu.Unlock()
+
+	for _, fn := range chain {
+		var ok bool
+
+		ev, ok = fn(ev)
+		if !ok {
+			return ev, false
+		}
+	}
+
+	return ev, true
+}
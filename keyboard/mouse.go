@@ -0,0 +1,140 @@
+package keyboard
+
+import (
+	"strings"
+	"time"
+)
+
+// MouseButton is a set of mouse buttons.
+type MouseButton int
+
+const (
+	// MouseLeft is the left (primary) button.
+	MouseLeft MouseButton = 1 << iota
+
+	// MouseMiddle is the middle button.
+	MouseMiddle
+
+	// MouseRight is the right (secondary) button.
+	MouseRight
+)
+
+// MouseNone is the empty set of buttons. Events without buttons are motions
+// or releases.
+const MouseNone MouseButton = 0
+
+// Has checks whether all the given buttons are set.
+//
+// Parameters:
+//   - btn: The buttons to check.
+//
+// Returns:
+//   - bool: True if all of them are set, false otherwise.
+func (b MouseButton) Has(btn MouseButton) bool {
+	return b&btn == btn
+}
+
+// String implements the fmt.Stringer interface.
+//
+// Buttons are joined with '+' in the order Left, Middle, Right.
+func (b MouseButton) String() string {
+	var parts []string
+
+	if b.Has(MouseLeft) {
+		parts = append(parts, "Left")
+	}
+
+	if b.Has(MouseMiddle) {
+		parts = append(parts, "Middle")
+	}
+
+	if b.Has(MouseRight) {
+		parts = append(parts, "Right")
+	}
+
+	return strings.Join(parts, "+")
+}
+
+// Wheel is the direction of a wheel motion.
+type Wheel int
+
+const (
+	// WheelNone means the wheel did not move.
+	WheelNone Wheel = iota
+
+	// WheelUp is a motion up, away from the user.
+	WheelUp
+
+	// WheelDown is a motion down, towards the user.
+	WheelDown
+
+	// WheelLeft is a motion to the left.
+	WheelLeft
+
+	// WheelRight is a motion to the right.
+	WheelRight
+)
+
+// String implements the fmt.Stringer interface.
+func (w Wheel) String() string {
+	switch w {
+	case WheelNone:
+		return "none"
+	case WheelUp:
+		return "up"
+	case WheelDown:
+		return "down"
+	case WheelLeft:
+		return "left"
+	case WheelRight:
+		return "right"
+	default:
+		return "unknown"
+	}
+}
+
+// MouseEvent is a mouse press, release, motion or wheel impulse.
+type MouseEvent struct {
+	// X is the column of the pointer, starting at 0 on the left.
+	X int
+
+	// Y is the row of the pointer, starting at 0 at the top.
+	Y int
+
+	// Buttons are the buttons held.
+	Buttons MouseButton
+
+	// Wheel is the direction of the wheel motion, if any.
+	Wheel Wheel
+
+	// Mods are the modifiers held.
+	Mods Modifier
+
+	// Time is the time of the event.
+	Time time.Time
+}
+
+// Event is either a KeyEvent or a MouseEvent.
+type Event interface {
+	// isEvent is a private method that seals the interface.
+	isEvent()
+}
+
+// isEvent implements the Event interface.
+func (KeyEvent) isEvent() {}
+
+// isEvent implements the Event interface.
+func (MouseEvent) isEvent() {}
+
+// MouseBackend is a Backend that also reports mouse events. When a Keyboard
+// uses a MouseBackend, ReadInput is called instead of ReadKey.
+type MouseBackend interface {
+	Backend
+
+	// ReadInput blocks until the next key press or mouse event.
+	//
+	// Returns:
+	//   - Event: The KeyEvent or MouseEvent.
+	//   - error: An error if the input could not be read.
+	ReadInput() (Event, error)
+}
@@ -0,0 +1,123 @@
+package keyboard
+
+import (
+	"context"
+	"time"
+
+	gcers "github.com/PlayerR9/go-errors"
+	"github.com/PlayerR9/safe/runner"
+)
+
+// Recovery configures how a Keyboard recovers from the errors of its
+// backend.
+type Recovery struct {
+	// InitialDelay is the delay before the first reopening of the backend.
+	// Each subsequent consecutive failure doubles the delay.
+	InitialDelay time.Duration
+
+	// MaxDelay is the upper bound of the delay between reopenings. Zero means
+	// no upper bound.
+	MaxDelay time.Duration
+
+	// MaxFailures is the number of consecutive failures after which the
+	// Keyboard gives up.
+	MaxFailures int
+}
+
+// SetRecovery sets how the Keyboard recovers from the errors of its backend.
+// When a read fails, the backend is closed and reopened after a delay, and
+// nothing is reported. After MaxFailures consecutive failures, whether of a
+// read or of a reopening, a *runner.ErrRetriesExceeded is sent to the error
+// channel and the Keyboard shuts down.
+//
+// Parameters:
+//   - cfg: The recovery configuration. If nil, every error is sent to the
+//     error channel and the backend is read again at once.
+//
+// Returns:
+//   - error: An error if a delay is negative or MaxFailures is not positive.
+func (k *Keyboard) SetRecovery(cfg *Recovery) error {
+	if cfg != nil {
+		if cfg.InitialDelay < 0 || cfg.MaxDelay < 0 {
+			return gcers.NewErrInvalidParameter("delays must not be negative")
+		} else if cfg.MaxFailures <= 0 {
+			return gcers.NewErrInvalidParameter("max failures must be positive")
+		}
+
+		cfg = &Recovery{
+			InitialDelay: cfg.InitialDelay,
+			MaxDelay:     cfg.MaxDelay,
+			MaxFailures:  cfg.MaxFailures,
+		}
+	}
+
+	k.recovery.Store(cfg)
+
+	return nil
+}
+
+// recoverer keeps track of the consecutive failures of a backend.
+type recoverer struct {
+	// failures is the number of consecutive failures.
+	failures int
+
+	// delay is the delay of the next reopening.
+	delay time.Duration
+}
+
+// reopen is a private method of Keyboard that reopens the backend after a
+// failed read, with backoff.
+//
+// Parameters:
+//   - ctx: The context of the run.
+//   - cfg: The recovery configuration.
+//   - rec: The failures of the run.
+//   - err: The error of the read.
+//
+// Returns:
+//   - error: The fatal error, if the Keyboard has to give up. Nil otherwise.
+func (k *Keyboard) reopen(ctx context.Context, cfg *Recovery, rec *recoverer, err error) error {
+	for {
+		if rec.failures == 0 {
+			rec.delay = cfg.InitialDelay
+		}
+
+		rec.failures++
+
+		if rec.failures >= cfg.MaxFailures {
+			return runner.NewErrRetriesExceeded(rec.failures, err)
+		}
+
+		_ = k.backend.Close()
+
+		timer := time.NewTimer(rec.delay)
+
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return nil
+		}
+
+		rec.delay *= 2
+
+		if cfg.MaxDelay > 0 && rec.delay > cfg.MaxDelay {
+			rec.delay = cfg.MaxDelay
+		}
+
+		err = k.backend.Open()
+
+		if ctx.Err() != nil {
+			// The shutdown may have closed the backend before it was reopened.
+			if err == nil {
+				_ = k.backend.Close()
+			}
+
+			return nil
+		}
+
+		if err == nil {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,246 @@
+package keyboard
+
+import (
+	"sync"
+	"time"
+
+	gcers "github.com/PlayerR9/go-errors"
+)
+
+// Repeat configures the auto-repeat of held keys.
+//
+// Terminals do not report key releases. Instead, they resend a held key at
+// their own pace, starting after their own delay. A key is therefore
+// considered held once the backend reports it a second time, and released
+// once the backend stopped reporting it for Release.
+//
+// Since a quick double press of the same key, such as the "ll" of "hello",
+// looks the same as a held key, presses are never discarded unless Coalesce
+// is set.
+type Repeat struct {
+	// Delay is how long a key must be held before its first repeat. Only used
+	// if Coalesce is set.
+	Delay time.Duration
+
+	// Interval is the time between two repeats. Only used if Coalesce is set.
+	Interval time.Duration
+
+	// Release is the longest silence of the backend about a key that is still
+	// held. It must exceed the initial delay of the terminal, usually around
+	// 500ms.
+	Release time.Duration
+
+	// Coalesce, if true, discards the resends of the backend and emits the
+	// repeats of the held key every Interval, starting Delay after it was
+	// pressed. A press of the same key within Release of the previous one is
+	// then taken as a resend and discarded. If false, every press is delivered
+	// as it is, with Repeat set on those within Release of the previous press
+	// of the same key.
+	Coalesce bool
+}
+
+// repeater emits the repeats of the held key.
+type repeater struct {
+	// cfg is the configuration of the repeats.
+	cfg Repeat
+
+	// emit is called with every repeat.
+	emit func(ev KeyEvent)
+
+	// active is true while a key may be held.
+	active bool
+
+	// held is the event of the first press of the held key.
+	held KeyEvent
+
+	// c is the chord of the held key.
+	c chord
+
+	// first is the time of the first press of the held key.
+	first time.Time
+
+	// last is the time the backend last reported the held key.
+	last time.Time
+
+	// confirmed is true once the backend reported the held key twice.
+	confirmed bool
+
+	// gen is incremented whenever the held key changes, so that stale timers
+	// do nothing.
+	gen int
+
+	// timer fires the next repeat. Nil if none is scheduled.
+	timer *time.Timer
+
+	// mu protects the fields above except cfg and emit.
+	mu sync.Mutex
+}
+
+// newRepeater creates a new repeater.
+//
+// Parameters:
+//   - cfg: The configuration of the repeats.
+//   - emit: The function called with every repeat.
+//
+// Returns:
+//   - *repeater: The new repeater. Never returns nil.
+func newRepeater(cfg Repeat, emit func(ev KeyEvent)) *repeater {
+	return &repeater{
+		cfg:  cfg,
+		emit: emit,
+	}
+}
+
+// press is a private method of repeater that records a key event of the
+// backend.
+//
+// Parameters:
+//   - ev: The key event.
+//
+// Returns:
+//   - KeyEvent: The event to deliver.
+//   - bool: True if the event must be delivered, false if it is a resend of
+//     the held key and the repeater takes care of it.
+func (r *repeater) press(ev KeyEvent) (KeyEvent, bool) {
+	if r == nil {
+		return ev, true
+	}
+
+	now := time.Now()
+	c := chordOf(ev)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.active && r.c == c && now.Sub(r.last) <= r.cfg.Release {
+		r.last = now
+
+		if !r.cfg.Coalesce {
+			ev.Repeat = true
+
+			return ev, true
+		}
+
+		if !r.confirmed {
+			r.confirmed = true
+
+			r.schedule(r.first.Add(r.cfg.Delay).Sub(now))
+		}
+
+		return ev, false
+	}
+
+	r.reset()
+
+	r.active = true
+	r.held = ev
+	r.c = c
+	r.first = now
+	r.last = now
+
+	return ev, true
+}
+
+// stop is a private method of repeater that stops the pending repeats.
+func (r *repeater) stop() {
+	if r == nil {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.reset()
+}
+
+// reset is a private method of repeater that forgets the held key. The lock
+// must be held.
+func (r *repeater) reset() {
+	if r.timer != nil {
+		r.timer.Stop()
+		r.timer = nil
+	}
+
+	r.active = false
+	r.confirmed = false
+	r.gen++
+}
+
+// schedule is a private method of repeater that schedules the next repeat.
+// The lock must be held.
+//
+// Parameters:
+//   - after: The time to wait. Non-positive values fire at once.
+func (r *repeater) schedule(after time.Duration) {
+	gen := r.gen
+
+	r.timer = time.AfterFunc(max(after, 0), func() {
+		r.tick(gen)
+	})
+}
+
+// tick is a private method of repeater that emits a repeat of the held key
+// and schedules the next one.
+//
+// Parameters:
+//   - gen: The generation the timer was scheduled in.
+func (r *repeater) tick(gen int) {
+	r.mu.Lock()
+
+	if gen != r.gen || !r.active {
+		r.mu.Unlock()
+		return
+	}
+
+	now := time.Now()
+
+	if now.Sub(r.last) > r.cfg.Release {
+		// The key was released.
+		r.reset()
+		r.mu.Unlock()
+
+		return
+	}
+
+	ev := r.held
+	ev.Repeat = true
+	ev.Time = now
+
+	r.schedule(r.cfg.Interval)
+
+	r.mu.Unlock()
+
+	r.emit(ev)
+}
+
+// SetRepeat enables the detection of held keys. The resends of the backend
+// are delivered with Repeat set or, if Coalesce is set, the Keyboard emits a
+// copy of the event of the held key with Repeat set every Interval, starting
+// Delay after the key was pressed, and the resends are discarded.
+//
+// Parameters:
+//   - cfg: The configuration of the repeats. If nil, auto-repeat is disabled
+//     and the resends of the backend are delivered as they are.
+//
+// Returns:
+//   - error: An error if Delay is negative, if Release is not positive, or if
+//     Coalesce is set and Interval is not positive.
+func (k *Keyboard) SetRepeat(cfg *Repeat) error {
+	var r *repeater
+
+	if cfg != nil {
+		if cfg.Delay < 0 {
+			return gcers.NewErrInvalidParameter("delay must not be negative")
+		} else if cfg.Coalesce && cfg.Interval <= 0 {
+			return gcers.NewErrInvalidParameter("interval must be positive")
+		} else if cfg.Release <= 0 {
+			return gcers.NewErrInvalidParameter("release must be positive")
+		}
+
+		r = newRepeater(*cfg, k.dispatchKey)
+	}
+
+	k.repeat.Swap(r).stop()
+
+	return nil
+}
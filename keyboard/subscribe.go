@@ -0,0 +1,76 @@
+package keyboard
+
+import (
+	"slices"
+
+	"github.com/PlayerR9/safe/runner"
+)
+
+// Subscribe adds an independent consumer of the key events. Every key event
+// that would reach the key receiver is also sent to every subscriber, so that
+// each of them sees all the events regardless of the others.
+//
+// Returns:
+//   - runner.Receiver[KeyEvent]: The receiver of the subscriber. It is closed
+//     on Unsubscribe or once the Keyboard shuts down.
+//
+// A subscriber that is not drained keeps queuing events, up to the capacity
+// of the Keyboard (see NewBoundedKeyboard); call Unsubscribe once it is no
+// longer needed.
+func (k *Keyboard) Subscribe() runner.Receiver[KeyEvent] {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	sub := newBoundedBuffer[KeyEvent](k.capacity, k.policy)
+
+	// Copy on write so that deliver can iterate without the lock.
+	subs := make([]*buffer[KeyEvent], 0, len(k.subs)+1)
+	subs = append(subs, k.subs...)
+
+	k.subs = append(subs, sub)
+
+	return sub
+}
+
+// Unsubscribe removes a subscriber and closes its receiver.
+//
+// Parameters:
+//   - rcv: The receiver returned by Subscribe.
+//
+// Returns:
+//   - bool: True if rcv was subscribed, false otherwise.
+func (k *Keyboard) Unsubscribe(rcv runner.Receiver[KeyEvent]) bool {
+	sub, ok := rcv.(*buffer[KeyEvent])
+	if !ok {
+		return false
+	}
+
+	k.mu.Lock()
+
+	idx := slices.Index(k.subs, sub)
+	if idx < 0 {
+		k.mu.Unlock()
+		return false
+	}
+
+	k.subs = slices.Delete(slices.Clone(k.subs), idx, idx+1)
+
+	k.mu.Unlock()
+
+	sub.Close()
+
+	return true
+}
+
+// closeSubscribers is a private method of Keyboard that removes all the
+// subscribers and closes their receivers.
+func (k *Keyboard) closeSubscribers() {
+	k.mu.Lock()
+	subs := k.subs
+	k.subs = nil
+	k.mu.Unlock()
+
+	for _, sub := range subs {
+		sub.Close()
+	}
+}
@@ -0,0 +1,226 @@
+package keyboard
+
+import (
+	"sync/atomic"
+
+	"github.com/gdamore/tcell"
+)
+
+// tcellKeys maps the special keys of tcell to their codes.
+var tcellKeys = map[tcell.Key]Key{
+	tcell.KeyEnter:      KeyEnter,
+	tcell.KeyTab:        KeyTab,
+	tcell.KeyBackspace:  KeyBackspace,
+	tcell.KeyBackspace2: KeyBackspace,
+	tcell.KeyEscape:     KeyEsc,
+	tcell.KeyInsert:     KeyInsert,
+	tcell.KeyDelete:     KeyDelete,
+	tcell.KeyHome:       KeyHome,
+	tcell.KeyEnd:        KeyEnd,
+	tcell.KeyPgUp:       KeyPgUp,
+	tcell.KeyPgDn:       KeyPgDn,
+	tcell.KeyUp:         KeyUp,
+	tcell.KeyDown:       KeyDown,
+	tcell.KeyLeft:       KeyLeft,
+	tcell.KeyRight:      KeyRight,
+	tcell.KeyF1:         KeyF1,
+	tcell.KeyF2:         KeyF2,
+	tcell.KeyF3:         KeyF3,
+	tcell.KeyF4:         KeyF4,
+	tcell.KeyF5:         KeyF5,
+	tcell.KeyF6:         KeyF6,
+	tcell.KeyF7:         KeyF7,
+	tcell.KeyF8:         KeyF8,
+	tcell.KeyF9:         KeyF9,
+	tcell.KeyF10:        KeyF10,
+	tcell.KeyF11:        KeyF11,
+	tcell.KeyF12:        KeyF12,
+}
+
+// tcellMods converts tcell modifiers into Modifiers.
+//
+// Parameters:
+//   - mods: The tcell modifiers.
+//
+// Returns:
+//   - Modifier: The converted modifiers.
+func tcellMods(mods tcell.ModMask) Modifier {
+	var out Modifier
+
+	if mods&tcell.ModShift != 0 {
+		out |= ModShift
+	}
+
+	if mods&tcell.ModCtrl != 0 {
+		out |= ModCtrl
+	}
+
+	if mods&(tcell.ModAlt|tcell.ModMeta) != 0 {
+		out |= ModAlt
+	}
+
+	return out
+}
+
+// tcellMouse converts a tcell mouse event into a MouseEvent.
+//
+// Parameters:
+//   - ev: The tcell mouse event.
+//
+// Returns:
+//   - MouseEvent: The converted event.
+func tcellMouse(ev *tcell.EventMouse) MouseEvent {
+	out := MouseEvent{
+		Mods: tcellMods(ev.Modifiers()),
+		Time: ev.When(),
+	}
+
+	out.X, out.Y = ev.Position()
+
+	btn := ev.Buttons()
+
+	if btn&tcell.Button1 != 0 {
+		out.Buttons |= MouseLeft
+	}
+
+	if btn&tcell.Button2 != 0 {
+		out.Buttons |= MouseMiddle
+	}
+
+	if btn&tcell.Button3 != 0 {
+		out.Buttons |= MouseRight
+	}
+
+	switch {
+	case btn&tcell.WheelUp != 0:
+		out.Wheel = WheelUp
+	case btn&tcell.WheelDown != 0:
+		out.Wheel = WheelDown
+	case btn&tcell.WheelLeft != 0:
+		out.Wheel = WheelLeft
+	case btn&tcell.WheelRight != 0:
+		out.Wheel = WheelRight
+	}
+
+	return out
+}
+
+// tcellEvent converts a tcell key event into a KeyEvent.
+//
+// Parameters:
+//   - ev: The tcell key event.
+//
+// Returns:
+//   - KeyEvent: The converted event.
+func tcellEvent(ev *tcell.EventKey) KeyEvent {
+	out := KeyEvent{
+		Mods: tcellMods(ev.Modifiers()),
+		Time: ev.When(),
+	}
+
+	key := ev.Key()
+
+	switch {
+	case key == tcell.KeyRune:
+		out.Rune = ev.Rune()
+	case key == tcell.KeyCtrlSpace:
+		out.Rune = ' '
+		out.Mods |= ModCtrl
+	default:
+		code, ok := tcellKeys[key]
+		if ok {
+			out.Key = code
+		} else if key >= tcell.KeyCtrlA && key <= tcell.KeyCtrlZ {
+			out.Rune = 'a' + rune(key-tcell.KeyCtrlA)
+			out.Mods |= ModCtrl
+		} else {
+			out.Rune = ev.Rune()
+		}
+	}
+
+	return out
+}
+
+// tcellBackend is the Backend that reads the events of a tcell.Screen.
+type tcellBackend struct {
+	// screen is the screen the events are read from.
+	screen tcell.Screen
+
+	// closed is true once the backend is closed.
+	closed atomic.Bool
+}
+
+// NewTcellBackend creates a Backend that reads the key and mouse events of a
+// tcell.Screen, so that applications drawing with tcell share one input
+// pipeline with the Keyboard.
+//
+// Parameters:
+//   - screen: The screen. It must be initialized by the caller, who also
+//     remains in charge of finalizing it and of enabling the mouse.
+//
+// Returns:
+//   - MouseBackend: The new Backend. Nil if screen is nil.
+//
+// Events other than key presses and mouse events are discarded.
+func NewTcellBackend(screen tcell.Screen) MouseBackend {
+	if screen == nil {
+		return nil
+	}
+
+	return &tcellBackend{
+		screen: screen,
+	}
+}
+
+// Open implements the Backend interface.
+func (b *tcellBackend) Open() error {
+	b.closed.Store(false)
+
+	return nil
+}
+
+// Close implements the Backend interface.
+//
+// The screen is not finalized; an interrupt is posted to unblock a pending
+// read.
+func (b *tcellBackend) Close() error {
+	if b.closed.Swap(true) {
+		return nil
+	}
+
+	return b.screen.PostEvent(tcell.NewEventInterrupt(b))
+}
+
+// ReadKey implements the Backend interface.
+//
+// Mouse events are discarded.
+func (b *tcellBackend) ReadKey() (KeyEvent, error) {
+	for {
+		ev, err := b.ReadInput()
+		if err != nil {
+			return KeyEvent{}, err
+		}
+
+		key, ok := ev.(KeyEvent)
+		if ok {
+			return key, nil
+		}
+	}
+}
+
+// ReadInput implements the MouseBackend interface.
+func (b *tcellBackend) ReadInput() (Event, error) {
+	for !b.closed.Load() {
+		switch ev := b.screen.PollEvent().(type) {
+		case nil:
+			// The screen was finalized.
+			return nil, BackendClosed
+		case *tcell.EventKey:
+			return tcellEvent(ev), nil
+		case *tcell.EventMouse:
+			return tcellMouse(ev), nil
+		}
+	}
+
+	return nil, BackendClosed
+}
@@ -1,10 +1,16 @@
 package runner
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"slices"
 	"sync"
+	"time"
 
+	gcers "github.com/PlayerR9/go-errors"
 	rws "github.com/PlayerR9/safe/rw_safe"
+	sbj "github.com/PlayerR9/safe/subject"
 )
 
 var (
@@ -16,11 +22,245 @@ func init() {
 	AlreadyRunning = errors.New("the process is already running")
 }
 
+// RoutineState is the state of a Go routine of a batch.
+type RoutineState int
+
+const (
+	// RoutineStarted is emitted when the Go routine is started.
+	RoutineStarted RoutineState = iota
+
+	// RoutineErrored is emitted when the Go routine reports an error.
+	RoutineErrored
+
+	// RoutineFinished is emitted when the Go routine has finished.
+	RoutineFinished
+)
+
+// String implements the fmt.Stringer interface.
+func (s RoutineState) String() string {
+	switch s {
+	case RoutineStarted:
+		return "started"
+	case RoutineErrored:
+		return "errored"
+	case RoutineFinished:
+		return "finished"
+	default:
+		return "unknown"
+	}
+}
+
+// RoutineResult is the outcome of a Go routine of a batch.
+type RoutineResult struct {
+	// Err is the last error reported by the Go routine.
+	Err error
+
+	// Start is the time the Go routine was started. Zero if it was never
+	// started.
+	Start time.Time
+
+	// Duration is the time the Go routine ran for. Zero if it was never
+	// started.
+	Duration time.Duration
+
+	// Panicked is true if the Go routine finished because of a panic.
+	Panicked bool
+}
+
+// progressEvent is a state transition of a Go routine of a batch.
+type progressEvent struct {
+	// id is the identifier of the Go routine.
+	id string
+
+	// state is the new state of the Go routine.
+	state RoutineState
+}
+
+// batchOutcome is the first failure of a batch.
+type batchOutcome struct {
+	// id is the identifier of the Go routine that failed first.
+	id string
+
+	// err is the error of the Go routine that failed first. Nil if none failed.
+	err error
+
+	// finished is true once all the Go routines have finished.
+	finished bool
+}
+
+// batchEntry is a Go routine of a batch along with its prerequisites.
+type batchEntry struct {
+	// handler is the handler of the Go routine.
+	handler ErrRunner
+
+	// send forwards a message to the handler. Nil if the handler does not
+	// accept messages.
+	send func(msg any) bool
+
+	// deps are the identifiers of the Go routines that must finish before
+	// this one is started.
+	deps []string
+
+	// err is the last error reported by the Go routine. Only valid once done
+	// is closed.
+	err error
+
+	// done is closed when the Go routine has finished. Nil if the batch was
+	// never started.
+	done chan struct{}
+
+	// stopped is true if the Go routine was stopped with Batch.Stop.
+	stopped bool
+
+	// start is the time the Go routine was started. Zero if it was not.
+	start time.Time
+
+	// end is the time the Go routine finished. Only valid once done is closed.
+	end time.Time
+
+	// mu is the mutex that protects err, done, stopped, start and end.
+	mu sync.Mutex
+}
+
+// reset is a private method of batchEntry that prepares the entry for a new run.
+//
+// Returns:
+//   - chan struct{}: The new done channel.
+func (e *batchEntry) reset() chan struct{} {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.err = nil
+	e.stopped = false
+	e.start = time.Time{}
+	e.end = time.Time{}
+	e.done = make(chan struct{})
+
+	return e.done
+}
+
+// setErr is a private method of batchEntry that records an error.
+//
+// Parameters:
+//   - err: The error to record.
+func (e *batchEntry) setErr(err error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.err = err
+}
+
+// finish is a private method of batchEntry that records the time the Go
+// routine finished.
+func (e *batchEntry) finish() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.end = time.Now()
+}
+
+// result is a private method of batchEntry that builds the result of the Go
+// routine. The caller must hold the lock.
+//
+// Returns:
+//   - RoutineResult: The result of the Go routine.
+func (e *batchEntry) result() RoutineResult {
+	res := RoutineResult{
+		Err:   e.err,
+		Start: e.start,
+	}
+
+	if !e.start.IsZero() && !e.end.IsZero() {
+		res.Duration = e.end.Sub(e.start)
+	}
+
+	var panic_err *ErrPanic
+
+	res.Panicked = errors.As(e.err, &panic_err)
+
+	return res
+}
+
+// wait is a private method of batchEntry that waits for the Go routine to
+// finish. If the Go routine is restarted in the meantime, it waits for the new
+// run instead.
+//
+// Returns:
+//   - RoutineResult: The result of the Go routine.
+func (e *batchEntry) wait() RoutineResult {
+	res, _ := e.waitCtx(context.Background())
+	return res
+}
+
+// waitCtx is like wait but it stops waiting when the context is done.
+//
+// Parameters:
+//   - ctx: The context to observe.
+//
+// Returns:
+//   - RoutineResult: The result of the Go routine.
+//   - bool: True if the Go routine finished, false if the context is done first.
+func (e *batchEntry) waitCtx(ctx context.Context) (RoutineResult, bool) {
+	for {
+		e.mu.Lock()
+		done := e.done
+		e.mu.Unlock()
+
+		if done == nil {
+			return RoutineResult{}, true
+		}
+
+		select {
+		case <-done:
+		case <-ctx.Done():
+			return RoutineResult{}, false
+		}
+
+		e.mu.Lock()
+		if e.done == done {
+			res := e.result()
+			e.mu.Unlock()
+
+			return res, true
+		}
+		e.mu.Unlock()
+	}
+}
+
+// stop is a private method of batchEntry that stops the Go routine.
+//
+// The handler is closed without holding the lock, since closing it waits for
+// the Go routine, which may need the lock to report its errors.
+func (e *batchEntry) stop() {
+	e.mu.Lock()
+	e.stopped = true
+	e.mu.Unlock()
+
+	e.handler.Close()
+}
+
 // Batch is a struct that represents a batch of Go routines.
 type Batch struct {
-	// handlers is a map of the identifiers of the Go routines to the GRHandler
-	// instances that handle them.
-	handlers map[string]*HandlerSimple
+	// entries is a map of the identifiers of the Go routines to the entries
+	// that handle them.
+	entries map[string]*batchEntry
+
+	// maxConcurrent is the maximum number of Go routines that run at the same
+	// time. Zero means no limit.
+	maxConcurrent int
+
+	// sem limits the number of Go routines that run at the same time. Nil if
+	// there is no limit.
+	sem chan struct{}
+
+	// progress is the subject that notifies the state transitions of the Go routines.
+	progress *sbj.Subject[progressEvent]
+
+	// progressMu serializes the notifications of progress.
+	progressMu sync.Mutex
+
+	// outcome records the first failure of the current run.
+	outcome *rws.SafeCond[batchOutcome]
 }
 
 // NewBatch creates a new batch of Go routines.
@@ -29,7 +269,9 @@ type Batch struct {
 //   - *Batch: The new batch. Never returns nil.
 func NewBatch() *Batch {
 	return &Batch{
-		handlers: make(map[string]*HandlerSimple),
+		entries:  make(map[string]*batchEntry),
+		progress: sbj.NewSubject(progressEvent{}),
+		outcome:  rws.NewSafeCond(batchOutcome{}),
 	}
 }
 
@@ -38,18 +280,142 @@ func NewBatch() *Batch {
 // Parameters:
 //   - identifier: The identifier of the Go routine.
 //   - routine: The Go routine to add to the batch.
+//   - deps: The identifiers of the Go routines that must finish before this one
+//     is started.
 //
 // Behaviors:
 //   - It ignores nil Go routines.
 //   - It replaces the Go routine if the identifier already exists in the batch.
-func (b *Batch) Add(identifier string, routine func() error) {
+//   - A Go routine whose prerequisite failed (with an error other than NoError)
+//     is never started and reports an *ErrDependency instead.
+func (b *Batch) Add(identifier string, routine func() error, deps ...string) {
 	if b == nil || routine == nil {
 		return
 	}
 
 	h, _ := NewHandlerSimple(routine)
 
-	b.handlers[identifier] = h
+	b.entries[identifier] = &batchEntry{
+		handler: h,
+		deps:    deps,
+	}
+}
+
+// AddSender adds a message-driven handler, such as a HandlerSend, to the
+// batch. Messages are routed to it with Batch.Send.
+//
+// Parameters:
+//   - b: The batch.
+//   - identifier: The identifier of the handler.
+//   - h: The handler to add.
+//   - deps: The identifiers of the Go routines that must finish before this
+//     handler is started.
+//
+// Behaviors:
+//   - It ignores nil handlers and nil batches.
+//   - It replaces the Go routine if the identifier already exists in the batch.
+//   - If 'h' is an ErrReceiver, its errors are reported like those of the other
+//     Go routines. Otherwise, it is considered finished only once it is stopped.
+//   - Handlers usually keep running until they are stopped, so WaitAll does
+//     not return before Stop or StopAll is called.
+func AddSender[T any](b *Batch, identifier string, h SenderRunner[T], deps ...string) {
+	if b == nil || h == nil {
+		return
+	}
+
+	r, ok := h.(ErrRunner)
+	if !ok {
+		r = &silentRunner{
+			Runner: h,
+		}
+	}
+
+	b.entries[identifier] = &batchEntry{
+		handler: r,
+		send: func(msg any) bool {
+			m, ok := msg.(T)
+			if !ok {
+				return false
+			}
+
+			return h.Send(m)
+		},
+		deps: deps,
+	}
+}
+
+// Send is a method of Batch that sends a message to a handler added with
+// AddSender.
+//
+// Parameters:
+//   - identifier: The identifier of the handler.
+//   - msg: The message to send. It must be of the type the handler accepts.
+//
+// Returns:
+//   - bool: True if the message was sent, false if the handler does not
+//     exist, does not accept messages of that type or is not running.
+func (b *Batch) Send(identifier string, msg any) bool {
+	if b == nil {
+		return false
+	}
+
+	e, ok := b.entries[identifier]
+	if !ok || e.send == nil {
+		return false
+	}
+
+	return e.send(msg)
+}
+
+// SetMaxConcurrent is a method of Batch that limits the number of Go routines
+// that run at the same time. The remaining ones are started as slots free up.
+// It only takes effect on the next call to StartAll.
+//
+// Parameters:
+//   - n: The maximum number of concurrent Go routines. Zero or less means no limit.
+func (b *Batch) SetMaxConcurrent(n int) {
+	if b == nil {
+		return
+	}
+
+	if n < 0 {
+		n = 0
+	}
+
+	b.maxConcurrent = n
+}
+
+// ObserveProgress is a method of Batch that registers a function that is
+// called on every state transition of the Go routines of the batch.
+//
+// Parameters:
+//   - fn: The function to call with the identifier and the new state.
+//
+// If 'fn' or the receiver are nil, then nothing is done.
+func (b *Batch) ObserveProgress(fn func(id string, state RoutineState)) {
+	if b == nil || fn == nil {
+		return
+	}
+
+	b.progress.SetObserver(func(ev progressEvent) {
+		fn(ev.id, ev.state)
+	})
+}
+
+// notify is a private method of Batch that notifies the observers of a state
+// transition.
+//
+// Parameters:
+//   - id: The identifier of the Go routine.
+//   - state: The new state of the Go routine.
+func (b *Batch) notify(id string, state RoutineState) {
+	b.progressMu.Lock()
+	defer b.progressMu.Unlock()
+
+	b.progress.Set(progressEvent{
+		id:    id,
+		state: state,
+	})
 }
 
 // Clear is a method of Batch that clears the batch.
@@ -58,75 +424,467 @@ func (b *Batch) Clear() {
 		return
 	}
 
-	if len(b.handlers) > 0 {
-		for k := range b.handlers {
-			b.handlers[k] = nil
+	if len(b.entries) > 0 {
+		for k := range b.entries {
+			b.entries[k] = nil
+
+			delete(b.entries, k)
+		}
+	}
+
+	b.entries = make(map[string]*batchEntry)
+}
+
+// order is a private method of Batch that sorts the identifiers of the batch
+// so that every Go routine comes after its prerequisites.
+//
+// Returns:
+//   - []string: The sorted identifiers.
+//   - error: An error if a prerequisite does not exist or if there is a cycle.
+func (b *Batch) order() ([]string, error) {
+	keys := make([]string, 0, len(b.entries))
+
+	for k := range b.entries {
+		keys = append(keys, k)
+	}
+
+	slices.Sort(keys)
+
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+
+	marks := make(map[string]int, len(keys))
+	order := make([]string, 0, len(keys))
+
+	var visit func(k string) error
+
+	visit = func(k string) error {
+		switch marks[k] {
+		case visited:
+			return nil
+		case visiting:
+			return fmt.Errorf("dependency cycle involving %q", k)
+		}
+
+		marks[k] = visiting
+
+		for _, dep := range b.entries[k].deps {
+			_, ok := b.entries[dep]
+			if !ok {
+				return gcers.NewErrNoSuchKey(dep)
+			}
+
+			err := visit(dep)
+			if err != nil {
+				return err
+			}
+		}
+
+		marks[k] = visited
+		order = append(order, k)
 
-			delete(b.handlers, k)
+		return nil
+	}
+
+	for _, k := range keys {
+		err := visit(k)
+		if err != nil {
+			return nil, err
 		}
 	}
 
-	b.handlers = make(map[string]*HandlerSimple)
+	return order, nil
 }
 
 // StartAll is a function that starts all Go routines in the batch.
 //
+// Go routines with prerequisites are started only once all of their
+// prerequisites have finished. If a limit was set with SetMaxConcurrent, Go
+// routines also wait for a free slot before being started.
+//
+// Returns:
+//   - error: An error if a prerequisite does not exist or if the prerequisites
+//     form a cycle. In that case, no Go routine is started.
+func (b *Batch) StartAll() error {
+	if b == nil || len(b.entries) == 0 {
+		return nil
+	}
+
+	order, err := b.order()
+	if err != nil {
+		return err
+	}
+
+	b.outcome.Set(batchOutcome{})
+
+	dones := make(map[string]chan struct{}, len(b.entries))
+
+	for k, e := range b.entries {
+		dones[k] = e.reset()
+	}
+
+	if b.maxConcurrent > 0 {
+		b.sem = make(chan struct{}, b.maxConcurrent)
+	} else {
+		b.sem = nil
+	}
+
+	for _, k := range order {
+		go b.launch(k, b.entries[k], dones[k], b.sem)
+	}
+
+	return nil
+}
+
+// launch is a private method of Batch that waits for the prerequisites of an
+// entry, runs it and collects its errors.
+//
 // Parameters:
-//   - batch: A slice of pointers to the GRHandler instances that handle the Go routines.
-func (b *Batch) StartAll() {
-	if b == nil || len(b.handlers) == 0 {
+//   - id: The identifier of the entry.
+//   - e: The entry to launch.
+//   - done: The channel to close once the entry has finished.
+//   - sem: The semaphore that limits concurrency. Nil if there is no limit.
+func (b *Batch) launch(id string, e *batchEntry, done chan struct{}, sem chan struct{}) {
+	defer close(done)
+	defer e.finish()
+	defer b.notify(id, RoutineFinished)
+
+	for _, dep := range e.deps {
+		err := b.entries[dep].wait().Err
+
+		if err != nil && err != NoError {
+			dep_err := NewErrDependency(dep, err)
+
+			e.setErr(dep_err)
+			b.fail(id, dep_err)
+			b.notify(id, RoutineErrored)
+
+			return
+		}
+	}
+
+	if sem != nil {
+		sem <- struct{}{}
+		defer func() { <-sem }()
+	}
+
+	e.mu.Lock()
+
+	if e.stopped {
+		e.mu.Unlock()
 		return
 	}
 
-	for _, h := range b.handlers {
-		h.Start()
+	e.start = time.Now()
+
+	e.handler.Start()
+
+	e.mu.Unlock()
+
+	b.notify(id, RoutineStarted)
+
+	for {
+		err, ok := e.handler.ReceiveErr()
+		if !ok {
+			return
+		}
+
+		e.setErr(err)
+
+		if err != nil && err != NoError {
+			b.fail(id, err)
+			b.notify(id, RoutineErrored)
+		}
 	}
 }
 
-// WaitAll is a function that waits for all Go routines in the batch to finish
-// and returns a slice of errors that represent the error statuses of the Go routines.
+// Stop is a method of Batch that stops a single Go routine of the batch
+// without affecting the others. If the Go routine has not been started yet,
+// it will not be started.
 //
 // Parameters:
-//   - batch: A slice of pointers to the GRHandler instances that handle the Go routines.
+//   - identifier: The identifier of the Go routine.
+//
+// Returns:
+//   - bool: True if the Go routine exists, false otherwise.
+func (b *Batch) Stop(identifier string) bool {
+	if b == nil {
+		return false
+	}
+
+	e, ok := b.entries[identifier]
+	if !ok {
+		return false
+	}
+
+	e.stop()
+
+	return true
+}
+
+// Restart is a method of Batch that stops a single Go routine of the batch,
+// waits for it to finish and starts it again. WaitAll reports the result of
+// the new run.
+//
+// Parameters:
+//   - identifier: The identifier of the Go routine.
+//
+// Returns:
+//   - bool: True if the Go routine exists and the batch was started, false otherwise.
+func (b *Batch) Restart(identifier string) bool {
+	if b == nil {
+		return false
+	}
+
+	e, ok := b.entries[identifier]
+	if !ok {
+		return false
+	}
+
+	// Swap the done channel first so that WaitAll waits for the new run.
+	done := make(chan struct{})
+
+	e.mu.Lock()
+	prev := e.done
+	if prev != nil {
+		e.done = done
+	}
+	e.mu.Unlock()
+
+	if prev == nil {
+		return false
+	}
+
+	e.stop()
+
+	<-prev
+
+	e.mu.Lock()
+	e.err = nil
+	e.stopped = false
+	e.start = time.Time{}
+	e.end = time.Time{}
+	e.mu.Unlock()
+
+	// The batch is running again, and the failure of the previous run of the
+	// Go routine is no longer current.
+	b.outcome.Modify(func(o batchOutcome) batchOutcome {
+		o.finished = false
+
+		if o.id == identifier {
+			o.id = ""
+			o.err = nil
+		}
+
+		return o
+	})
+
+	go b.launch(identifier, e, done, b.sem)
+
+	return true
+}
+
+// WaitAll is a function that waits for all Go routines in the batch to finish
+// and returns their results.
 //
 // Returns:
-//   - map[string]error: A map of the error statuses of the Go routines.
-func (b *Batch) WaitAll() map[string]error {
-	if b == nil || len(b.handlers) == 0 {
+//   - map[string]RoutineResult: A map of the results of the Go routines, which
+//     carry their error statuses along with their timings.
+func (b *Batch) WaitAll() map[string]RoutineResult {
+	if b == nil || len(b.entries) == 0 {
 		return nil
 	}
 
-	errMap := rws.NewSafeMap[string, error]()
+	resMap := make(map[string]RoutineResult, len(b.entries))
 
-	for k := range b.handlers {
-		errMap.Set(k, nil)
+	for k, e := range b.entries {
+		resMap[k] = e.wait()
+	}
+
+	return resMap
+}
+
+// StopAll is a method of Batch that stops every Go routine of the batch. Go
+// routines that have not been started yet will not be started.
+func (b *Batch) StopAll() {
+	if b == nil {
+		return
 	}
 
 	var wg sync.WaitGroup
 
-	wg.Add(len(b.handlers))
+	wg.Add(len(b.entries))
 
-	for k, h := range b.handlers {
-		go func(k string, h *HandlerSimple) {
+	for _, e := range b.entries {
+		go func(e *batchEntry) {
 			defer wg.Done()
 
-			for {
-				err, ok := h.ReceiveErr()
-				if !ok {
-					return
-				}
+			e.stop()
+		}(e)
+	}
 
-				errMap.Set(k, err)
+	wg.Wait()
+}
 
-				if err != nil {
-					return
-				}
-			}
-		}(k, h)
+// WaitAllCtx is like WaitAll but it stops waiting when the context is done.
+//
+// Parameters:
+//   - ctx: The context that bounds the wait.
+//
+// Returns:
+//   - map[string]RoutineResult: A map of the results of the Go routines that finished.
+//   - []string: The sorted identifiers of the Go routines that were still running
+//     when the context was done. Nil if all of them finished.
+func (b *Batch) WaitAllCtx(ctx context.Context) (map[string]RoutineResult, []string) {
+	if b == nil || len(b.entries) == 0 {
+		return nil, nil
 	}
 
-	wg.Wait()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	resMap := make(map[string]RoutineResult, len(b.entries))
+	var running []string
+
+	for k, e := range b.entries {
+		res, ok := e.waitCtx(ctx)
+		if ok {
+			resMap[k] = res
+		} else {
+			running = append(running, k)
+		}
+	}
+
+	slices.Sort(running)
+
+	return resMap, running
+}
+
+// fail is a private method of Batch that records a failure if it is the first one.
+//
+// Parameters:
+//   - id: The identifier of the Go routine that failed.
+//   - err: The error of the Go routine.
+func (b *Batch) fail(id string, err error) {
+	b.outcome.Modify(func(o batchOutcome) batchOutcome {
+		if o.err == nil {
+			o.id = id
+			o.err = err
+		}
+
+		return o
+	})
+}
+
+// WaitFirstError is a method of Batch that waits until a Go routine reports
+// an error (other than NoError) or until all of them have finished.
+//
+// Parameters:
+//   - cancelRest: Whether to stop all the Go routines once one of them fails.
+//
+// Returns:
+//   - string: The identifier of the Go routine that failed first. Empty if none failed.
+//   - error: The error of the Go routine that failed first. Nil if none failed.
+func (b *Batch) WaitFirstError(cancelRest bool) (string, error) {
+	if b == nil || len(b.entries) == 0 {
+		return "", nil
+	}
+
+	b.outcome.Modify(func(o batchOutcome) batchOutcome {
+		o.finished = false
+		return o
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go func() {
+		_, running := b.WaitAllCtx(ctx)
+		if running != nil {
+			return
+		}
+
+		b.outcome.Modify(func(o batchOutcome) batchOutcome {
+			o.finished = true
+			return o
+		})
+	}()
+
+	o := b.outcome.Wait(func(o batchOutcome) bool {
+		return o.err != nil || o.finished
+	})
+
+	if o.err != nil && cancelRest {
+		b.StopAll()
+	}
+
+	return o.id, o.err
+}
+
+// silentRunner is an ErrRunner that wraps a Runner that does not report
+// errors. ReceiveErr blocks until the runner is closed.
+type silentRunner struct {
+	Runner
+
+	// done is closed when the runner is closed. Nil if it was never started.
+	done chan struct{}
+
+	// mu protects done.
+	mu sync.Mutex
+}
+
+// Start implements the Runner interface.
+func (r *silentRunner) Start() {
+	r.mu.Lock()
+
+	if r.done == nil {
+		r.done = make(chan struct{})
+	} else {
+		select {
+		case <-r.done:
+			r.done = make(chan struct{})
+		default:
+		}
+	}
+
+	r.mu.Unlock()
+
+	r.Runner.Start()
+}
+
+// Close implements the Runner interface.
+func (r *silentRunner) Close() {
+	r.Runner.Close()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.done == nil {
+		return
+	}
+
+	select {
+	case <-r.done:
+	default:
+		close(r.done)
+	}
+}
+
+// ReceiveErr implements the ErrReceiver interface.
+//
+// It never reports an error; it only waits for the runner to be closed.
+func (r *silentRunner) ReceiveErr() (error, bool) {
+	r.mu.Lock()
+	done := r.done
+	r.mu.Unlock()
+
+	if done != nil {
+		<-done
+	}
 
-	m := errMap.GetMap()
-	return m
+	return nil, false
 }
@@ -0,0 +1,317 @@
+package runner
+
+import (
+	"sync"
+	"time"
+
+	sbj "github.com/PlayerR9/safe/subject"
+)
+
+// BreakerState is the state of a CircuitBreaker.
+type BreakerState int
+
+const (
+	// BreakerClosed lets every message through.
+	BreakerClosed BreakerState = iota
+
+	// BreakerOpen rejects every message.
+	BreakerOpen
+
+	// BreakerHalfOpen lets a single trial message through to decide whether
+	// the breaker closes again or reopens.
+	BreakerHalfOpen
+)
+
+// String implements the fmt.Stringer interface.
+func (s BreakerState) String() string {
+	switch s {
+	case BreakerClosed:
+		return "closed"
+	case BreakerOpen:
+		return "open"
+	case BreakerHalfOpen:
+		return "half-open"
+	default:
+		return "unknown"
+	}
+}
+
+// CircuitBreaker is a handler that stops accepting messages after its routine
+// fails several times in a row, giving the downstream dependency time to
+// recover.
+//
+// After threshold consecutive errors the breaker opens and Send returns false.
+// Once the cooldown elapses, the breaker half-opens and lets one message
+// through: if it succeeds the breaker closes, otherwise it opens again.
+type CircuitBreaker[T any] struct {
+	// handler is the handler that runs the Go routine.
+	handler *HandlerSend[T]
+
+	// threshold is the number of consecutive errors that opens the breaker.
+	threshold int
+
+	// cooldown is the time the breaker stays open.
+	cooldown time.Duration
+
+	// state is the current state of the breaker.
+	state BreakerState
+
+	// failures is the number of consecutive errors.
+	failures int
+
+	// openedAt is the time the breaker last opened.
+	openedAt time.Time
+
+	// trial is true while the trial message of the half-open state is in flight.
+	trial bool
+
+	// mu protects state, failures, openedAt and trial.
+	mu sync.Mutex
+
+	// subject notifies the state changes.
+	subject *sbj.Subject[BreakerState]
+
+	// notifyMu serializes the notifications of subject.
+	notifyMu sync.Mutex
+}
+
+// NewCircuitBreaker creates a new CircuitBreaker.
+//
+// Parameters:
+//   - routine: The Go routine to run.
+//   - threshold: The number of consecutive errors that opens the breaker.
+//     Values less than 1 are treated as 1.
+//   - cooldown: The time the breaker stays open before half-opening.
+//
+// Returns:
+//   - *CircuitBreaker: A pointer to the CircuitBreaker. Nil if routine is nil.
+//   - bool: True if the CircuitBreaker was created successfully, false otherwise.
+func NewCircuitBreaker[T any](routine func(T) error, threshold int, cooldown time.Duration) (*CircuitBreaker[T], bool) {
+	if routine == nil {
+		return nil, false
+	}
+
+	cb := &CircuitBreaker[T]{
+		threshold: max(threshold, 1),
+		cooldown:  cooldown,
+		subject:   sbj.NewSubject(BreakerClosed),
+	}
+
+	cb.handler, _ = NewHandlerSend(func(msg T) error {
+		ok := false
+
+		// A panic counts as a failure, so that a trial message never stays in
+		// flight forever.
+		defer func() {
+			cb.record(ok)
+		}()
+
+		err := routine(msg)
+
+		ok = err == nil || err == NoError
+
+		return err
+	})
+
+	return cb, true
+}
+
+// ObserveBreakerState is a method of CircuitBreaker that registers a function
+// that is called on every state change of the breaker.
+//
+// Parameters:
+//   - fn: The function to call with the new state.
+//
+// If 'fn' or the receiver are nil, then nothing is done.
+func (cb *CircuitBreaker[T]) ObserveBreakerState(fn func(BreakerState)) {
+	if cb == nil || fn == nil {
+		return
+	}
+
+	cb.subject.SetObserver(fn)
+}
+
+// BreakerState is a method of CircuitBreaker that returns the current state
+// of the breaker.
+//
+// Returns:
+//   - BreakerState: The current state.
+func (cb *CircuitBreaker[T]) BreakerState() BreakerState {
+	if cb == nil {
+		return BreakerOpen
+	}
+
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	return cb.state
+}
+
+// transition is a private method of CircuitBreaker that changes the state and
+// notifies the observers. The caller must hold mu; it is released by this
+// method.
+//
+// Parameters:
+//   - state: The new state.
+func (cb *CircuitBreaker[T]) transition(state BreakerState) {
+	if cb.state == state {
+		cb.mu.Unlock()
+		return
+	}
+
+	cb.state = state
+
+	if state == BreakerOpen {
+		cb.openedAt = time.Now()
+	}
+
+	cb.notifyMu.Lock()
+	defer cb.notifyMu.Unlock()
+
+	cb.mu.Unlock()
+
+	cb.subject.Set(state)
+}
+
+// record is a private method of CircuitBreaker that updates the state with
+// the outcome of a routine invocation.
+//
+// Parameters:
+//   - ok: Whether the invocation succeeded.
+func (cb *CircuitBreaker[T]) record(ok bool) {
+	cb.mu.Lock()
+
+	cb.trial = false
+
+	if ok {
+		cb.failures = 0
+
+		cb.transition(BreakerClosed)
+
+		return
+	}
+
+	cb.failures++
+
+	if cb.state == BreakerHalfOpen || cb.failures >= cb.threshold {
+		cb.transition(BreakerOpen)
+	} else {
+		cb.mu.Unlock()
+	}
+}
+
+// Send implements the Sender interface.
+//
+// Returns false if the breaker is open or if the trial message of the
+// half-open state is already in flight.
+func (cb *CircuitBreaker[T]) Send(msg T) bool {
+	if cb == nil {
+		return false
+	}
+
+	cb.mu.Lock()
+
+	if cb.state == BreakerOpen && time.Since(cb.openedAt) >= cb.cooldown {
+		cb.transition(BreakerHalfOpen)
+		cb.mu.Lock()
+	}
+
+	switch {
+	case cb.state == BreakerOpen:
+		cb.mu.Unlock()
+		return false
+	case cb.state == BreakerHalfOpen && cb.trial:
+		cb.mu.Unlock()
+		return false
+	case cb.state == BreakerHalfOpen:
+		cb.trial = true
+	}
+
+	cb.mu.Unlock()
+
+	ok := cb.handler.Send(msg)
+	if !ok {
+		cb.mu.Lock()
+		cb.trial = false
+		cb.mu.Unlock()
+	}
+
+	return ok
+}
+
+// Start implements the Runner interface.
+func (cb *CircuitBreaker[T]) Start() {
+	if cb == nil {
+		return
+	}
+
+	cb.handler.Start()
+}
+
+// Close implements the Runner interface.
+func (cb *CircuitBreaker[T]) Close() {
+	if cb == nil {
+		return
+	}
+
+	cb.handler.Close()
+}
+
+// IsClosed implements the Runner interface.
+func (cb *CircuitBreaker[T]) IsClosed() bool {
+	return cb == nil || cb.handler.IsClosed()
+}
+
+// State implements the StateProvider interface.
+//
+// It is the lifecycle state of the handler; see BreakerState for the state of
+// the breaker itself.
+func (cb *CircuitBreaker[T]) State() RunnerState {
+	if cb == nil {
+		return Idle
+	}
+
+	return cb.handler.State()
+}
+
+// ObserveState implements the StateProvider interface.
+func (cb *CircuitBreaker[T]) ObserveState(fn func(RunnerState)) {
+	if cb == nil {
+		return
+	}
+
+	cb.handler.ObserveState(fn)
+}
+
+// Healthy implements the HealthChecker interface.
+//
+// An open breaker is reported as unhealthy.
+func (cb *CircuitBreaker[T]) Healthy() error {
+	if cb.IsClosed() {
+		return NotRunning
+	}
+
+	if cb.BreakerState() == BreakerOpen {
+		return NewErrBreakerOpen()
+	}
+
+	return nil
+}
+
+// Stats implements the StatsProvider interface.
+func (cb *CircuitBreaker[T]) Stats() Stats {
+	if cb == nil {
+		return Stats{}
+	}
+
+	return cb.handler.Stats()
+}
+
+// ReceiveErr implements the ErrReceiver interface.
+func (cb *CircuitBreaker[T]) ReceiveErr() (error, bool) {
+	if cb == nil {
+		return nil, false
+	}
+
+	return cb.handler.ReceiveErr()
+}
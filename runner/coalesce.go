@@ -0,0 +1,239 @@
+package runner
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Coalescer is a runner that runs a function in the background when it is
+// triggered, running it at most once per window no matter how many triggers
+// it receives.
+//
+// The first trigger opens a window; once the window elapses, the function is
+// run once for all the triggers received in the meantime. Triggers received
+// while the function is running open a new window.
+type Coalescer struct {
+	// fn is the function to run.
+	fn func() error
+
+	// window is the time the triggers are coalesced for.
+	window time.Duration
+
+	// trigger holds the pending trigger, if any.
+	trigger chan struct{}
+
+	// errChan is the channel the errors of fn are sent to.
+	errChan chan error
+
+	// ctx is the context of the coalescer.
+	ctx context.Context
+
+	// cancel is the cancel function of the coalescer.
+	cancel context.CancelFunc
+
+	// wg is a WaitGroup that is used to wait for the Go routine to finish.
+	wg sync.WaitGroup
+
+	// mu protects trigger, errChan, ctx and cancel.
+	mu sync.Mutex
+
+	// state is the lifecycle state of the coalescer.
+	state lifecycle
+}
+
+// Coalesce creates a new Coalescer.
+//
+// Parameters:
+//   - f: The function to run.
+//   - window: The time the triggers are coalesced for. Negative values are
+//     treated as 0.
+//
+// Returns:
+//   - *Coalescer: The new Coalescer. Nil if f is nil.
+//
+// The Coalescer is not started automatically. Triggers are sent with Send.
+func Coalesce(f func() error, window time.Duration) *Coalescer {
+	if f == nil {
+		return nil
+	}
+
+	return &Coalescer{
+		fn:     f,
+		window: max(window, 0),
+	}
+}
+
+// Start implements the Runner interface.
+func (c *Coalescer) Start() {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.ctx != nil && c.ctx.Err() == nil {
+		return
+	}
+
+	c.state.set(Starting)
+
+	c.trigger = make(chan struct{}, 1)
+	c.errChan = make(chan error)
+	c.ctx, c.cancel = context.WithCancel(context.Background())
+
+	c.wg.Add(1)
+
+	go c.loop(c.ctx, c.trigger, c.errChan)
+
+	c.state.set(Running)
+}
+
+// Close implements the Runner interface.
+//
+// A pending trigger is dropped, but a running invocation of the function is
+// waited for.
+func (c *Coalescer) Close() {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+
+	if c.ctx == nil || c.ctx.Err() != nil {
+		c.mu.Unlock()
+		return
+	}
+
+	c.state.set(Stopping)
+
+	c.cancel()
+
+	errChan := c.errChan
+
+	c.mu.Unlock()
+
+	c.wg.Wait()
+
+	close(errChan)
+
+	c.state.set(Stopped)
+}
+
+// IsClosed implements the Runner interface.
+func (c *Coalescer) IsClosed() bool {
+	if c == nil {
+		return true
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.ctx == nil || c.ctx.Err() != nil
+}
+
+// State implements the StateProvider interface.
+func (c *Coalescer) State() RunnerState {
+	if c == nil {
+		return Idle
+	}
+
+	return c.state.get()
+}
+
+// ObserveState implements the StateProvider interface.
+func (c *Coalescer) ObserveState(fn func(RunnerState)) {
+	if c == nil {
+		return
+	}
+
+	c.state.observe(fn)
+}
+
+// Send implements the Sender interface.
+//
+// It never blocks: if a trigger is already pending, the new one is merged
+// into it.
+func (c *Coalescer) Send(_ struct{}) bool {
+	if c == nil {
+		return false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.ctx == nil || c.ctx.Err() != nil {
+		return false
+	}
+
+	select {
+	case c.trigger <- struct{}{}:
+	default:
+	}
+
+	return true
+}
+
+// ReceiveErr implements the ErrReceiver interface.
+func (c *Coalescer) ReceiveErr() (error, bool) {
+	if c == nil {
+		return nil, false
+	}
+
+	c.mu.Lock()
+	errChan := c.errChan
+	c.mu.Unlock()
+
+	if errChan == nil {
+		return nil, false
+	}
+
+	err, ok := <-errChan
+	return err, ok
+}
+
+// loop is a private method of Coalescer that runs the function once per
+// window of triggers until the context is done.
+//
+// Parameters:
+//   - ctx: The context of the coalescer.
+//   - trigger: The channel the triggers are received from.
+//   - errChan: The channel the errors are sent to.
+func (c *Coalescer) loop(ctx context.Context, trigger <-chan struct{}, errChan chan<- error) {
+	defer c.wg.Done()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-trigger:
+		}
+
+		timer := time.NewTimer(c.window)
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		// Triggers received during the window are covered by this run.
+		select {
+		case <-trigger:
+		default:
+		}
+
+		err := runJob(c.fn)
+		if err == nil || err == NoError {
+			continue
+		}
+
+		select {
+		case errChan <- err:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
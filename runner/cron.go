@@ -0,0 +1,223 @@
+package runner
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// schedule is the interface that computes the activation times of a job.
+type schedule interface {
+	// next returns the first activation time strictly after t.
+	//
+	// Parameters:
+	//   - t: The reference time.
+	//
+	// Returns:
+	//   - time.Time: The next activation time. Zero if there is none.
+	next(t time.Time) time.Time
+}
+
+// intervalSchedule is a schedule that activates at fixed intervals.
+type intervalSchedule time.Duration
+
+// next implements the schedule interface.
+func (s intervalSchedule) next(t time.Time) time.Time {
+	return t.Add(time.Duration(s))
+}
+
+// cronSchedule is a schedule that follows a cron expression. Each field is a
+// bit set of the allowed values.
+type cronSchedule struct {
+	// minute, hour, dom, month and dow are the allowed minutes, hours, days of
+	// the month, months and days of the week, respectively.
+	minute, hour, dom, month, dow uint64
+
+	// domAny and dowAny are true if the day of the month and day of the week
+	// fields start with '*', such as "*" or "*/2", respectively. As in cron,
+	// such fields do not restrict the days on their own.
+	domAny, dowAny bool
+}
+
+// cronMaxIterations bounds the search of the next activation time so that
+// expressions that never match (e.g., February 30th) do not loop forever.
+const cronMaxIterations = 100000
+
+// parseCron parses a cron expression.
+//
+// The expression is made of five space-separated fields: minute (0-59),
+// hour (0-23), day of the month (1-31), month (1-12) and day of the week
+// (0-6, Sunday is 0 or 7). Each field accepts '*', single values, ranges
+// ("a-b"), steps ("*/n" or "a-b/n") and comma-separated lists thereof.
+// When neither day field starts with '*', a day matches if either of them
+// does.
+//
+// Parameters:
+//   - expr: The cron expression.
+//
+// Returns:
+//   - *cronSchedule: The schedule. Nil if an error occurred.
+//   - error: An error if the expression is invalid.
+func parseCron(expr string) (*cronSchedule, error) {
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("expected 5 fields, got %d", len(fields))
+	}
+
+	bounds := [5][2]int{{0, 59}, {0, 23}, {1, 31}, {1, 12}, {0, 7}}
+	names := [5]string{"minute", "hour", "day of month", "month", "day of week"}
+
+	var sets [5]uint64
+
+	for i, field := range fields {
+		set, err := parseCronField(field, bounds[i][0], bounds[i][1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s field %q: %w", names[i], field, err)
+		}
+
+		sets[i] = set
+	}
+
+	// Sunday can be written as 7.
+	if sets[4]&(1<<7) != 0 {
+		sets[4] = (sets[4] | 1) &^ (1 << 7)
+	}
+
+	return &cronSchedule{
+		minute: sets[0],
+		hour:   sets[1],
+		dom:    sets[2],
+		month:  sets[3],
+		dow:    sets[4],
+		domAny: strings.HasPrefix(fields[2], "*"),
+		dowAny: strings.HasPrefix(fields[4], "*"),
+	}, nil
+}
+
+// parseCronField parses a single field of a cron expression.
+//
+// Parameters:
+//   - field: The field to parse.
+//   - lo: The lowest allowed value.
+//   - hi: The highest allowed value.
+//
+// Returns:
+//   - uint64: The bit set of the allowed values.
+//   - error: An error if the field is invalid.
+func parseCronField(field string, lo, hi int) (uint64, error) {
+	var set uint64
+
+	for _, part := range strings.Split(field, ",") {
+		rng, stepStr, hasStep := strings.Cut(part, "/")
+
+		step := 1
+
+		if hasStep {
+			n, err := strconv.Atoi(stepStr)
+			if err != nil || n < 1 {
+				return 0, fmt.Errorf("invalid step %q", stepStr)
+			}
+
+			step = n
+		}
+
+		start, end := lo, hi
+
+		switch {
+		case rng == "*":
+			// Full range.
+		case strings.Contains(rng, "-"):
+			a, b, _ := strings.Cut(rng, "-")
+
+			var err error
+
+			start, err = strconv.Atoi(a)
+			if err != nil {
+				return 0, fmt.Errorf("invalid value %q", a)
+			}
+
+			end, err = strconv.Atoi(b)
+			if err != nil {
+				return 0, fmt.Errorf("invalid value %q", b)
+			}
+		default:
+			n, err := strconv.Atoi(rng)
+			if err != nil {
+				return 0, fmt.Errorf("invalid value %q", rng)
+			}
+
+			start = n
+
+			if hasStep {
+				end = hi
+			} else {
+				end = n
+			}
+		}
+
+		if start < lo || end > hi || start > end {
+			return 0, fmt.Errorf("range %d-%d is not within %d-%d", start, end, lo, hi)
+		}
+
+		for v := start; v <= end; v += step {
+			set |= 1 << uint(v)
+		}
+	}
+
+	return set, nil
+}
+
+// has checks whether a value is in a bit set.
+func has(set uint64, v int) bool {
+	return set&(1<<uint(v)) != 0
+}
+
+// matchDay checks whether the day of t matches the schedule.
+//
+// Parameters:
+//   - t: The time to check.
+//
+// Returns:
+//   - bool: True if the day matches, false otherwise.
+func (s *cronSchedule) matchDay(t time.Time) bool {
+	dom := has(s.dom, t.Day())
+	dow := has(s.dow, int(t.Weekday()))
+
+	if s.domAny || s.dowAny {
+		return dom && dow
+	}
+
+	return dom || dow
+}
+
+// next implements the schedule interface.
+func (s *cronSchedule) next(t time.Time) time.Time {
+	t = t.Truncate(time.Minute).Add(time.Minute)
+
+	for i := 0; i < cronMaxIterations; i++ {
+		if !has(s.month, int(t.Month())) {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+
+		if !s.matchDay(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+
+		if !has(s.hour, t.Hour()) {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+			continue
+		}
+
+		if !has(s.minute, t.Minute()) {
+			t = t.Add(time.Minute)
+			continue
+		}
+
+		return t
+	}
+
+	return time.Time{}
+}
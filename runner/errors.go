@@ -1,6 +1,9 @@
 package runner
 
-import "fmt"
+import (
+	"fmt"
+	"time"
+)
 
 // ErrPanic represents an error when a panic occurs.
 type ErrPanic struct {
@@ -27,3 +30,224 @@ func NewErrPanic(value any) *ErrPanic {
 		Value: value,
 	}
 }
+
+// ErrDependency represents an error when a prerequisite of a Go routine
+// failed and, as a result, the Go routine was never started.
+type ErrDependency struct {
+	// Dependency is the identifier of the prerequisite that failed.
+	Dependency string
+
+	// Reason is the error the prerequisite failed with.
+	Reason error
+}
+
+// Error implements the error interface.
+//
+// Message: "dependency {dependency} failed: {reason}"
+func (e ErrDependency) Error() string {
+	return fmt.Sprintf("dependency %q failed: %v", e.Dependency, e.Reason)
+}
+
+// Unwrap returns the error the prerequisite failed with.
+//
+// Returns:
+//   - error: The reason of the failure.
+func (e ErrDependency) Unwrap() error {
+	return e.Reason
+}
+
+// NewErrDependency creates a new ErrDependency error.
+//
+// Parameters:
+//   - dependency: The identifier of the prerequisite that failed.
+//   - reason: The error the prerequisite failed with.
+//
+// Returns:
+//   - *ErrDependency: A pointer to the newly created ErrDependency. Never returns nil.
+func NewErrDependency(dependency string, reason error) *ErrDependency {
+	return &ErrDependency{
+		Dependency: dependency,
+		Reason:     reason,
+	}
+}
+
+// ErrJob represents an error reported by a job of a Scheduler.
+type ErrJob struct {
+	// Name is the name of the job.
+	Name string
+
+	// Reason is the error the job failed with.
+	Reason error
+}
+
+// Error implements the error interface.
+//
+// Message: "job {name} failed: {reason}"
+func (e ErrJob) Error() string {
+	return fmt.Sprintf("job %q failed: %v", e.Name, e.Reason)
+}
+
+// Unwrap returns the error the job failed with.
+//
+// Returns:
+//   - error: The reason of the failure.
+func (e ErrJob) Unwrap() error {
+	return e.Reason
+}
+
+// NewErrJob creates a new ErrJob error.
+//
+// Parameters:
+//   - name: The name of the job.
+//   - reason: The error the job failed with.
+//
+// Returns:
+//   - *ErrJob: A pointer to the newly created ErrJob. Never returns nil.
+func NewErrJob(name string, reason error) *ErrJob {
+	return &ErrJob{
+		Name:   name,
+		Reason: reason,
+	}
+}
+
+// ErrShutdownTimeout represents an error when the runners could not be closed
+// within the grace period.
+type ErrShutdownTimeout struct {
+	// Pending is the name of the runner that was still closing.
+	Pending string
+}
+
+// Error implements the error interface.
+//
+// Message: "shutdown timed out while closing {pending}"
+func (e ErrShutdownTimeout) Error() string {
+	return fmt.Sprintf("shutdown timed out while closing %q", e.Pending)
+}
+
+// NewErrShutdownTimeout creates a new ErrShutdownTimeout error.
+//
+// Parameters:
+//   - pending: The name of the runner that was still closing.
+//
+// Returns:
+//   - *ErrShutdownTimeout: A pointer to the newly created ErrShutdownTimeout. Never returns nil.
+func NewErrShutdownTimeout(pending string) *ErrShutdownTimeout {
+	return &ErrShutdownTimeout{
+		Pending: pending,
+	}
+}
+
+// ErrRetriesExceeded represents an error when a routine kept failing after
+// all of its retries.
+type ErrRetriesExceeded struct {
+	// Attempts is the number of attempts made.
+	Attempts int
+
+	// Reason is the error of the last attempt.
+	Reason error
+}
+
+// Error implements the error interface.
+//
+// Message: "retries exceeded after {attempts} attempts: {reason}"
+func (e ErrRetriesExceeded) Error() string {
+	return fmt.Sprintf("retries exceeded after %d attempts: %v", e.Attempts, e.Reason)
+}
+
+// Unwrap returns the error of the last attempt.
+//
+// Returns:
+//   - error: The reason of the failure.
+func (e ErrRetriesExceeded) Unwrap() error {
+	return e.Reason
+}
+
+// NewErrRetriesExceeded creates a new ErrRetriesExceeded error.
+//
+// Parameters:
+//   - attempts: The number of attempts made.
+//   - reason: The error of the last attempt.
+//
+// Returns:
+//   - *ErrRetriesExceeded: A pointer to the newly created ErrRetriesExceeded. Never returns nil.
+func NewErrRetriesExceeded(attempts int, reason error) *ErrRetriesExceeded {
+	return &ErrRetriesExceeded{
+		Attempts: attempts,
+		Reason:   reason,
+	}
+}
+
+// ErrBreakerOpen represents an error when a circuit breaker is open.
+type ErrBreakerOpen struct{}
+
+// Error implements the error interface.
+//
+// Message: "circuit breaker is open"
+func (e ErrBreakerOpen) Error() string {
+	return "circuit breaker is open"
+}
+
+// NewErrBreakerOpen creates a new ErrBreakerOpen error.
+//
+// Returns:
+//   - *ErrBreakerOpen: A pointer to the newly created ErrBreakerOpen. Never returns nil.
+func NewErrBreakerOpen() *ErrBreakerOpen {
+	return &ErrBreakerOpen{}
+}
+
+// ErrTimeout represents an error when a routine did not return in time.
+type ErrTimeout struct {
+	// Timeout is the timeout that was exceeded.
+	Timeout time.Duration
+}
+
+// Error implements the error interface.
+//
+// Message: "routine timed out after {timeout}"
+func (e ErrTimeout) Error() string {
+	return fmt.Sprintf("routine timed out after %v", e.Timeout)
+}
+
+// NewErrTimeout creates a new ErrTimeout error.
+//
+// Parameters:
+//   - timeout: The timeout that was exceeded.
+//
+// Returns:
+//   - *ErrTimeout: A pointer to the newly created ErrTimeout. Never returns nil.
+func NewErrTimeout(timeout time.Duration) *ErrTimeout {
+	return &ErrTimeout{
+		Timeout: timeout,
+	}
+}
+
+// ErrStalled represents an error when a watched runner missed its heartbeat.
+type ErrStalled struct {
+	// Name is the name of the runner.
+	Name string
+
+	// Since is the time elapsed since the last heartbeat.
+	Since time.Duration
+}
+
+// Error implements the error interface.
+//
+// Message: "{name} stalled: no heartbeat for {since}"
+func (e ErrStalled) Error() string {
+	return fmt.Sprintf("%q stalled: no heartbeat for %v", e.Name, e.Since)
+}
+
+// NewErrStalled creates a new ErrStalled error.
+//
+// Parameters:
+//   - name: The name of the runner.
+//   - since: The time elapsed since the last heartbeat.
+//
+// Returns:
+//   - *ErrStalled: A pointer to the newly created ErrStalled. Never returns nil.
+func NewErrStalled(name string, since time.Duration) *ErrStalled {
+	return &ErrStalled{
+		Name:  name,
+		Since: since,
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"iter"
 	"sync"
+
+	rws "github.com/PlayerR9/safe/rw_safe"
 )
 
 // Handler is a struct that represents a Go routine handler.
@@ -69,3 +71,260 @@ func ExecuteBatch[I, O any](ctx context.Context, elems iter.Seq[I], do_fn DoFunc
 
 	return handlers
 }
+
+// ExecuteBatchN is like ExecuteBatch but at most maxWorkers Go routines run at
+// the same time, regardless of the number of elements.
+//
+// Parameters:
+//   - ctx: The context of the batch.
+//   - elems: The elements to process.
+//   - do_fn: The function that defines the behavior of the Go routines.
+//   - maxWorkers: The maximum number of Go routines. If less than 1, it behaves
+//     like ExecuteBatch.
+//
+// Returns:
+//   - []Handler[O]: The results of the Go routines.
+func ExecuteBatchN[I, O any](ctx context.Context, elems iter.Seq[I], do_fn DoFunc[I, O], maxWorkers int) []Handler[O] {
+	if elems == nil || do_fn == nil {
+		return nil
+	} else if maxWorkers < 1 {
+		return ExecuteBatch(ctx, elems, do_fn)
+	}
+
+	var handlers []Handler[O]
+	var mu sync.Mutex
+
+	var wg sync.WaitGroup
+
+	elemCh := make(chan I)
+
+	wg.Add(maxWorkers)
+
+	for i := 0; i < maxWorkers; i++ {
+		go func() {
+			defer wg.Done()
+
+			for elem := range elemCh {
+				output, err := do_fn(ctx, elem)
+
+				h := Handler[O]{
+					Data: output,
+					Err:  err,
+				}
+
+				mu.Lock()
+				handlers = append(handlers, h)
+				mu.Unlock()
+			}
+		}()
+	}
+
+	for elem := range elems {
+		elemCh <- elem
+	}
+
+	close(elemCh)
+
+	wg.Wait()
+
+	return handlers
+}
+
+// WeightFunc is a function that tells the weight of an element, that is, the
+// amount of a shared resource (memory, file handles, ...) its processing uses.
+//
+// Parameters:
+//   - elem: The element.
+//
+// Returns:
+//   - int64: The weight of the element.
+type WeightFunc[I any] func(elem I) int64
+
+// ExecuteBatchWeighted is like ExecuteBatch but the total weight of the
+// elements being processed at the same time never exceeds capacity.
+//
+// Parameters:
+//   - ctx: The context of the batch.
+//   - elems: The elements to process.
+//   - do_fn: The function that defines the behavior of the Go routines.
+//   - weight_fn: The function that tells the weight of every element.
+//   - capacity: The maximum total weight in flight. If less than 1, it behaves
+//     like ExecuteBatch.
+//
+// Returns:
+//   - []Handler[O]: The results of the Go routines.
+//
+// Behaviors:
+//   - Elements are started in order; an element waits for enough weight to be
+//     released even if later, lighter elements would fit.
+//   - Negative weights are treated as 0 and weights above capacity are treated
+//     as capacity, so that such elements run alone.
+//   - If ctx is done while waiting for capacity, the remaining elements are
+//     not processed and are absent from the results.
+func ExecuteBatchWeighted[I, O any](ctx context.Context, elems iter.Seq[I], do_fn DoFunc[I, O], weight_fn WeightFunc[I], capacity int64) []Handler[O] {
+	if elems == nil || do_fn == nil {
+		return nil
+	} else if weight_fn == nil || capacity < 1 {
+		return ExecuteBatch(ctx, elems, do_fn)
+	}
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	var handlers []Handler[O]
+	var mu sync.Mutex
+
+	var wg sync.WaitGroup
+
+	// Only this Go routine acquires weight, so waiting and then adding is
+	// not racy: releases can only make room.
+	inFlight := rws.NewSafeCond[int64](0)
+
+	for elem := range elems {
+		w := min(max(weight_fn(elem), 0), capacity)
+
+		_, err := inFlight.WaitCtx(ctx, func(curr int64) bool {
+			return curr+w <= capacity
+		})
+		if err != nil {
+			break
+		}
+
+		inFlight.Modify(func(curr int64) int64 {
+			return curr + w
+		})
+
+		wg.Add(1)
+
+		go func(elem I, w int64) {
+			defer wg.Done()
+
+			defer inFlight.Modify(func(curr int64) int64 {
+				return curr - w
+			})
+
+			output, err := do_fn(ctx, elem)
+
+			h := Handler[O]{
+				Data: output,
+				Err:  err,
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			handlers = append(handlers, h)
+		}(elem, w)
+	}
+
+	wg.Wait()
+
+	return handlers
+}
+
+// ExecuteBatchStream is like ExecuteBatchN but, instead of waiting for the
+// whole batch, it yields the results as soon as they are available.
+//
+// Parameters:
+//   - ctx: The context of the batch.
+//   - elems: The elements to process.
+//   - do_fn: The function that defines the behavior of the Go routines.
+//   - maxWorkers: The maximum number of Go routines. If less than 1, one Go
+//     routine is spawned per element.
+//
+// Returns:
+//   - iter.Seq[Handler[O]]: An iterator over the results in order of completion.
+//     Never returns nil.
+//
+// The batch is only executed when the iterator is ranged over. Stopping the
+// iteration early stops feeding new elements; results of elements that were
+// already being processed are discarded.
+func ExecuteBatchStream[I, O any](ctx context.Context, elems iter.Seq[I], do_fn DoFunc[I, O], maxWorkers int) iter.Seq[Handler[O]] {
+	if elems == nil || do_fn == nil {
+		return func(yield func(Handler[O]) bool) {}
+	}
+
+	fn := func(yield func(Handler[O]) bool) {
+		results := make(chan Handler[O])
+		stop := make(chan struct{})
+
+		defer close(stop)
+
+		process := func(elem I) bool {
+			output, err := do_fn(ctx, elem)
+
+			h := Handler[O]{
+				Data: output,
+				Err:  err,
+			}
+
+			select {
+			case results <- h:
+				return true
+			case <-stop:
+				return false
+			}
+		}
+
+		go func() {
+			var wg sync.WaitGroup
+
+			defer close(results)
+			defer wg.Wait()
+
+			if maxWorkers < 1 {
+				for elem := range elems {
+					select {
+					case <-stop:
+						return
+					default:
+					}
+
+					wg.Add(1)
+
+					go func(elem I) {
+						defer wg.Done()
+
+						process(elem)
+					}(elem)
+				}
+
+				return
+			}
+
+			elemCh := make(chan I)
+			defer close(elemCh)
+
+			wg.Add(maxWorkers)
+
+			for i := 0; i < maxWorkers; i++ {
+				go func() {
+					defer wg.Done()
+
+					for elem := range elemCh {
+						if !process(elem) {
+							return
+						}
+					}
+				}()
+			}
+
+			for elem := range elems {
+				select {
+				case elemCh <- elem:
+				case <-stop:
+					return
+				}
+			}
+		}()
+
+		for h := range results {
+			if !yield(h) {
+				return
+			}
+		}
+	}
+
+	return fn
+}
@@ -0,0 +1,100 @@
+package runner
+
+// group is a Runner made of several runners.
+type group struct {
+	// runners are the members of the group, in start order.
+	runners []Runner
+
+	// state is the lifecycle state of the group.
+	state lifecycle
+}
+
+// Group creates a Runner that manages several runners as one.
+//
+// Parameters:
+//   - runners: The members of the group. Nil runners are ignored.
+//
+// Returns:
+//   - Runner: The group. Never returns nil.
+//
+// Start starts the members in order, Close closes them in reverse order, and
+// IsClosed reports true only if all the members are closed. The group is also a
+// StateProvider whose state follows its own Start and Close calls.
+func Group(runners ...Runner) Runner {
+	members := make([]Runner, 0, len(runners))
+
+	for _, r := range runners {
+		if r != nil {
+			members = append(members, r)
+		}
+	}
+
+	return &group{
+		runners: members,
+	}
+}
+
+// Start implements the Runner interface.
+func (g *group) Start() {
+	g.state.set(Starting)
+
+	for _, r := range g.runners {
+		r.Start()
+	}
+
+	g.state.set(Running)
+}
+
+// Close implements the Runner interface.
+func (g *group) Close() {
+	stopping := g.state.setIf(Stopping, Starting, Running)
+
+	for i := len(g.runners) - 1; i >= 0; i-- {
+		g.runners[i].Close()
+	}
+
+	if stopping {
+		g.state.set(Stopped)
+	}
+}
+
+// IsClosed implements the Runner interface.
+func (g *group) IsClosed() bool {
+	for _, r := range g.runners {
+		if !r.IsClosed() {
+			return false
+		}
+	}
+
+	return true
+}
+
+// State implements the StateProvider interface.
+func (g *group) State() RunnerState {
+	return g.state.get()
+}
+
+// ObserveState implements the StateProvider interface.
+func (g *group) ObserveState(fn func(RunnerState)) {
+	g.state.observe(fn)
+}
+
+// Healthy implements the HealthChecker interface.
+//
+// The group is healthy if all the members that implement HealthChecker are
+// healthy. The error of the first unhealthy member is returned.
+func (g *group) Healthy() error {
+	for _, r := range g.runners {
+		hc, ok := r.(HealthChecker)
+		if !ok {
+			continue
+		}
+
+		err := hc.Healthy()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
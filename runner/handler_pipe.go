@@ -0,0 +1,222 @@
+package runner
+
+import (
+	"sync"
+)
+
+// HandlerPipe is a handler that, unlike HandlerSend, produces an output for
+// every message it processes. Outputs are retrieved with Receive so that
+// handlers can be chained.
+type HandlerPipe[I, O any] struct {
+	// handler is the handler that runs the Go routine.
+	handler *HandlerSend[I]
+
+	// mu protects outChan, drained, done and running.
+	mu sync.RWMutex
+
+	// outChan is the channel the outputs are sent to. It is closed once the
+	// Go routine has exited, whether it was closed or it exited on its own.
+	outChan chan O
+
+	// drained is closed once outChan is closed.
+	drained chan struct{}
+
+	// done is closed when the handler is closed, so that the outputs nobody
+	// receives do not block the Go routine.
+	done chan struct{}
+
+	// running is true between Start and Close.
+	running bool
+}
+
+// NewHandlerPipe creates a new HandlerPipe.
+//
+// Parameters:
+//   - routine: The Go routine to run.
+//
+// Returns:
+//   - *HandlerPipe: A pointer to the HandlerPipe that handles the result of the Go routine.
+//   - bool: True if the HandlerPipe was created successfully, false otherwise.
+//
+// Behaviors:
+//   - The Go routine is not started automatically.
+//   - Errors are sent to the error channel and no output is produced for them.
+//   - Outputs that are not received by the time the handler is closed are
+//     discarded.
+//   - Once the Go routine has exited, Receive returns false.
+//   - If routine is nil, this function returns nil.
+func NewHandlerPipe[I, O any](routine func(I) (O, error)) (*HandlerPipe[I, O], bool) {
+	if routine == nil {
+		return nil, false
+	}
+
+	h := &HandlerPipe[I, O]{}
+
+	h.handler, _ = NewHandlerSend(func(msg I) error {
+		out, err := routine(msg)
+		if err != nil {
+			return err
+		}
+
+		h.mu.RLock()
+		outChan, done := h.outChan, h.done
+		h.mu.RUnlock()
+
+		select {
+		case outChan <- out:
+		case <-done:
+		}
+
+		return nil
+	})
+
+	return h, true
+}
+
+// Start implements the Runner interface.
+func (h *HandlerPipe[I, O]) Start() {
+	if h == nil {
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.running {
+		if !h.handler.IsClosed() {
+			return
+		}
+
+		// The Go routine exited on its own.
+		close(h.done)
+	}
+
+	outChan := make(chan O)
+	drained := make(chan struct{})
+
+	h.running = true
+	h.outChan = outChan
+	h.drained = drained
+	h.done = make(chan struct{})
+
+	h.handler.Start()
+
+	h.handler.mu.RLock()
+	finished := h.handler.cur.finished
+	h.handler.mu.RUnlock()
+
+	go func() {
+		<-finished
+
+		// The workers have exited, so nothing sends to outChan anymore.
+		close(outChan)
+		close(drained)
+	}()
+}
+
+// Close implements the Runner interface.
+func (h *HandlerPipe[I, O]) Close() {
+	if h == nil {
+		return
+	}
+
+	h.mu.Lock()
+
+	if !h.running {
+		h.mu.Unlock()
+		return
+	}
+
+	h.running = false
+	drained := h.drained
+
+	close(h.done)
+
+	h.mu.Unlock()
+
+	h.handler.Close()
+
+	<-drained
+}
+
+// IsClosed implements the Runner interface.
+func (h *HandlerPipe[I, O]) IsClosed() bool {
+	return h == nil || h.handler.IsClosed()
+}
+
+// State implements the StateProvider interface.
+func (h *HandlerPipe[I, O]) State() RunnerState {
+	if h == nil {
+		return Idle
+	}
+
+	return h.handler.State()
+}
+
+// ObserveState implements the StateProvider interface.
+func (h *HandlerPipe[I, O]) ObserveState(fn func(RunnerState)) {
+	if h == nil {
+		return
+	}
+
+	h.handler.ObserveState(fn)
+}
+
+// Healthy implements the HealthChecker interface.
+func (h *HandlerPipe[I, O]) Healthy() error {
+	if h.IsClosed() {
+		return NotRunning
+	}
+
+	return nil
+}
+
+// Stats implements the StatsProvider interface.
+func (h *HandlerPipe[I, O]) Stats() Stats {
+	if h == nil {
+		return Stats{}
+	}
+
+	return h.handler.Stats()
+}
+
+// ReceiveErr is a method of HandlerPipe that receives the next error of the
+// Go routine.
+//
+// Returns:
+//   - error: The error received.
+//   - bool: False if the handler is closed, true otherwise.
+func (h *HandlerPipe[I, O]) ReceiveErr() (error, bool) {
+	if h == nil {
+		return nil, false
+	}
+
+	return h.handler.ReceiveErr()
+}
+
+// Send implements the Sender interface.
+func (h *HandlerPipe[I, O]) Send(msg I) bool {
+	if h == nil {
+		return false
+	}
+
+	return h.handler.Send(msg)
+}
+
+// Receive implements the Receiver interface.
+func (h *HandlerPipe[I, O]) Receive() (O, bool) {
+	if h == nil {
+		return *new(O), false
+	}
+
+	h.mu.RLock()
+	outChan := h.outChan
+	h.mu.RUnlock()
+
+	if outChan == nil {
+		return *new(O), false
+	}
+
+	out, ok := <-outChan
+	return out, ok
+}
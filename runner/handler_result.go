@@ -0,0 +1,242 @@
+package runner
+
+import (
+	"context"
+	"sync"
+)
+
+// Result is the outcome of a single invocation of the routine of a
+// HandlerResult. It carries either a value or an error.
+type Result[T any] struct {
+	// Value is the value produced by the routine. Only meaningful if Err is nil.
+	Value T
+
+	// Err is the error of the routine.
+	Err error
+}
+
+// HandlerResult is a handler that, unlike HandlerSimple, delivers the value
+// or the error of every invocation of its routine through a single stream
+// read with Receive.
+type HandlerResult[T any] struct {
+	// routine is the Go routine that is run by the handler.
+	routine func(ctx context.Context) (T, error)
+
+	// resChan is the channel the results are sent to.
+	resChan chan Result[T]
+
+	// ctx is the context of the Go routine.
+	ctx context.Context
+
+	// cancel is the cancel function of the Go routine.
+	cancel context.CancelFunc
+
+	// wg is a WaitGroup that is used to wait for the Go routine to finish.
+	wg sync.WaitGroup
+
+	// mu protects resChan, ctx and cancel.
+	mu sync.RWMutex
+
+	// state is the lifecycle state of the handler.
+	state lifecycle
+}
+
+// NewHandlerResult creates a new HandlerResult.
+//
+// Parameters:
+//   - routine: The Go routine to run.
+//
+// Returns:
+//   - *HandlerResult: A pointer to the HandlerResult that handles the results of the Go routine.
+//   - bool: True if the HandlerResult was created successfully, false otherwise.
+//
+// Behaviors:
+//   - If routine is nil, this function returns nil.
+//   - The Go routine is not started automatically.
+//   - In routine, return NoError to exit the Go routine; no result is delivered
+//     for it. Any other error is delivered and the Go routine keeps running.
+func NewHandlerResult[T any](routine func() (T, error)) (*HandlerResult[T], bool) {
+	if routine == nil {
+		return nil, false
+	}
+
+	return &HandlerResult[T]{
+		routine: func(_ context.Context) (T, error) {
+			return routine()
+		},
+	}, true
+}
+
+// NewHandlerResultCtx is like NewHandlerResult but the routine receives the
+// context of the handler so that it can observe the cancellation done by Close.
+//
+// Parameters:
+//   - routine: The Go routine to run.
+//
+// Returns:
+//   - *HandlerResult: A pointer to the HandlerResult that handles the results of the Go routine.
+//   - bool: True if the HandlerResult was created successfully, false otherwise.
+func NewHandlerResultCtx[T any](routine func(ctx context.Context) (T, error)) (*HandlerResult[T], bool) {
+	if routine == nil {
+		return nil, false
+	}
+
+	return &HandlerResult[T]{
+		routine: routine,
+	}, true
+}
+
+// Start implements the Runner interface.
+func (h *HandlerResult[T]) Start() {
+	if h == nil {
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.ctx != nil && h.ctx.Err() == nil {
+		return
+	}
+
+	h.state.set(Starting)
+
+	h.resChan = make(chan Result[T])
+	h.ctx, h.cancel = context.WithCancel(context.Background())
+
+	h.wg.Add(1)
+
+	go h.run(h.ctx, h.cancel, h.resChan)
+}
+
+// Close implements the Runner interface.
+func (h *HandlerResult[T]) Close() {
+	if h == nil {
+		return
+	}
+
+	h.mu.Lock()
+
+	if h.ctx == nil || h.ctx.Err() != nil {
+		h.mu.Unlock()
+		return
+	}
+
+	h.state.setIf(Stopping, Starting, Running)
+
+	h.cancel()
+
+	h.mu.Unlock()
+
+	h.wg.Wait()
+}
+
+// IsClosed implements the Runner interface.
+func (h *HandlerResult[T]) IsClosed() bool {
+	if h == nil {
+		return true
+	}
+
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	return h.ctx == nil || h.ctx.Err() != nil
+}
+
+// State implements the StateProvider interface.
+func (h *HandlerResult[T]) State() RunnerState {
+	if h == nil {
+		return Idle
+	}
+
+	return h.state.get()
+}
+
+// ObserveState implements the StateProvider interface.
+func (h *HandlerResult[T]) ObserveState(fn func(RunnerState)) {
+	if h == nil {
+		return
+	}
+
+	h.state.observe(fn)
+}
+
+// Receive implements the Receiver interface.
+//
+// It blocks until the next invocation of the routine has completed.
+func (h *HandlerResult[T]) Receive() (Result[T], bool) {
+	if h == nil {
+		return Result[T]{}, false
+	}
+
+	h.mu.RLock()
+	resChan := h.resChan
+	h.mu.RUnlock()
+
+	if resChan == nil {
+		return Result[T]{}, false
+	}
+
+	res, ok := <-resChan
+	return res, ok
+}
+
+// run is a private method of HandlerResult that is runned by the Go routine.
+//
+// Parameters:
+//   - ctx: The context of the run.
+//   - cancel: The cancel function of ctx.
+//   - resChan: The channel the results are sent to.
+//
+// Behaviors:
+//   - A panic of the routine is delivered as an *ErrPanic and ends the run.
+func (h *HandlerResult[T]) run(ctx context.Context, cancel context.CancelFunc, resChan chan Result[T]) {
+	defer h.wg.Done()
+	defer close(resChan)
+	defer cancel()
+
+	h.state.setIf(Running, Starting)
+
+	for ctx.Err() == nil {
+		res, panicked := h.invoke(ctx)
+		if res.Err == NoError {
+			break
+		}
+
+		select {
+		case resChan <- res:
+		case <-ctx.Done():
+		}
+
+		if panicked {
+			h.state.setIf(Failed, Starting, Running)
+			return
+		}
+	}
+
+	h.state.setIf(Stopped, Starting, Running, Stopping)
+}
+
+// invoke is a private method of HandlerResult that runs the routine once and
+// recovers from its panics.
+//
+// Parameters:
+//   - ctx: The context of the run.
+//
+// Returns:
+//   - Result[T]: The result of the routine.
+//   - bool: True if the routine panicked, false otherwise.
+func (h *HandlerResult[T]) invoke(ctx context.Context) (res Result[T], panicked bool) {
+	defer func() {
+		r := recover()
+
+		if r != nil {
+			res = Result[T]{Err: NewErrPanic(r)}
+			panicked = true
+		}
+	}()
+
+	value, err := h.routine(ctx)
+
+	return Result[T]{Value: value, Err: err}, false
+}
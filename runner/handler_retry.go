@@ -0,0 +1,216 @@
+package runner
+
+import (
+	"context"
+	"math/rand/v2"
+	"sync"
+	"time"
+)
+
+// RetryConfig is the configuration of the retries of a RetryHandler.
+type RetryConfig struct {
+	// MaxRetries is the number of retries after the first attempt.
+	MaxRetries int
+
+	// InitialDelay is the delay before the first retry. Each subsequent retry
+	// doubles the delay.
+	InitialDelay time.Duration
+
+	// MaxDelay is the upper bound of the delay between retries. Zero means no
+	// upper bound.
+	MaxDelay time.Duration
+
+	// Jitter is the upper bound of the random delay added to every retry.
+	Jitter time.Duration
+}
+
+// RetryHandler is a handler that, unlike HandlerSend, retries the routine
+// when it fails on a message. Only when all the retries fail is an
+// *ErrRetriesExceeded sent to the error channel.
+type RetryHandler[T any] struct {
+	// handler is the handler that runs the Go routine.
+	handler *HandlerSend[T]
+
+	// config is the retry configuration.
+	config RetryConfig
+
+	// ctx is the context that interrupts the delays between retries.
+	ctx context.Context
+
+	// cancel is the cancel function of ctx.
+	cancel context.CancelFunc
+
+	// mu protects ctx and cancel.
+	mu sync.RWMutex
+}
+
+// NewRetryHandler creates a new RetryHandler.
+//
+// Parameters:
+//   - routine: The Go routine to run.
+//   - config: The retry configuration.
+//
+// Returns:
+//   - *RetryHandler: A pointer to the RetryHandler that handles the result of the Go routine.
+//   - bool: True if the RetryHandler was created successfully, false otherwise.
+//
+// Behaviors:
+//   - The Go routine is not started automatically.
+//   - NoError is never retried.
+//   - If routine is nil, this function returns nil.
+func NewRetryHandler[T any](routine func(T) error, config RetryConfig) (*RetryHandler[T], bool) {
+	if routine == nil {
+		return nil, false
+	}
+
+	h := &RetryHandler[T]{
+		config: config,
+	}
+
+	h.handler, _ = NewHandlerSend(func(msg T) error {
+		return h.attempt(routine, msg)
+	})
+
+	return h, true
+}
+
+// attempt is a private method of RetryHandler that runs the routine on a
+// message until it succeeds or the retries are exhausted.
+//
+// Parameters:
+//   - routine: The routine to run.
+//   - msg: The message.
+//
+// Returns:
+//   - error: Nil or NoError on success, an *ErrRetriesExceeded otherwise.
+func (h *RetryHandler[T]) attempt(routine func(T) error, msg T) error {
+	h.mu.RLock()
+	ctx := h.ctx
+	h.mu.RUnlock()
+
+	delay := h.config.InitialDelay
+
+	var err error
+
+	for i := 0; ; i++ {
+		err = routine(msg)
+		if err == nil || err == NoError {
+			return err
+		}
+
+		if i >= h.config.MaxRetries {
+			return NewErrRetriesExceeded(i+1, err)
+		}
+
+		wait := delay
+
+		if h.config.Jitter > 0 {
+			wait += rand.N(h.config.Jitter)
+		}
+
+		select {
+		case <-ctx.Done():
+			return NewErrRetriesExceeded(i+1, err)
+		case <-time.After(wait):
+		}
+
+		delay *= 2
+
+		if h.config.MaxDelay > 0 && delay > h.config.MaxDelay {
+			delay = h.config.MaxDelay
+		}
+	}
+}
+
+// Start implements the Runner interface.
+func (h *RetryHandler[T]) Start() {
+	if h == nil || !h.handler.IsClosed() {
+		return
+	}
+
+	h.mu.Lock()
+	h.ctx, h.cancel = context.WithCancel(context.Background())
+	h.mu.Unlock()
+
+	h.handler.Start()
+}
+
+// Close implements the Runner interface.
+//
+// Pending retries are interrupted and reported as *ErrRetriesExceeded.
+func (h *RetryHandler[T]) Close() {
+	if h == nil {
+		return
+	}
+
+	h.mu.RLock()
+	cancel := h.cancel
+	h.mu.RUnlock()
+
+	if cancel != nil {
+		cancel()
+	}
+
+	h.handler.Close()
+}
+
+// IsClosed implements the Runner interface.
+func (h *RetryHandler[T]) IsClosed() bool {
+	return h == nil || h.handler.IsClosed()
+}
+
+// State implements the StateProvider interface.
+func (h *RetryHandler[T]) State() RunnerState {
+	if h == nil {
+		return Idle
+	}
+
+	return h.handler.State()
+}
+
+// ObserveState implements the StateProvider interface.
+func (h *RetryHandler[T]) ObserveState(fn func(RunnerState)) {
+	if h == nil {
+		return
+	}
+
+	h.handler.ObserveState(fn)
+}
+
+// Healthy implements the HealthChecker interface.
+func (h *RetryHandler[T]) Healthy() error {
+	if h.IsClosed() {
+		return NotRunning
+	}
+
+	return nil
+}
+
+// Stats implements the StatsProvider interface.
+//
+// Retries are not counted as separate messages.
+func (h *RetryHandler[T]) Stats() Stats {
+	if h == nil {
+		return Stats{}
+	}
+
+	return h.handler.Stats()
+}
+
+// ReceiveErr implements the ErrReceiver interface.
+func (h *RetryHandler[T]) ReceiveErr() (error, bool) {
+	if h == nil {
+		return nil, false
+	}
+
+	return h.handler.ReceiveErr()
+}
+
+// Send implements the Sender interface.
+func (h *RetryHandler[T]) Send(msg T) bool {
+	if h == nil {
+		return false
+	}
+
+	return h.handler.Send(msg)
+}
@@ -0,0 +1,734 @@
+package runner
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+var (
+	// NoError is the error that is returned when there is no error. Readers must return
+	// this error as is an not wrap it as callers are expected to check for this error
+	// with ==.
+	NoError error
+)
+
+func init() {
+	NoError = errors.New("no error")
+}
+
+// HandlerSend is a handler that, unlike HandlerSimple, whenever
+// an error occurs, it is sent to the error channel instead of
+// terminating the Go routine.
+type HandlerSend[T any] struct {
+	// mu protects cur and the settings of the handler.
+	mu sync.RWMutex
+
+	// cur is the current run of the handler. Nil if it was never started.
+	cur *sendRun[T]
+
+	// routine is the Go routine that is run by the handler.
+	routine func(ctx context.Context, msg T) error
+
+	// timeout is the maximum duration of a single invocation of the routine.
+	// Zero means no limit.
+	timeout time.Duration
+
+	// bufSize is the capacity of sendChan.
+	bufSize int
+
+	// workers is the number of Go routines that consume sendChan.
+	workers int
+
+	// restart is the restart configuration of the Go routine.
+	restart RestartConfig
+
+	// errHandler, if not nil, receives the errors instead of errChan.
+	errHandler func(err error)
+
+	// metrics are the operational data of the handler.
+	metrics metrics
+
+	// hooks are the lifecycle hooks of the handler.
+	hooks hooks
+
+	// state is the lifecycle state of the handler.
+	state lifecycle
+}
+
+// sendRun is a run of a HandlerSend: its channels and state along with the
+// settings captured by Start, so that the setters never race with the workers
+// and a run that outlives its handler never affects the next one.
+type sendRun[T any] struct {
+	// sendChan is the channel to send messages to the Go routine.
+	sendChan chan T
+
+	// errChan is the error status of the Go routine.
+	errChan chan error
+
+	// done is closed when the handler is closed. Only closed while holding
+	// the lock of the handler.
+	done chan struct{}
+
+	// abort is closed when a drain exceeds its deadline so that the workers
+	// discard the queued messages.
+	abort chan struct{}
+
+	// finished is closed once all the workers have exited and the run is
+	// cleaned up.
+	finished chan struct{}
+
+	// closed is true once all the workers have exited and errChan is closed.
+	closed atomic.Bool
+
+	// failed is true if a worker gave up because of an error.
+	failed atomic.Bool
+
+	// sending counts the Sends in flight so that the send channel is only
+	// closed once they are done.
+	sending sync.WaitGroup
+
+	// restart is the restart configuration of the run.
+	restart RestartConfig
+
+	// timeout is the maximum duration of a single invocation of the routine.
+	// Zero means no limit.
+	timeout time.Duration
+
+	// errHandler, if not nil, receives the errors instead of errChan.
+	errHandler func(err error)
+}
+
+// isOpen is a private method of sendRun that checks whether the run still
+// accepts messages.
+//
+// Returns:
+//   - bool: True if the handler was not closed, false otherwise.
+func (r *sendRun[T]) isOpen() bool {
+	select {
+	case <-r.done:
+		return false
+	default:
+		return true
+	}
+}
+
+// OnStart registers a function that is called every time the Go routine is
+// started.
+//
+// Parameters:
+//   - fn: The function to call. Ignored if nil.
+func (h *HandlerSend[T]) OnStart(fn func()) {
+	if h == nil {
+		return
+	}
+
+	h.hooks.addStart(fn)
+}
+
+// OnStop registers a function that is called every time the Go routine has
+// stopped, whether it was closed or it exited on its own.
+//
+// Parameters:
+//   - fn: The function to call. Ignored if nil.
+func (h *HandlerSend[T]) OnStop(fn func()) {
+	if h == nil {
+		return
+	}
+
+	h.hooks.addStop(fn)
+}
+
+// OnError registers a function that is called on every error of the routine,
+// including panics and errors that are followed by a restart.
+//
+// Parameters:
+//   - fn: The function to call. Ignored if nil.
+func (h *HandlerSend[T]) OnError(fn func(err error)) {
+	if h == nil {
+		return
+	}
+
+	h.hooks.addError(fn)
+}
+
+// SetRestartPolicy sets the restart configuration of the handler. It only
+// takes effect on the next call to Start.
+//
+// Parameters:
+//   - config: The restart configuration.
+func (h *HandlerSend[T]) SetRestartPolicy(config RestartConfig) {
+	if h == nil {
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.restart = config
+}
+
+// SetTimeout sets the maximum duration of a single invocation of the routine.
+// The routine receives a context with the corresponding deadline; if it has
+// not returned by then, an *ErrTimeout is sent to the error channel and the
+// worker moves on to the next message. It only takes effect on the next call
+// to Start.
+//
+// Parameters:
+//   - d: The timeout. Zero or less means no limit.
+func (h *HandlerSend[T]) SetTimeout(d time.Duration) {
+	if h == nil {
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.timeout = max(d, 0)
+}
+
+// SetErrorHandler sets a function that receives the errors of the routine
+// instead of the error channel. It only takes effect on the next call to Start.
+//
+// Parameters:
+//   - fn: The function to call. If nil, errors are sent to the error channel.
+//
+// Behaviors:
+//   - Unlike the error channel, errors that nobody reads do not block the
+//     workers.
+//   - fn is called from the worker Go routines and may be called concurrently
+//     when there are several workers.
+//   - ReceiveErr only reports the closure of the handler.
+func (h *HandlerSend[T]) SetErrorHandler(fn func(err error)) {
+	if h == nil {
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.errHandler = fn
+}
+
+// SetWorkers sets the number of Go routines that consume the messages
+// concurrently. It only takes effect on the next call to Start.
+//
+// Parameters:
+//   - n: The number of workers. Values less than 1 are treated as 1.
+//
+// Returns:
+//   - error: AlreadyRunning if the handler is running, in which case the
+//     number of workers is left unchanged.
+func (h *HandlerSend[T]) SetWorkers(n int) error {
+	if h == nil {
+		return nil
+	}
+
+	if n < 1 {
+		n = 1
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.cur != nil && h.cur.isOpen() && !h.cur.closed.Load() {
+		return AlreadyRunning
+	}
+
+	h.workers = n
+
+	return nil
+}
+
+// Start implements the Runner interface.
+func (h *HandlerSend[T]) Start() {
+	if h == nil {
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	// A run whose workers have all exited on their own is replaced.
+	if h.cur != nil && h.cur.isOpen() && !h.cur.closed.Load() {
+		return
+	}
+
+	run := &sendRun[T]{
+		sendChan:   make(chan T, h.bufSize),
+		errChan:    make(chan error),
+		done:       make(chan struct{}),
+		abort:      make(chan struct{}),
+		finished:   make(chan struct{}),
+		restart:    h.restart,
+		timeout:    h.timeout,
+		errHandler: h.errHandler,
+	}
+
+	h.cur = run
+
+	h.state.set(Starting)
+
+	h.metrics.start()
+
+	n := max(h.workers, 1)
+
+	var wg sync.WaitGroup
+
+	wg.Add(n)
+
+	for i := 0; i < n; i++ {
+		go func() {
+			defer wg.Done()
+
+			h.run(run)
+		}()
+	}
+
+	go func() {
+		h.hooks.fireStart()
+
+		h.state.setIf(Running, Starting)
+
+		wg.Wait()
+
+		h.clean(run)
+
+		// A run that outlived a drain must not change the state of the next one.
+		h.mu.RLock()
+		current := h.cur == run
+		h.mu.RUnlock()
+
+		if current {
+			if run.failed.Load() {
+				h.state.set(Failed)
+			} else {
+				h.state.set(Stopped)
+			}
+		}
+
+		h.hooks.fireStop()
+
+		close(run.finished)
+	}()
+}
+
+// Close implements the Runner interface.
+//
+// It is equivalent to CloseAndDrain with a context that is never done.
+func (h *HandlerSend[T]) Close() {
+	_ = h.CloseAndDrain(context.Background())
+}
+
+// CloseAndDrain is a method of HandlerSend that stops accepting new messages
+// and waits for the queued ones to be processed before shutting down.
+//
+// Parameters:
+//   - ctx: The context that bounds the wait. When it is done, the messages
+//     still queued are discarded.
+//
+// Returns:
+//   - error: The context's error if it is done before all the messages are
+//     processed.
+//
+// Sends that were already in progress are completed before the handler shuts
+// down, so they never race with the closure. If the workers have all exited,
+// they return false instead.
+func (h *HandlerSend[T]) CloseAndDrain(ctx context.Context) error {
+	if h == nil {
+		return nil
+	}
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	h.mu.Lock()
+
+	run := h.cur
+	if run == nil || !run.isOpen() {
+		h.mu.Unlock()
+		return nil
+	}
+
+	h.state.setIf(Stopping, Starting, Running)
+
+	close(run.done)
+
+	h.mu.Unlock()
+
+	go func() {
+		run.sending.Wait()
+		close(run.sendChan)
+	}()
+
+	select {
+	case <-run.finished:
+		return nil
+	case <-ctx.Done():
+		close(run.abort)
+		return ctx.Err()
+	}
+}
+
+// IsClosed implements the Runner interface.
+func (h *HandlerSend[T]) IsClosed() bool {
+	if h == nil {
+		return true
+	}
+
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	return h.cur == nil || h.cur.closed.Load()
+}
+
+// State implements the StateProvider interface.
+//
+// The handler is Failed once all its workers have exited and at least one of
+// them gave up because of an error.
+func (h *HandlerSend[T]) State() RunnerState {
+	if h == nil {
+		return Idle
+	}
+
+	return h.state.get()
+}
+
+// ObserveState implements the StateProvider interface.
+func (h *HandlerSend[T]) ObserveState(fn func(RunnerState)) {
+	if h == nil {
+		return
+	}
+
+	h.state.observe(fn)
+}
+
+// Healthy implements the HealthChecker interface.
+//
+// The handler is healthy as long as at least one of its workers is running.
+func (h *HandlerSend[T]) Healthy() error {
+	if h.IsClosed() {
+		return NotRunning
+	}
+
+	return nil
+}
+
+// Stats implements the StatsProvider interface.
+//
+// Messages counts the messages processed by all the workers.
+func (h *HandlerSend[T]) Stats() Stats {
+	if h == nil {
+		return Stats{}
+	}
+
+	return h.metrics.snapshot(!h.IsClosed())
+}
+
+// ReceiveErr implements the Runner interface.
+func (h *HandlerSend[T]) ReceiveErr() (error, bool) {
+	if h == nil {
+		return nil, false
+	}
+
+	h.mu.RLock()
+	run := h.cur
+	h.mu.RUnlock()
+
+	if run == nil {
+		return nil, false
+	}
+
+	err, ok := <-run.errChan
+	if !ok {
+		return nil, false
+	} else {
+		return err, true
+	}
+}
+
+// run is a private method of HandlerSend that is runned by the Go routine.
+//
+// Parameters:
+//   - run: The run the Go routine belongs to.
+//
+// Behaviors:
+//   - Use uc.ErrNoError to exit the Go routine as nil is used to signal
+//     that the function has finished successfully but the Go routine is still running.
+//   - The routine is restarted according to the restart configuration.
+//   - With several workers, each one exits independently; the handler is
+//     closed once all of them have exited.
+func (h *HandlerSend[T]) run(run *sendRun[T]) {
+	r := newRestarter(run.restart)
+
+	for {
+		err := h.runOnce(run)
+		if err == nil {
+			return
+		} else if err != NoError {
+			h.metrics.errors.Add(1)
+			h.hooks.fireError(err)
+		}
+
+		delay, ok := r.next(err)
+		if !ok {
+			if err != NoError {
+				run.failed.Store(true)
+				h.report(run, err)
+			}
+
+			return
+		}
+
+		h.metrics.restarts.Add(1)
+
+		select {
+		case <-run.done:
+			return
+		case <-time.After(delay):
+		}
+	}
+}
+
+// runOnce is a private method of HandlerSend that runs the routine on every
+// message until the channel is closed, the routine exits or it panics.
+//
+// Parameters:
+//   - run: The run the Go routine belongs to.
+//
+// Returns:
+//   - error: NoError if the routine exited, an *ErrPanic if it panicked, and
+//     nil if the channel was closed.
+func (h *HandlerSend[T]) runOnce(run *sendRun[T]) (err error) {
+	defer func() {
+		r := recover()
+
+		if r != nil {
+			err = NewErrPanic(r)
+		}
+	}()
+
+	for msg := range run.sendChan {
+		select {
+		case <-run.abort:
+			// Keep receiving so that pending Sends complete.
+			continue
+		default:
+		}
+
+		h.metrics.messages.Add(1)
+
+		err := h.invoke(msg, run.timeout)
+		if err == nil {
+			continue
+		} else if err == NoError {
+			return NoError
+		}
+
+		h.metrics.errors.Add(1)
+		h.hooks.fireError(err)
+
+		h.report(run, err)
+	}
+
+	return nil
+}
+
+// report is a private method of HandlerSend that delivers an error to the
+// error handler if any, or to the error channel otherwise.
+//
+// Parameters:
+//   - run: The run the error belongs to.
+//   - err: The error to deliver.
+func (h *HandlerSend[T]) report(run *sendRun[T], err error) {
+	if run.errHandler != nil {
+		run.errHandler(err)
+	} else {
+		run.errChan <- err
+	}
+}
+
+// invoke is a private method of HandlerSend that runs the routine on a single
+// message, enforcing the timeout if any.
+//
+// Parameters:
+//   - msg: The message.
+//   - timeout: The timeout of the run. Zero means no limit.
+//
+// Returns:
+//   - error: The error of the routine, or an *ErrTimeout if it timed out.
+//
+// Panics of the routine are propagated to the caller.
+func (h *HandlerSend[T]) invoke(msg T, timeout time.Duration) error {
+	if timeout <= 0 {
+		return h.routine(context.Background(), msg)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	type result struct {
+		err   error
+		panic any
+	}
+
+	resChan := make(chan result, 1)
+
+	go func() {
+		defer func() {
+			r := recover()
+			if r != nil {
+				resChan <- result{panic: r}
+			}
+		}()
+
+		resChan <- result{err: h.routine(ctx, msg)}
+	}()
+
+	select {
+	case res := <-resChan:
+		if res.panic != nil {
+			panic(res.panic)
+		}
+
+		return res.err
+	case <-ctx.Done():
+		return NewErrTimeout(timeout)
+	}
+}
+
+// NewHandlerSend creates a new HandlerSend.
+//
+// Parameters:
+//   - routine: The Go routine to run.
+//
+// Returns:
+//   - *HandlerSend: A pointer to the HandlerSend that handles the result of the Go routine.
+//   - bool: True if the HandlerSend was created successfully, false otherwise.
+//
+// Behaviors:
+//   - The Go routine is not started automatically.
+//   - In routine, use *uc.ErrNoError to exit the Go routine as nil is used to signal
+//     that the function has finished successfully but the Go routine is still running.
+//   - If routine is nil, this function returns nil.
+func NewHandlerSend[T any](routine func(T) error) (*HandlerSend[T], bool) {
+	if routine == nil {
+		return nil, false
+	}
+
+	return &HandlerSend[T]{
+		routine: func(_ context.Context, msg T) error {
+			return routine(msg)
+		},
+	}, true
+}
+
+// NewHandlerSendCtx is like NewHandlerSend but the routine receives a context
+// that carries the deadline set with SetTimeout.
+//
+// Parameters:
+//   - routine: The Go routine to run.
+//
+// Returns:
+//   - *HandlerSend: A pointer to the HandlerSend that handles the result of the Go routine.
+//   - bool: True if the HandlerSend was created successfully, false otherwise.
+//
+// Behaviors:
+//   - The Go routine is not started automatically.
+//   - Routines should return as soon as ctx is done.
+//   - If routine is nil, this function returns nil.
+func NewHandlerSendCtx[T any](routine func(ctx context.Context, msg T) error) (*HandlerSend[T], bool) {
+	if routine == nil {
+		return nil, false
+	}
+
+	return &HandlerSend[T]{
+		routine: routine,
+	}, true
+}
+
+// NewHandlerSendBuffered is like NewHandlerSend but messages are queued in a
+// buffer of the given capacity so that Send does not block while the Go
+// routine is busy.
+//
+// Parameters:
+//   - routine: The Go routine to run.
+//   - n: The capacity of the buffer. Negative values are treated as 0.
+//
+// Returns:
+//   - *HandlerSend: A pointer to the HandlerSend that handles the result of the Go routine.
+//   - bool: True if the HandlerSend was created successfully, false otherwise.
+//
+// Behaviors:
+//   - Send only blocks when the buffer is full.
+//   - If routine is nil, this function returns nil.
+func NewHandlerSendBuffered[T any](routine func(T) error, n int) (*HandlerSend[T], bool) {
+	if routine == nil {
+		return nil, false
+	}
+
+	if n < 0 {
+		n = 0
+	}
+
+	return &HandlerSend[T]{
+		routine: func(_ context.Context, msg T) error {
+			return routine(msg)
+		},
+		bufSize: n,
+	}, true
+}
+
+// Send is a method of HandlerSend that sends a message to the Go routine.
+// If the Go routine is not running, false is returned.
+//
+// Parameters:
+//   - msg: The message to send.
+//
+// Returns:
+//   - bool: True if the message is sent, false otherwise.
+func (h *HandlerSend[T]) Send(msg T) bool {
+	if h == nil {
+		return false
+	}
+
+	h.mu.RLock()
+
+	run := h.cur
+	if run == nil || !run.isOpen() || run.closed.Load() {
+		h.mu.RUnlock()
+		return false
+	}
+
+	run.sending.Add(1)
+
+	h.mu.RUnlock()
+
+	defer run.sending.Done()
+
+	// Once all the workers have exited, nobody receives the message.
+	select {
+	case run.sendChan <- msg:
+		return true
+	case <-run.finished:
+		return false
+	}
+}
+
+// clean is a private method of HandlerSend that cleans up a run of the
+// handler.
+//
+// Parameters:
+//   - run: The run to clean up.
+func (h *HandlerSend[T]) clean(run *sendRun[T]) {
+	if h == nil {
+		return
+	}
+
+	if !run.closed.Swap(true) {
+		close(run.errChan)
+	}
+}
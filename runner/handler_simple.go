@@ -3,25 +3,122 @@ package runner
 import (
 	"context"
 	"sync"
+	"sync/atomic"
+	"time"
 )
 
 // HandlerSimple is a struct that represents a Go routine handler.
 // It is used to handle the result of a Go routine.
 type HandlerSimple struct {
-	// wg is a WaitGroup that is used to wait for the Go routine to finish.
-	wg sync.WaitGroup
+	// cur is the current run of the handler. Nil if it was never started.
+	cur *simpleRun
 
+	// routine is the Go routine that is run by the handler.
+	routine func(ctx context.Context) error
+
+	// restart is the restart configuration of the Go routine.
+	restart RestartConfig
+
+	// mu protects cur and restart.
+	mu sync.RWMutex
+
+	// metrics are the operational data of the handler.
+	metrics metrics
+
+	// hooks are the lifecycle hooks of the handler.
+	hooks hooks
+
+	// state is the lifecycle state of the handler.
+	state lifecycle
+}
+
+// simpleRun is a run of a HandlerSimple: its channels and state along with
+// the settings captured by Start, so that a run that outlives its handler
+// never affects the next one.
+type simpleRun struct {
 	// errChan is the error status of the Go routine.
 	errChan chan error
 
-	// routine is the Go routine that is run by the handler.
-	routine func() error
-
 	// ctx is the context of the Go routine.
 	ctx context.Context
 
 	// cancel is the cancel function of the Go routine.
 	cancel context.CancelFunc
+
+	// finished is closed once the Go routine has exited and the run is
+	// cleaned up.
+	finished chan struct{}
+
+	// closed is true once the Go routine has exited and errChan is closed.
+	closed atomic.Bool
+
+	// restart is the restart configuration of the run.
+	restart RestartConfig
+}
+
+// isOpen is a private method of simpleRun that checks whether the Go routine
+// of the run may still be running.
+//
+// Returns:
+//   - bool: True if the run was neither closed nor has exited, false
+//     otherwise.
+func (r *simpleRun) isOpen() bool {
+	return r.ctx.Err() == nil && !r.closed.Load()
+}
+
+// OnStart registers a function that is called every time the Go routine is
+// started.
+//
+// Parameters:
+//   - fn: The function to call. Ignored if nil.
+func (h *HandlerSimple) OnStart(fn func()) {
+	if h == nil {
+		return
+	}
+
+	h.hooks.addStart(fn)
+}
+
+// OnStop registers a function that is called every time the Go routine has
+// stopped, whether it was closed or it exited on its own.
+//
+// Parameters:
+//   - fn: The function to call. Ignored if nil.
+func (h *HandlerSimple) OnStop(fn func()) {
+	if h == nil {
+		return
+	}
+
+	h.hooks.addStop(fn)
+}
+
+// OnError registers a function that is called on every error of the routine,
+// including panics and errors that are followed by a restart.
+//
+// Parameters:
+//   - fn: The function to call. Ignored if nil.
+func (h *HandlerSimple) OnError(fn func(err error)) {
+	if h == nil {
+		return
+	}
+
+	h.hooks.addError(fn)
+}
+
+// SetRestartPolicy sets the restart configuration of the handler. It only
+// takes effect on the next call to Start.
+//
+// Parameters:
+//   - config: The restart configuration.
+func (h *HandlerSimple) SetRestartPolicy(config RestartConfig) {
+	if h == nil {
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.restart = config
 }
 
 // Start implements the Runner interface.
@@ -30,14 +127,35 @@ func (h *HandlerSimple) Start() {
 		return
 	}
 
-	h.errChan = make(chan error)
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.cur != nil {
+		if h.cur.isOpen() {
+			return
+		}
+
+		// Release the context of the previous run, which exited on its own.
+		h.cur.cancel()
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	run := &simpleRun{
+		errChan:  make(chan error),
+		ctx:      ctx,
+		cancel:   cancel,
+		finished: make(chan struct{}),
+		restart:  h.restart,
+	}
 
-	h.ctx, h.cancel = context.WithCancel(context.Background())
+	h.cur = run
 
-	h.wg.Add(1)
+	h.state.set(Starting)
 
-	go h.run()
+	h.metrics.start()
 
+	go h.run(run)
 }
 
 // Close implements the Runner interface.
@@ -46,28 +164,91 @@ func (h *HandlerSimple) Close() {
 		return
 	}
 
-	select {
-	case <-h.ctx.Done():
-		// Do nothing as the context is already done.
-	default:
-		h.cancel()
+	h.mu.Lock()
 
-		h.wg.Wait()
+	run := h.cur
+	if run == nil || run.ctx.Err() != nil {
+		// Do nothing as the context is already done.
+		h.mu.Unlock()
+		return
 	}
+
+	h.state.setIf(Stopping, Starting, Running)
+
+	run.cancel()
+
+	h.mu.Unlock()
+
+	<-run.finished
 }
 
 // IsClosed implements the Runner interface.
 func (h *HandlerSimple) IsClosed() bool {
-	return h == nil || h.errChan == nil
+	if h == nil {
+		return true
+	}
+
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	return h.cur == nil || h.cur.closed.Load()
+}
+
+// State implements the StateProvider interface.
+func (h *HandlerSimple) State() RunnerState {
+	if h == nil {
+		return Idle
+	}
+
+	return h.state.get()
+}
+
+// ObserveState implements the StateProvider interface.
+func (h *HandlerSimple) ObserveState(fn func(RunnerState)) {
+	if h == nil {
+		return
+	}
+
+	h.state.observe(fn)
+}
+
+// Healthy implements the HealthChecker interface.
+//
+// The handler is healthy as long as its Go routine is running.
+func (h *HandlerSimple) Healthy() error {
+	if h.IsClosed() {
+		return NotRunning
+	}
+
+	return nil
+}
+
+// Stats implements the StatsProvider interface.
+//
+// Messages counts the invocations of the routine.
+func (h *HandlerSimple) Stats() Stats {
+	if h == nil {
+		return Stats{}
+	}
+
+	return h.metrics.snapshot(!h.IsClosed())
 }
 
 // ReceiveErr implements the Runner interface.
 func (h *HandlerSimple) ReceiveErr() (error, bool) {
-	if h == nil || h.errChan == nil {
+	if h == nil {
+		return nil, false
+	}
+
+	h.mu.RLock()
+	run := h.cur
+	h.mu.RUnlock()
+
+	if run == nil {
 		return nil, false
 	}
 
-	err, ok := <-h.errChan
+	err, ok := <-run.errChan
 	if !ok {
 		return nil, false
 	} else {
@@ -80,28 +261,78 @@ func (h *HandlerSimple) ReceiveErr() (error, bool) {
 // Behaviors:
 //   - Use uc.ErrNoError to exit the Go routine as nil is used to signal
 //     that the function has finished successfully but the Go routine is still running.
-func (h *HandlerSimple) run() {
-	defer h.wg.Done()
+//   - The routine is restarted according to the restart configuration; only the
+//     error that made the handler give up is sent to the error channel.
+//
+// Parameters:
+//   - run: The run the Go routine belongs to.
+func (h *HandlerSimple) run(run *simpleRun) {
+	defer close(run.finished)
+	defer h.hooks.fireStop()
+	defer h.clean(run)
+
+	h.hooks.fireStart()
+
+	h.setState(run, Running, Starting)
+
+	r := newRestarter(run.restart)
+
+	for {
+		err := h.runOnce(run.ctx)
+		if err == nil {
+			return
+		} else if err != NoError {
+			h.metrics.errors.Add(1)
+			h.hooks.fireError(err)
+		}
+
+		delay, ok := r.next(err)
+		if !ok {
+			if err != NoError {
+				h.setState(run, Failed, Starting, Running)
+			}
+
+			run.errChan <- err
+			return
+		}
+
+		h.metrics.restarts.Add(1)
+
+		select {
+		case <-run.ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+	}
+}
 
+// runOnce is a private method of HandlerSimple that runs the routine until it
+// fails or the context is done.
+//
+// Parameters:
+//   - ctx: The context of the run.
+//
+// Returns:
+//   - error: The error the routine failed with. Nil if the context is done.
+func (h *HandlerSimple) runOnce(ctx context.Context) (err error) {
 	defer func() {
 		r := recover()
 
 		if r != nil {
-			h.errChan <- NewErrPanic(r)
+			err = NewErrPanic(r)
 		}
-
-		h.clean()
 	}()
 
 	for {
 		select {
-		case <-h.ctx.Done():
-			return
+		case <-ctx.Done():
+			return nil
 		default:
-			err := h.routine()
+			h.metrics.messages.Add(1)
+
+			err := h.routine(ctx)
 			if err != nil {
-				h.errChan <- err
-				return
+				return err
 			}
 		}
 	}
@@ -126,19 +357,65 @@ func NewHandlerSimple(routine func() error) (*HandlerSimple, bool) {
 		return nil, false
 	}
 
+	return &HandlerSimple{
+		routine: func(_ context.Context) error {
+			return routine()
+		},
+	}, true
+}
+
+// NewHandlerSimpleCtx is like NewHandlerSimple but the routine receives the
+// context of the handler so that it can observe the cancellation done by Close.
+//
+// Parameters:
+//   - routine: The Go routine to run.
+//
+// Returns:
+//   - *HandlerSimple: A pointer to the HandlerSimple that handles the result of the Go routine.
+//   - bool: True if the HandlerSimple was created successfully, false otherwise.
+//
+// Behaviors:
+//   - If routine is nil, this function returns nil.
+//   - The Go routine is not started automatically.
+//   - Long-running routines should return as soon as ctx is done.
+func NewHandlerSimpleCtx(routine func(ctx context.Context) error) (*HandlerSimple, bool) {
+	if routine == nil {
+		return nil, false
+	}
+
 	return &HandlerSimple{
 		routine: routine,
 	}, true
 }
 
+// setState is a private method of HandlerSimple that changes the state of the
+// handler as lifecycle.setIf does, unless a newer run has been started since.
+//
+// Parameters:
+//   - run: The run that changes the state.
+//   - to: The new state.
+//   - from: The states the handler must be in for the change to happen.
+func (h *HandlerSimple) setState(run *simpleRun, to RunnerState, from ...RunnerState) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	if h.cur == run {
+		h.state.setIf(to, from...)
+	}
+}
+
 // clean is a private method of HandlerSimple that cleans up the handler.
-func (h *HandlerSimple) clean() {
+//
+// Parameters:
+//   - run: The run to clean up.
+func (h *HandlerSimple) clean(run *simpleRun) {
 	if h == nil {
 		return
 	}
 
-	if h.errChan != nil {
-		close(h.errChan)
-		h.errChan = nil
+	if !run.closed.Swap(true) {
+		close(run.errChan)
 	}
+
+	h.setState(run, Stopped, Starting, Running, Stopping)
 }
@@ -0,0 +1,54 @@
+package runner
+
+import (
+	"errors"
+)
+
+var (
+	// NotRunning is the error that is returned when the process is not running.
+	NotRunning error
+)
+
+func init() {
+	NotRunning = errors.New("the process is not running")
+}
+
+// HealthChecker is the interface that wraps the Healthy method.
+type HealthChecker interface {
+	// Healthy checks whether the component is able to do its work.
+	//
+	// Returns:
+	//   - error: Nil if the component is healthy, the reason otherwise.
+	Healthy() error
+}
+
+// CheckAll checks the health of several components at once.
+//
+// Parameters:
+//   - checkers: The components to check, indexed by name. Nil checkers are ignored.
+//
+// Returns:
+//   - map[string]error: The errors of the unhealthy components. Nil if all of
+//     them are healthy.
+func CheckAll(checkers map[string]HealthChecker) map[string]error {
+	var errMap map[string]error
+
+	for name, c := range checkers {
+		if c == nil {
+			continue
+		}
+
+		err := c.Healthy()
+		if err == nil {
+			continue
+		}
+
+		if errMap == nil {
+			errMap = make(map[string]error)
+		}
+
+		errMap[name] = err
+	}
+
+	return errMap
+}
@@ -0,0 +1,101 @@
+package runner
+
+import (
+	"sync"
+)
+
+// hooks are the lifecycle hooks of a handler. They are safe for concurrent use.
+type hooks struct {
+	// onStart are called when the Go routine starts.
+	onStart []func()
+
+	// onStop are called when the Go routine stops.
+	onStop []func()
+
+	// onError are called on every error of the Go routine.
+	onError []func(err error)
+
+	// mu protects the hooks.
+	mu sync.RWMutex
+}
+
+// addStart is a private method of hooks that registers a start hook.
+//
+// Parameters:
+//   - fn: The hook. Ignored if nil.
+func (h *hooks) addStart(fn func()) {
+	if fn == nil {
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.onStart = append(h.onStart, fn)
+}
+
+// addStop is a private method of hooks that registers a stop hook.
+//
+// Parameters:
+//   - fn: The hook. Ignored if nil.
+func (h *hooks) addStop(fn func()) {
+	if fn == nil {
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.onStop = append(h.onStop, fn)
+}
+
+// addError is a private method of hooks that registers an error hook.
+//
+// Parameters:
+//   - fn: The hook. Ignored if nil.
+func (h *hooks) addError(fn func(err error)) {
+	if fn == nil {
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.onError = append(h.onError, fn)
+}
+
+// fireStart is a private method of hooks that calls the start hooks.
+func (h *hooks) fireStart() {
+	h.mu.RLock()
+	fns := h.onStart
+	h.mu.RUnlock()
+
+	for _, fn := range fns {
+		fn()
+	}
+}
+
+// fireStop is a private method of hooks that calls the stop hooks.
+func (h *hooks) fireStop() {
+	h.mu.RLock()
+	fns := h.onStop
+	h.mu.RUnlock()
+
+	for _, fn := range fns {
+		fn()
+	}
+}
+
+// fireError is a private method of hooks that calls the error hooks.
+//
+// Parameters:
+//   - err: The error.
+func (h *hooks) fireError(err error) {
+	h.mu.RLock()
+	fns := h.onError
+	h.mu.RUnlock()
+
+	for _, fn := range fns {
+		fn(err)
+	}
+}
@@ -0,0 +1,63 @@
+package runner
+
+// Runner is an interface that defines the behavior of a type that can be started,
+// stopped, and waited for.
+type Runner interface {
+	// Start starts the runner. If it is already running, nothing happens.
+	Start()
+
+	// Close closes the runner. If it is not running, nothing happens.
+	Close()
+
+	// IsClosed checks if the runner is closed. It is true both before Start
+	// and after Close; use StateProvider to tell them apart.
+	//
+	// Returns:
+	//   - bool: True if the runner is closed, false otherwise.
+	IsClosed() bool
+}
+
+// ErrReceiver is the interface that wraps the ReceiveErr method.
+type ErrReceiver interface {
+	// ReceiveErr receives the next error reported by the Go routine. It blocks
+	// until an error is available.
+	//
+	// Returns:
+	//   - error: The error received.
+	//   - bool: False if the runner is closed, true otherwise.
+	ReceiveErr() (error, bool)
+}
+
+// ErrRunner is the interface that wraps the ReceiveErr method and the Runner interface.
+type ErrRunner interface {
+	ErrReceiver
+	Runner
+}
+
+// Sender is the interface that wraps the Send method.
+type Sender[T any] interface {
+	// Send sends a message to the Buffer.
+	//
+	// Parameters:
+	//   - msg: The message to send.
+	//
+	// Returns:
+	//   - bool: False if the Buffer is closed, true otherwise.
+	Send(msg T) bool
+}
+
+// SenderRunner is the interface that wraps the Send method and the Runner interface.
+type SenderRunner[T any] interface {
+	Sender[T]
+	Runner
+}
+
+// Receiver is the interface that wraps the Receive method.
+type Receiver[T any] interface {
+	// Receive receives a message. It blocks until a message is available.
+	//
+	// Returns:
+	//   - T: The message received.
+	//   - bool: False if the Receiver is closed, true otherwise.
+	Receive() (T, bool)
+}
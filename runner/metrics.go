@@ -0,0 +1,152 @@
+package runner
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Stats is a snapshot of the operational data of a handler.
+type Stats struct {
+	// StartTime is the time the handler was last started. Zero if it was never started.
+	StartTime time.Time
+
+	// Uptime is the time elapsed since StartTime. Zero if the handler is not running.
+	Uptime time.Duration
+
+	// Messages is the number of routine invocations.
+	Messages uint64
+
+	// Errors is the number of errors and panics of the routine.
+	Errors uint64
+
+	// Restarts is the number of restarts done by the restart policy.
+	Restarts uint64
+}
+
+// StatsProvider is the interface that wraps the Stats method.
+type StatsProvider interface {
+	// Stats returns a snapshot of the operational data of the handler.
+	//
+	// Returns:
+	//   - Stats: The snapshot.
+	Stats() Stats
+}
+
+// metrics are the counters behind Stats. They are safe for concurrent use.
+type metrics struct {
+	// startTime is the start time in Unix nanoseconds. Zero if never started.
+	startTime atomic.Int64
+
+	// messages, errors and restarts are the counters of Stats.
+	messages, errors, restarts atomic.Uint64
+}
+
+// start is a private method of metrics that records a start.
+func (m *metrics) start() {
+	m.startTime.Store(time.Now().UnixNano())
+}
+
+// snapshot is a private method of metrics that returns the current values.
+//
+// Parameters:
+//   - running: Whether the handler is running.
+//
+// Returns:
+//   - Stats: The snapshot.
+func (m *metrics) snapshot(running bool) Stats {
+	stats := Stats{
+		Messages: m.messages.Load(),
+		Errors:   m.errors.Load(),
+		Restarts: m.restarts.Load(),
+	}
+
+	nano := m.startTime.Load()
+	if nano == 0 {
+		return stats
+	}
+
+	stats.StartTime = time.Unix(0, nano)
+
+	if running {
+		stats.Uptime = time.Since(stats.StartTime)
+	}
+
+	return stats
+}
+
+// Registry is a set of named handlers whose statistics can be collected at once.
+// It is safe for concurrent use.
+type Registry struct {
+	// providers are the registered handlers.
+	providers map[string]StatsProvider
+
+	// mu is the mutex to synchronize access to providers.
+	mu sync.RWMutex
+}
+
+// NewRegistry creates a new Registry.
+//
+// Returns:
+//   - *Registry: The new Registry. Never returns nil.
+func NewRegistry() *Registry {
+	return &Registry{
+		providers: make(map[string]StatsProvider),
+	}
+}
+
+// Register is a method of Registry that adds a handler. It replaces the
+// handler if the name already exists.
+//
+// Parameters:
+//   - name: The name of the handler.
+//   - p: The handler.
+//
+// If 'p' or the receiver are nil, then nothing is done.
+func (r *Registry) Register(name string, p StatsProvider) {
+	if r == nil || p == nil {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.providers[name] = p
+}
+
+// Unregister is a method of Registry that removes a handler.
+//
+// Parameters:
+//   - name: The name of the handler.
+func (r *Registry) Unregister(name string) {
+	if r == nil {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.providers, name)
+}
+
+// Snapshot is a method of Registry that collects the statistics of all the
+// registered handlers.
+//
+// Returns:
+//   - map[string]Stats: The statistics, indexed by name. Never returns nil.
+func (r *Registry) Snapshot() map[string]Stats {
+	if r == nil {
+		return make(map[string]Stats)
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	snapshot := make(map[string]Stats, len(r.providers))
+
+	for name, p := range r.providers {
+		snapshot[name] = p.Stats()
+	}
+
+	return snapshot
+}
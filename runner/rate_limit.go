@@ -0,0 +1,201 @@
+package runner
+
+import (
+	"sync"
+	"time"
+)
+
+// rateLimited is a SenderRunner whose Send is limited to a number of
+// messages per second. The ErrReceiver, HealthChecker and StatsProvider
+// methods are forwarded to the wrapped SenderRunner, if it implements them.
+type rateLimited[T any] struct {
+	SenderRunner[T]
+
+	// interval is the minimum time between two messages.
+	interval time.Duration
+
+	// reject is true if messages above the limit are rejected instead of delayed.
+	reject bool
+
+	// next is the earliest time the next message can be sent.
+	next time.Time
+
+	// done is closed by Close so that delayed Sends return, and replaced by
+	// Start.
+	done chan struct{}
+
+	// mu protects next and done.
+	mu sync.Mutex
+}
+
+// RateLimit wraps a SenderRunner so that Send blocks as long as needed to
+// stay below rps messages per second. A Send that is still waiting when the
+// rate-limited SenderRunner is closed returns false without forwarding its
+// message.
+//
+// Parameters:
+//   - h: The SenderRunner to wrap.
+//   - rps: The maximum number of messages per second.
+//
+// Returns:
+//   - SenderRunner[T]: The rate-limited SenderRunner. If rps is not positive,
+//     'h' is returned as is.
+func RateLimit[T any](h SenderRunner[T], rps float64) SenderRunner[T] {
+	return newRateLimited(h, rps, false)
+}
+
+// RateLimitReject is like RateLimit but messages above the limit are rejected:
+// Send returns false without forwarding them.
+//
+// Parameters:
+//   - h: The SenderRunner to wrap.
+//   - rps: The maximum number of messages per second.
+//
+// Returns:
+//   - SenderRunner[T]: The rate-limited SenderRunner. If rps is not positive,
+//     'h' is returned as is.
+func RateLimitReject[T any](h SenderRunner[T], rps float64) SenderRunner[T] {
+	return newRateLimited(h, rps, true)
+}
+
+// newRateLimited creates a new rate-limited SenderRunner.
+//
+// Parameters:
+//   - h: The SenderRunner to wrap.
+//   - rps: The maximum number of messages per second.
+//   - reject: Whether messages above the limit are rejected.
+//
+// Returns:
+//   - SenderRunner[T]: The rate-limited SenderRunner.
+func newRateLimited[T any](h SenderRunner[T], rps float64, reject bool) SenderRunner[T] {
+	if h == nil || rps <= 0 {
+		return h
+	}
+
+	return &rateLimited[T]{
+		SenderRunner: h,
+		interval:     time.Duration(float64(time.Second) / rps),
+		reject:       reject,
+		done:         make(chan struct{}),
+	}
+}
+
+// Start implements the Runner interface.
+func (r *rateLimited[T]) Start() {
+	r.mu.Lock()
+
+	select {
+	case <-r.done:
+		r.done = make(chan struct{})
+	default:
+	}
+
+	r.mu.Unlock()
+
+	r.SenderRunner.Start()
+}
+
+// Close implements the Runner interface.
+//
+// The Sends that are waiting return false.
+func (r *rateLimited[T]) Close() {
+	r.mu.Lock()
+
+	select {
+	case <-r.done:
+	default:
+		close(r.done)
+	}
+
+	r.mu.Unlock()
+
+	r.SenderRunner.Close()
+}
+
+// ReceiveErr implements the ErrReceiver interface.
+//
+// It returns false at once if the wrapped SenderRunner is not an ErrReceiver.
+func (r *rateLimited[T]) ReceiveErr() (error, bool) {
+	er, ok := r.SenderRunner.(ErrReceiver)
+	if !ok {
+		return nil, false
+	}
+
+	return er.ReceiveErr()
+}
+
+// Healthy implements the HealthChecker interface.
+//
+// It returns nil if the wrapped SenderRunner is not a HealthChecker.
+func (r *rateLimited[T]) Healthy() error {
+	hc, ok := r.SenderRunner.(HealthChecker)
+	if !ok {
+		return nil
+	}
+
+	return hc.Healthy()
+}
+
+// Stats implements the StatsProvider interface.
+//
+// It returns the zero Stats if the wrapped SenderRunner is not a
+// StatsProvider.
+func (r *rateLimited[T]) Stats() Stats {
+	sp, ok := r.SenderRunner.(StatsProvider)
+	if !ok {
+		return Stats{}
+	}
+
+	return sp.Stats()
+}
+
+// State implements the StateProvider interface.
+func (r *rateLimited[T]) State() RunnerState {
+	return StateOf(r.SenderRunner)
+}
+
+// ObserveState implements the StateProvider interface.
+//
+// Nothing is done if the wrapped SenderRunner is not a StateProvider.
+func (r *rateLimited[T]) ObserveState(fn func(RunnerState)) {
+	sp, ok := r.SenderRunner.(StateProvider)
+	if ok {
+		sp.ObserveState(fn)
+	}
+}
+
+// Send implements the Sender interface.
+func (r *rateLimited[T]) Send(msg T) bool {
+	now := time.Now()
+
+	r.mu.Lock()
+
+	at := r.next
+	if at.Before(now) {
+		at = now
+	}
+
+	if r.reject && at.After(now) {
+		r.mu.Unlock()
+		return false
+	}
+
+	r.next = at.Add(r.interval)
+
+	done := r.done
+
+	r.mu.Unlock()
+
+	if wait := at.Sub(now); wait > 0 {
+		timer := time.NewTimer(wait)
+		defer timer.Stop()
+
+		select {
+		case <-timer.C:
+		case <-done:
+			return false
+		}
+	}
+
+	return r.SenderRunner.Send(msg)
+}
@@ -0,0 +1,117 @@
+package runner
+
+import (
+	"time"
+)
+
+// RestartPolicy is the policy that tells a handler when its Go routine has
+// to be restarted.
+type RestartPolicy int
+
+const (
+	// RestartNever never restarts the Go routine. This is the default.
+	RestartNever RestartPolicy = iota
+
+	// RestartOnError restarts the Go routine when it exits because of an error
+	// or a panic. Exiting with NoError is not considered a failure.
+	RestartOnError
+
+	// RestartAlways restarts the Go routine whenever it exits, regardless of
+	// the reason.
+	RestartAlways
+)
+
+// String implements the fmt.Stringer interface.
+func (p RestartPolicy) String() string {
+	switch p {
+	case RestartNever:
+		return "never"
+	case RestartOnError:
+		return "on error"
+	case RestartAlways:
+		return "always"
+	default:
+		return "unknown"
+	}
+}
+
+// RestartConfig is the configuration of the restart behavior of a handler.
+type RestartConfig struct {
+	// Policy is the restart policy.
+	Policy RestartPolicy
+
+	// InitialDelay is the delay before the first restart. Each subsequent
+	// restart doubles the delay.
+	InitialDelay time.Duration
+
+	// MaxDelay is the upper bound of the delay between restarts. Zero means
+	// no upper bound.
+	MaxDelay time.Duration
+
+	// MaxAttempts is the maximum number of restarts. Zero means no limit.
+	MaxAttempts int
+}
+
+// restarter keeps track of the restarts of a handler.
+type restarter struct {
+	// config is the restart configuration.
+	config RestartConfig
+
+	// attempts is the number of restarts done so far.
+	attempts int
+
+	// delay is the delay of the next restart.
+	delay time.Duration
+}
+
+// newRestarter creates a new restarter.
+//
+// Parameters:
+//   - config: The restart configuration.
+//
+// Returns:
+//   - *restarter: The new restarter. Never returns nil.
+func newRestarter(config RestartConfig) *restarter {
+	return &restarter{
+		config: config,
+		delay:  config.InitialDelay,
+	}
+}
+
+// next tells whether the Go routine has to be restarted after it exited with
+// the given error.
+//
+// Parameters:
+//   - err: The error the Go routine exited with.
+//
+// Returns:
+//   - time.Duration: The delay to wait before restarting.
+//   - bool: True if the Go routine has to be restarted, false otherwise.
+func (r *restarter) next(err error) (time.Duration, bool) {
+	switch r.config.Policy {
+	case RestartOnError:
+		if err == nil || err == NoError {
+			return 0, false
+		}
+	case RestartAlways:
+		// Always restart.
+	default:
+		return 0, false
+	}
+
+	if r.config.MaxAttempts > 0 && r.attempts >= r.config.MaxAttempts {
+		return 0, false
+	}
+
+	r.attempts++
+
+	delay := r.delay
+
+	r.delay *= 2
+
+	if r.config.MaxDelay > 0 && r.delay > r.config.MaxDelay {
+		r.delay = r.config.MaxDelay
+	}
+
+	return delay, true
+}
@@ -0,0 +1,329 @@
+package runner
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	gcers "github.com/PlayerR9/go-errors"
+)
+
+// scheduledJob is a job registered in a Scheduler.
+type scheduledJob struct {
+	// name is the name of the job.
+	name string
+
+	// sched computes the activation times of the job.
+	sched schedule
+
+	// jitter is the upper bound of the random delay added to every activation.
+	jitter time.Duration
+
+	// job is the function to run.
+	job func() error
+}
+
+// Scheduler is a runner that invokes jobs on fixed intervals or following
+// cron expressions.
+//
+// A job is never run concurrently with itself: if an execution takes longer
+// than the time until the next activation, the missed activations are skipped.
+type Scheduler struct {
+	// jobs are the registered jobs.
+	jobs []*scheduledJob
+
+	// errChan is the channel the errors of the jobs are sent to.
+	errChan chan error
+
+	// ctx is the context of the scheduler.
+	ctx context.Context
+
+	// cancel is the cancel function of the scheduler.
+	cancel context.CancelFunc
+
+	// wg is a WaitGroup that is used to wait for the jobs to finish.
+	wg sync.WaitGroup
+
+	// mu protects jobs, errChan, ctx and cancel.
+	mu sync.Mutex
+
+	// state is the lifecycle state of the scheduler.
+	state lifecycle
+}
+
+// NewScheduler creates a new Scheduler.
+//
+// Returns:
+//   - *Scheduler: The new Scheduler. Never returns nil.
+func NewScheduler() *Scheduler {
+	return &Scheduler{}
+}
+
+// AddInterval is a method of Scheduler that registers a job that runs every
+// interval.
+//
+// Parameters:
+//   - name: The name of the job. Used to identify the job in its errors.
+//   - interval: The time between two activations.
+//   - jitter: The upper bound of the random delay added to every activation.
+//   - job: The job to run.
+//
+// Returns:
+//   - error: An error if the interval is not positive or the job is nil.
+//
+// Jobs added while the Scheduler is running are started immediately.
+func (s *Scheduler) AddInterval(name string, interval, jitter time.Duration, job func() error) error {
+	if interval <= 0 {
+		return gcers.NewErrInvalidParameter("interval must be positive")
+	}
+
+	return s.add(name, intervalSchedule(interval), jitter, job)
+}
+
+// AddCron is a method of Scheduler that registers a job that runs following a
+// cron expression.
+//
+// The expression is made of five space-separated fields: minute, hour, day of
+// the month, month and day of the week. Each field accepts '*', single
+// values, ranges ("a-b"), steps ("*/n" or "a-b/n") and comma-separated lists.
+//
+// Parameters:
+//   - name: The name of the job. Used to identify the job in its errors.
+//   - expr: The cron expression.
+//   - jitter: The upper bound of the random delay added to every activation.
+//   - job: The job to run.
+//
+// Returns:
+//   - error: An error if the expression is invalid or the job is nil.
+//
+// Jobs added while the Scheduler is running are started immediately.
+func (s *Scheduler) AddCron(name, expr string, jitter time.Duration, job func() error) error {
+	sched, err := parseCron(expr)
+	if err != nil {
+		return err
+	}
+
+	return s.add(name, sched, jitter, job)
+}
+
+// add is a private method of Scheduler that registers a job.
+//
+// Parameters:
+//   - name: The name of the job.
+//   - sched: The schedule of the job.
+//   - jitter: The upper bound of the random delay added to every activation.
+//   - job: The job to run.
+//
+// Returns:
+//   - error: An error if the job is nil.
+func (s *Scheduler) add(name string, sched schedule, jitter time.Duration, job func() error) error {
+	if s == nil {
+		return errors.New("receiver must not be nil")
+	} else if job == nil {
+		return gcers.NewErrNilParameter("job")
+	}
+
+	sj := &scheduledJob{
+		name:   name,
+		sched:  sched,
+		jitter: max(jitter, 0),
+		job:    job,
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.jobs = append(s.jobs, sj)
+
+	if s.ctx != nil && s.ctx.Err() == nil {
+		s.wg.Add(1)
+
+		go s.loop(s.ctx, s.errChan, sj)
+	}
+
+	return nil
+}
+
+// Start implements the Runner interface.
+func (s *Scheduler) Start() {
+	if s == nil {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.ctx != nil && s.ctx.Err() == nil {
+		return
+	}
+
+	s.state.set(Starting)
+
+	s.errChan = make(chan error)
+	s.ctx, s.cancel = context.WithCancel(context.Background())
+
+	s.wg.Add(len(s.jobs))
+
+	for _, sj := range s.jobs {
+		go s.loop(s.ctx, s.errChan, sj)
+	}
+
+	s.state.set(Running)
+}
+
+// Close implements the Runner interface.
+func (s *Scheduler) Close() {
+	if s == nil {
+		return
+	}
+
+	s.mu.Lock()
+
+	if s.ctx == nil || s.ctx.Err() != nil {
+		s.mu.Unlock()
+		return
+	}
+
+	s.state.set(Stopping)
+
+	s.cancel()
+
+	errChan := s.errChan
+
+	s.mu.Unlock()
+
+	s.wg.Wait()
+
+	close(errChan)
+
+	s.state.set(Stopped)
+}
+
+// IsClosed implements the Runner interface.
+func (s *Scheduler) IsClosed() bool {
+	if s == nil {
+		return true
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.ctx == nil || s.ctx.Err() != nil
+}
+
+// State implements the StateProvider interface.
+func (s *Scheduler) State() RunnerState {
+	if s == nil {
+		return Idle
+	}
+
+	return s.state.get()
+}
+
+// ObserveState implements the StateProvider interface.
+func (s *Scheduler) ObserveState(fn func(RunnerState)) {
+	if s == nil {
+		return
+	}
+
+	s.state.observe(fn)
+}
+
+// Healthy implements the HealthChecker interface.
+func (s *Scheduler) Healthy() error {
+	if s.IsClosed() {
+		return NotRunning
+	}
+
+	return nil
+}
+
+// ReceiveErr is a method of Scheduler that receives the next error reported
+// by a job. Errors are of type *ErrJob.
+//
+// Returns:
+//   - error: The error received.
+//   - bool: False if the Scheduler is closed, true otherwise.
+func (s *Scheduler) ReceiveErr() (error, bool) {
+	if s == nil {
+		return nil, false
+	}
+
+	s.mu.Lock()
+	errChan := s.errChan
+	s.mu.Unlock()
+
+	if errChan == nil {
+		return nil, false
+	}
+
+	err, ok := <-errChan
+	return err, ok
+}
+
+// loop is a private method of Scheduler that runs a job on every activation
+// until the context is done.
+//
+// Parameters:
+//   - ctx: The context of the scheduler.
+//   - errChan: The channel the errors are sent to.
+//   - sj: The job to run.
+func (s *Scheduler) loop(ctx context.Context, errChan chan<- error, sj *scheduledJob) {
+	defer s.wg.Done()
+
+	for {
+		now := time.Now()
+
+		next := sj.sched.next(now)
+		if next.IsZero() {
+			return
+		}
+
+		delay := next.Sub(now)
+
+		if sj.jitter > 0 {
+			delay += rand.N(sj.jitter)
+		}
+
+		timer := time.NewTimer(delay)
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		err := runJob(sj.job)
+		if err == nil {
+			continue
+		}
+
+		select {
+		case errChan <- NewErrJob(sj.name, err):
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// runJob runs a job and recovers from its panics.
+//
+// Parameters:
+//   - job: The job to run.
+//
+// Returns:
+//   - error: The error of the job, or an *ErrPanic if it panicked.
+func runJob(job func() error) (err error) {
+	defer func() {
+		r := recover()
+
+		if r != nil {
+			err = NewErrPanic(r)
+		}
+	}()
+
+	return job()
+}
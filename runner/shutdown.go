@@ -0,0 +1,190 @@
+package runner
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+)
+
+// namedRunner is a runner registered in a ShutdownManager.
+type namedRunner struct {
+	// name is the name of the runner.
+	name string
+
+	// runner is the runner to close.
+	runner Runner
+}
+
+// ShutdownManager closes a list of runners, in order, when the process
+// receives SIGINT or SIGTERM.
+//
+// Runners are given a grace period to close. If they do not close in time,
+// the shutdown is reported as failed and, if they are still closing once the
+// force timeout elapses too, the process exits with status 1. A second signal
+// received during the shutdown also makes the process exit immediately.
+type ShutdownManager struct {
+	// runners are the runners to close, in order.
+	runners []namedRunner
+
+	// grace is the time given to the runners to close.
+	grace time.Duration
+
+	// force is the additional time after the grace period before the process
+	// is killed. Zero disables the force kill.
+	force time.Duration
+
+	// exit terminates the process.
+	exit func(code int)
+
+	// mu protects runners.
+	mu sync.Mutex
+}
+
+// NewShutdownManager creates a new ShutdownManager.
+//
+// Parameters:
+//   - grace: The time given to the runners to close.
+//   - force: The additional time after the grace period before the process is
+//     killed. Zero or less disables the force kill.
+//
+// Returns:
+//   - *ShutdownManager: The new ShutdownManager. Never returns nil.
+func NewShutdownManager(grace, force time.Duration) *ShutdownManager {
+	return &ShutdownManager{
+		grace: grace,
+		force: max(force, 0),
+		exit:  os.Exit,
+	}
+}
+
+// Register is a method of ShutdownManager that adds a runner. Runners are
+// closed in the order they are registered.
+//
+// Parameters:
+//   - name: The name of the runner. Used to report which runner timed out.
+//   - r: The runner.
+//
+// If 'r' or the receiver are nil, then nothing is done.
+func (m *ShutdownManager) Register(name string, r Runner) {
+	if m == nil || r == nil {
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.runners = append(m.runners, namedRunner{
+		name:   name,
+		runner: r,
+	})
+}
+
+// Wait is a method of ShutdownManager that blocks until the process receives
+// SIGINT or SIGTERM, or until the context is done, and then shuts down.
+//
+// Parameters:
+//   - ctx: The context to observe. If nil, only signals trigger the shutdown.
+//
+// Returns:
+//   - error: An *ErrShutdownTimeout if the runners could not be closed within
+//     the grace period. Nil otherwise.
+func (m *ShutdownManager) Wait(ctx context.Context) error {
+	if m == nil {
+		return nil
+	}
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	sigChan := make(chan os.Signal, 1)
+
+	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(sigChan)
+
+	select {
+	case <-sigChan:
+	case <-ctx.Done():
+	}
+
+	stop := make(chan struct{})
+	defer close(stop)
+
+	go func() {
+		select {
+		case <-sigChan:
+			m.exit(1)
+		case <-stop:
+		}
+	}()
+
+	return m.Shutdown()
+}
+
+// Shutdown is a method of ShutdownManager that closes all the registered
+// runners in order.
+//
+// Returns:
+//   - error: An *ErrShutdownTimeout if the runners could not be closed within
+//     the grace period. Nil otherwise.
+func (m *ShutdownManager) Shutdown() error {
+	if m == nil {
+		return nil
+	}
+
+	m.mu.Lock()
+	runners := make([]namedRunner, len(m.runners))
+	copy(runners, m.runners)
+	m.mu.Unlock()
+
+	if len(runners) == 0 {
+		return nil
+	}
+
+	var current atomic.Int64
+
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		for i, nr := range runners {
+			current.Store(int64(i))
+
+			nr.runner.Close()
+		}
+	}()
+
+	timer := time.NewTimer(m.grace)
+
+	select {
+	case <-done:
+		timer.Stop()
+
+		return nil
+	case <-timer.C:
+	}
+
+	if m.force > 0 {
+		go func() {
+			select {
+			case <-done:
+			case <-time.After(m.force):
+				m.exit(1)
+			}
+		}()
+	}
+
+	var pending string
+
+	i := current.Load()
+	if i >= 0 && i < int64(len(runners)) {
+		pending = runners[i].name
+	}
+
+	return NewErrShutdownTimeout(pending)
+}
@@ -0,0 +1,167 @@
+package runner
+
+import (
+	"slices"
+	"sync"
+
+	sbj "github.com/PlayerR9/safe/subject"
+)
+
+// RunnerState is the lifecycle state of a runner.
+type RunnerState int
+
+const (
+	// Idle is the state of a runner that was never started.
+	Idle RunnerState = iota
+
+	// Starting is the state of a runner that is being started.
+	Starting
+
+	// Running is the state of a runner that is doing its work.
+	Running
+
+	// Stopping is the state of a runner that is being closed.
+	Stopping
+
+	// Stopped is the state of a runner that was closed or that finished on its
+	// own.
+	Stopped
+
+	// Failed is the state of a runner that gave up because of an error.
+	Failed
+)
+
+// String implements the fmt.Stringer interface.
+func (s RunnerState) String() string {
+	switch s {
+	case Idle:
+		return "idle"
+	case Starting:
+		return "starting"
+	case Running:
+		return "running"
+	case Stopping:
+		return "stopping"
+	case Stopped:
+		return "stopped"
+	case Failed:
+		return "failed"
+	default:
+		return "unknown"
+	}
+}
+
+// StateProvider is the interface that wraps the State and ObserveState methods.
+//
+// Unlike IsClosed, which is true both before Start and after Close, State
+// tells all the phases of the lifecycle apart.
+type StateProvider interface {
+	// State returns the current lifecycle state.
+	//
+	// Returns:
+	//   - RunnerState: The current state.
+	State() RunnerState
+
+	// ObserveState registers a function that is called on every state change.
+	// The function must not start or close the runner.
+	//
+	// Parameters:
+	//   - fn: The function to call with the new state.
+	ObserveState(fn func(RunnerState))
+}
+
+// StateOf returns the lifecycle state of a runner.
+//
+// Parameters:
+//   - r: The runner.
+//
+// Returns:
+//   - RunnerState: The state of the runner. If 'r' is not a StateProvider,
+//     the state is derived from IsClosed: Running if it is not closed and
+//     Stopped otherwise. Idle if 'r' is nil.
+func StateOf(r Runner) RunnerState {
+	if r == nil {
+		return Idle
+	}
+
+	sp, ok := r.(StateProvider)
+	if ok {
+		return sp.State()
+	}
+
+	if r.IsClosed() {
+		return Stopped
+	}
+
+	return Running
+}
+
+// lifecycle tracks the state of a runner. Its zero value is Idle and ready to
+// use.
+type lifecycle struct {
+	// subject holds the state and notifies its changes.
+	subject sbj.Subject[RunnerState]
+
+	// mu serializes the transitions and their notifications.
+	mu sync.Mutex
+}
+
+// get is a private method of lifecycle that returns the current state.
+//
+// Returns:
+//   - RunnerState: The current state.
+func (l *lifecycle) get() RunnerState {
+	return l.subject.State()
+}
+
+// set is a private method of lifecycle that changes the state and notifies
+// the observers. Nothing is done if the state does not change.
+//
+// Parameters:
+//   - state: The new state.
+func (l *lifecycle) set(state RunnerState) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.subject.State() == state {
+		return
+	}
+
+	l.subject.Set(state)
+}
+
+// setIf is like set but it only changes the state if the current one is
+// among the given ones.
+//
+// Parameters:
+//   - state: The new state.
+//   - from: The states the transition is allowed from.
+//
+// Returns:
+//   - bool: True if the state was changed, false otherwise.
+func (l *lifecycle) setIf(state RunnerState, from ...RunnerState) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	curr := l.subject.State()
+
+	if curr == state || !slices.Contains(from, curr) {
+		return false
+	}
+
+	l.subject.Set(state)
+
+	return true
+}
+
+// observe is a private method of lifecycle that registers an observer.
+//
+// Parameters:
+//   - fn: The function to call with the new state. Ignored if nil.
+func (l *lifecycle) observe(fn func(RunnerState)) {
+	if fn == nil {
+		return
+	}
+
+	l.subject.SetObserver(fn)
+}
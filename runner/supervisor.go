@@ -0,0 +1,435 @@
+package runner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+)
+
+// SupervisorStrategy is the strategy a Supervisor follows when a child terminates.
+type SupervisorStrategy int
+
+const (
+	// OneForOne restarts only the child that terminated.
+	OneForOne SupervisorStrategy = iota
+
+	// OneForAll closes and restarts all the children when one of them terminates.
+	OneForAll
+)
+
+// String implements the fmt.Stringer interface.
+func (s SupervisorStrategy) String() string {
+	switch s {
+	case OneForOne:
+		return "one for one"
+	case OneForAll:
+		return "one for all"
+	default:
+		return "unknown"
+	}
+}
+
+// supervisedChild is a child of a Supervisor.
+type supervisedChild struct {
+	// name is the name of the child.
+	name string
+
+	// runner is the runner of the child.
+	runner ErrRunner
+
+	// gen is incremented every time the child is restarted so that exits of
+	// previous runs are ignored.
+	gen int
+}
+
+// childExit is the termination of a child.
+type childExit struct {
+	// child is the child that terminated.
+	child *supervisedChild
+
+	// gen is the generation of the child that terminated.
+	gen int
+
+	// err is the last error reported by the child.
+	err error
+}
+
+// Supervisor is a runner that owns child runners and restarts them when they
+// terminate, in the manner of Erlang supervisors.
+//
+// A child is considered terminated when its error channel is closed while the
+// Supervisor is running. Errors reported by children that keep running (e.g.,
+// HandlerSend) do not trigger restarts.
+type Supervisor struct {
+	// children are the children of the supervisor, in start order.
+	children []*supervisedChild
+
+	// strategy is the restart strategy.
+	strategy SupervisorStrategy
+
+	// maxRestarts is the maximum number of restarts allowed within window.
+	maxRestarts int
+
+	// window is the period over which restarts are counted.
+	window time.Duration
+
+	// onEscalate is called when the restart intensity is exceeded.
+	onEscalate func(child string, err error)
+
+	// restarts are the times of the recent restarts.
+	restarts []time.Time
+
+	// exits receives the terminations of the children.
+	exits chan childExit
+
+	// ctx is the context of the supervisor.
+	ctx context.Context
+
+	// cancel is the cancel function of the supervisor.
+	cancel context.CancelFunc
+
+	// wg waits for the monitors and the main loop.
+	wg sync.WaitGroup
+
+	// mu protects the fields of the supervisor.
+	mu sync.Mutex
+
+	// state is the lifecycle state of the supervisor.
+	state lifecycle
+}
+
+// NewSupervisor creates a new Supervisor.
+//
+// Parameters:
+//   - strategy: The restart strategy.
+//   - maxRestarts: The maximum number of restarts allowed within window. If
+//     exceeded, the Supervisor closes all its children and escalates.
+//   - window: The period over which restarts are counted.
+//
+// Returns:
+//   - *Supervisor: The new Supervisor. Never returns nil.
+func NewSupervisor(strategy SupervisorStrategy, maxRestarts int, window time.Duration) *Supervisor {
+	return &Supervisor{
+		strategy:    strategy,
+		maxRestarts: max(maxRestarts, 0),
+		window:      window,
+	}
+}
+
+// AddChild is a method of Supervisor that adds a child. Children are started
+// in the order they are added and closed in reverse order. It only takes effect
+// on the next call to Start.
+//
+// Parameters:
+//   - name: The name of the child.
+//   - r: The runner of the child.
+//
+// If 'r' or the receiver are nil, then nothing is done.
+func (s *Supervisor) AddChild(name string, r ErrRunner) {
+	if s == nil || r == nil {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.children = append(s.children, &supervisedChild{
+		name:   name,
+		runner: r,
+	})
+}
+
+// OnEscalate is a method of Supervisor that sets the function called when the
+// restart intensity is exceeded. By then, all the children have been closed.
+//
+// Parameters:
+//   - fn: The function to call with the name and the last error of the child
+//     whose termination exceeded the intensity.
+func (s *Supervisor) OnEscalate(fn func(child string, err error)) {
+	if s == nil {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.onEscalate = fn
+}
+
+// Start implements the Runner interface.
+func (s *Supervisor) Start() {
+	if s == nil {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.ctx != nil && s.ctx.Err() == nil {
+		return
+	}
+
+	s.state.set(Starting)
+
+	s.ctx, s.cancel = context.WithCancel(context.Background())
+	s.exits = make(chan childExit)
+	s.restarts = nil
+
+	for _, c := range s.children {
+		s.startChild(c)
+	}
+
+	s.wg.Add(1)
+
+	go s.loop(s.ctx)
+
+	s.state.set(Running)
+}
+
+// Close implements the Runner interface.
+func (s *Supervisor) Close() {
+	if s == nil {
+		return
+	}
+
+	s.mu.Lock()
+
+	if s.ctx == nil || s.ctx.Err() != nil {
+		s.mu.Unlock()
+		return
+	}
+
+	s.state.set(Stopping)
+
+	s.cancel()
+
+	s.closeChildren()
+
+	s.mu.Unlock()
+
+	s.wg.Wait()
+
+	s.state.set(Stopped)
+}
+
+// IsClosed implements the Runner interface.
+func (s *Supervisor) IsClosed() bool {
+	if s == nil {
+		return true
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.ctx == nil || s.ctx.Err() != nil
+}
+
+// State implements the StateProvider interface.
+//
+// The Supervisor is Failed once it has escalated.
+func (s *Supervisor) State() RunnerState {
+	if s == nil {
+		return Idle
+	}
+
+	return s.state.get()
+}
+
+// ObserveState implements the StateProvider interface.
+func (s *Supervisor) ObserveState(fn func(RunnerState)) {
+	if s == nil {
+		return
+	}
+
+	s.state.observe(fn)
+}
+
+// Healthy implements the HealthChecker interface.
+//
+// The Supervisor is healthy if it is running and all of its children that
+// implement HealthChecker are healthy.
+func (s *Supervisor) Healthy() error {
+	if s.IsClosed() {
+		return NotRunning
+	}
+
+	s.mu.Lock()
+
+	checkers := make(map[string]HealthChecker, len(s.children))
+
+	for _, c := range s.children {
+		hc, ok := c.runner.(HealthChecker)
+		if ok {
+			checkers[c.name] = hc
+		}
+	}
+
+	s.mu.Unlock()
+
+	errMap := CheckAll(checkers)
+
+	names := make([]string, 0, len(errMap))
+
+	for name := range errMap {
+		names = append(names, name)
+	}
+
+	slices.Sort(names)
+
+	errs := make([]error, 0, len(names))
+
+	for _, name := range names {
+		errs = append(errs, fmt.Errorf("%s: %w", name, errMap[name]))
+	}
+
+	return errors.Join(errs...)
+}
+
+// startChild is a private method of Supervisor that starts a child and its
+// monitor. The caller must hold the lock.
+//
+// Parameters:
+//   - c: The child to start.
+func (s *Supervisor) startChild(c *supervisedChild) {
+	c.gen++
+
+	c.runner.Start()
+
+	s.wg.Add(1)
+
+	go s.monitor(s.ctx, c, c.gen)
+}
+
+// closeChildren is a private method of Supervisor that closes all the children
+// in reverse order. The caller must hold the lock.
+func (s *Supervisor) closeChildren() {
+	for i := len(s.children) - 1; i >= 0; i-- {
+		c := s.children[i]
+
+		// Invalidate the current run so that its exit is ignored.
+		c.gen++
+
+		c.runner.Close()
+	}
+}
+
+// monitor is a private method of Supervisor that watches a child until it
+// terminates.
+//
+// Parameters:
+//   - ctx: The context of the supervisor.
+//   - c: The child to watch.
+//   - gen: The generation of the child.
+func (s *Supervisor) monitor(ctx context.Context, c *supervisedChild, gen int) {
+	defer s.wg.Done()
+
+	var last error
+
+	for {
+		err, ok := c.runner.ReceiveErr()
+		if !ok {
+			break
+		}
+
+		last = err
+	}
+
+	select {
+	case s.exits <- childExit{child: c, gen: gen, err: last}:
+	case <-ctx.Done():
+	}
+}
+
+// loop is a private method of Supervisor that handles the terminations of the
+// children until the context is done.
+//
+// Parameters:
+//   - ctx: The context of the supervisor.
+func (s *Supervisor) loop(ctx context.Context) {
+	defer s.wg.Done()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case ev := <-s.exits:
+			if !s.handleExit(ev) {
+				return
+			}
+		}
+	}
+}
+
+// handleExit is a private method of Supervisor that restarts the children
+// after a termination, or escalates if the restart intensity is exceeded.
+//
+// Parameters:
+//   - ev: The termination.
+//
+// Returns:
+//   - bool: True if the supervisor keeps running, false otherwise.
+func (s *Supervisor) handleExit(ev childExit) bool {
+	s.mu.Lock()
+
+	if s.ctx.Err() != nil {
+		s.mu.Unlock()
+		return false
+	}
+
+	if ev.gen != ev.child.gen {
+		s.mu.Unlock()
+		return true
+	}
+
+	now := time.Now()
+
+	recent := s.restarts[:0]
+
+	for _, t := range s.restarts {
+		if now.Sub(t) < s.window {
+			recent = append(recent, t)
+		}
+	}
+
+	s.restarts = recent
+
+	if len(s.restarts) >= s.maxRestarts {
+		s.cancel()
+		s.closeChildren()
+
+		s.state.set(Failed)
+
+		fn := s.onEscalate
+
+		s.mu.Unlock()
+
+		if fn != nil {
+			fn(ev.child.name, ev.err)
+		}
+
+		return false
+	}
+
+	s.restarts = append(s.restarts, now)
+
+	switch s.strategy {
+	case OneForAll:
+		s.closeChildren()
+
+		for _, c := range s.children {
+			s.startChild(c)
+		}
+	default:
+		// Release whatever the terminated run still holds before restarting it.
+		ev.child.runner.Close()
+
+		s.startChild(ev.child)
+	}
+
+	s.mu.Unlock()
+
+	return true
+}
@@ -0,0 +1,293 @@
+package runner
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// StallBuffer is the number of stalls a Watchdog keeps until they are received
+// with ReceiveErr. Further stalls are dropped.
+const StallBuffer int = 16
+
+// watchedRunner is a runner registered in a Watchdog.
+type watchedRunner struct {
+	// name is the name of the runner.
+	name string
+
+	// runner is the watched runner.
+	runner Runner
+
+	// last is the time of the last heartbeat, in nanoseconds since the epoch.
+	last atomic.Int64
+
+	// stalled is true once the current stall has been reported.
+	stalled atomic.Bool
+}
+
+// beat is a private method of watchedRunner that records a heartbeat.
+func (wr *watchedRunner) beat() {
+	wr.last.Store(time.Now().UnixNano())
+	wr.stalled.Store(false)
+}
+
+// Watchdog is a runner that detects hung Go routines. Watched runners must
+// call the Beat function they are given at least once per threshold; when one
+// of them misses its heartbeat, an *ErrStalled is reported and, if enabled,
+// the runner is restarted.
+//
+// A stall is only reported once; the runner has to beat again before a new
+// stall can be reported. Closed runners are not checked. Stalls are reported
+// without blocking, so that the runners are still checked and restarted when
+// nobody calls ReceiveErr; up to StallBuffer of them are kept and the others
+// are dropped.
+type Watchdog struct {
+	// threshold is the maximum time allowed between two heartbeats.
+	threshold time.Duration
+
+	// restart is true if stalled runners are restarted.
+	restart bool
+
+	// watched are the watched runners.
+	watched []*watchedRunner
+
+	// errChan is the channel the stalls are sent to.
+	errChan chan error
+
+	// ctx is the context of the watchdog.
+	ctx context.Context
+
+	// cancel is the cancel function of the watchdog.
+	cancel context.CancelFunc
+
+	// wg is a WaitGroup that is used to wait for the Go routines to finish.
+	wg sync.WaitGroup
+
+	// mu protects the fields of the watchdog.
+	mu sync.Mutex
+
+	// state is the lifecycle state of the watchdog.
+	state lifecycle
+}
+
+// NewWatchdog creates a new Watchdog.
+//
+// Parameters:
+//   - threshold: The maximum time allowed between two heartbeats. Values less
+//     than a millisecond are treated as a millisecond.
+//   - restart: Whether stalled runners are closed and started again.
+//
+// Returns:
+//   - *Watchdog: The new Watchdog. Never returns nil.
+//
+// Behaviors:
+//   - A restart can only succeed if the hung Go routine eventually returns, as
+//     Close waits for it. Restarts are done in the background so that they do
+//     not block the Watchdog.
+func NewWatchdog(threshold time.Duration, restart bool) *Watchdog {
+	return &Watchdog{
+		threshold: max(threshold, time.Millisecond),
+		restart:   restart,
+	}
+}
+
+// Watch is a method of Watchdog that registers a runner.
+//
+// Parameters:
+//   - name: The name of the runner. Used to identify the runner in its errors.
+//   - r: The runner to watch.
+//
+// Returns:
+//   - func(): The Beat function the Go routine of the runner must call to
+//     signal that it is alive. Never returns nil.
+//
+// The heartbeat is considered received at registration time. If 'r' or the
+// receiver are nil, the returned function does nothing.
+func (w *Watchdog) Watch(name string, r Runner) func() {
+	if w == nil || r == nil {
+		return func() {}
+	}
+
+	wr := &watchedRunner{
+		name:   name,
+		runner: r,
+	}
+
+	wr.beat()
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.watched = append(w.watched, wr)
+
+	return wr.beat
+}
+
+// Start implements the Runner interface.
+//
+// The heartbeats of all the watched runners are considered received at start
+// time.
+func (w *Watchdog) Start() {
+	if w == nil {
+		return
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.ctx != nil && w.ctx.Err() == nil {
+		return
+	}
+
+	w.state.set(Starting)
+
+	for _, wr := range w.watched {
+		wr.beat()
+	}
+
+	w.errChan = make(chan error, StallBuffer)
+	w.ctx, w.cancel = context.WithCancel(context.Background())
+
+	w.wg.Add(1)
+
+	go w.loop(w.ctx, w.errChan)
+
+	w.state.set(Running)
+}
+
+// Close implements the Runner interface.
+//
+// It does not close the watched runners.
+func (w *Watchdog) Close() {
+	if w == nil {
+		return
+	}
+
+	w.mu.Lock()
+
+	if w.ctx == nil || w.ctx.Err() != nil {
+		w.mu.Unlock()
+		return
+	}
+
+	w.state.set(Stopping)
+
+	w.cancel()
+
+	errChan := w.errChan
+
+	w.mu.Unlock()
+
+	w.wg.Wait()
+
+	close(errChan)
+
+	w.state.set(Stopped)
+}
+
+// IsClosed implements the Runner interface.
+func (w *Watchdog) IsClosed() bool {
+	if w == nil {
+		return true
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return w.ctx == nil || w.ctx.Err() != nil
+}
+
+// State implements the StateProvider interface.
+func (w *Watchdog) State() RunnerState {
+	if w == nil {
+		return Idle
+	}
+
+	return w.state.get()
+}
+
+// ObserveState implements the StateProvider interface.
+func (w *Watchdog) ObserveState(fn func(RunnerState)) {
+	if w == nil {
+		return
+	}
+
+	w.state.observe(fn)
+}
+
+// ReceiveErr implements the ErrReceiver interface.
+//
+// Errors are of type *ErrStalled.
+func (w *Watchdog) ReceiveErr() (error, bool) {
+	if w == nil {
+		return nil, false
+	}
+
+	w.mu.Lock()
+	errChan := w.errChan
+	w.mu.Unlock()
+
+	if errChan == nil {
+		return nil, false
+	}
+
+	err, ok := <-errChan
+	return err, ok
+}
+
+// loop is a private method of Watchdog that checks the heartbeats until the
+// context is done.
+//
+// Parameters:
+//   - ctx: The context of the watchdog.
+//   - errChan: The channel the stalls are sent to.
+func (w *Watchdog) loop(ctx context.Context, errChan chan<- error) {
+	defer w.wg.Done()
+
+	ticker := time.NewTicker(w.threshold / 4)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		w.mu.Lock()
+		watched := w.watched
+		w.mu.Unlock()
+
+		for _, wr := range watched {
+			since := time.Since(time.Unix(0, wr.last.Load()))
+
+			if since <= w.threshold || wr.runner.IsClosed() || wr.stalled.Swap(true) {
+				continue
+			}
+
+			if w.restart {
+				// Not waited for by Close as the hung Go routine may never return.
+				go restartRunner(wr)
+			}
+
+			select {
+			case errChan <- NewErrStalled(wr.name, since):
+			default:
+				// Nobody is receiving; drop the stall rather than stop checking.
+			}
+		}
+	}
+}
+
+// restartRunner closes and starts a stalled runner again.
+//
+// Parameters:
+//   - wr: The stalled runner.
+func restartRunner(wr *watchedRunner) {
+	wr.runner.Close()
+
+	wr.beat()
+
+	wr.runner.Start()
+}
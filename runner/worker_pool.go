@@ -0,0 +1,436 @@
+package runner
+
+import (
+	"context"
+	"sync"
+)
+
+// Future is the result of a task submitted to a WorkerPool. It is safe for
+// concurrent use.
+type Future[O any] struct {
+	// done is closed once the result is available.
+	done chan struct{}
+
+	// value is the output of the task.
+	value O
+
+	// err is the error of the task.
+	err error
+}
+
+// newFuture creates a new Future.
+//
+// Returns:
+//   - *Future[O]: The new Future. Never returns nil.
+func newFuture[O any]() *Future[O] {
+	return &Future[O]{
+		done: make(chan struct{}),
+	}
+}
+
+// resolve is a private method of Future that sets the result.
+//
+// Parameters:
+//   - value: The output of the task.
+//   - err: The error of the task.
+func (f *Future[O]) resolve(value O, err error) {
+	f.value = value
+	f.err = err
+
+	close(f.done)
+}
+
+// Done is a method of Future that returns a channel that is closed once the
+// result is available.
+//
+// Returns:
+//   - <-chan struct{}: The channel. Never returns nil.
+func (f *Future[O]) Done() <-chan struct{} {
+	return f.done
+}
+
+// Get is a method of Future that blocks until the result is available.
+//
+// Returns:
+//   - O: The output of the task.
+//   - error: The error of the task.
+func (f *Future[O]) Get() (O, error) {
+	<-f.done
+
+	return f.value, f.err
+}
+
+// GetCtx is like Get but it stops waiting when the context is done.
+//
+// Parameters:
+//   - ctx: The context to observe.
+//
+// Returns:
+//   - O: The output of the task.
+//   - error: The error of the task, or the context's error if it is done first.
+func (f *Future[O]) GetCtx(ctx context.Context) (O, error) {
+	select {
+	case <-f.done:
+		return f.value, f.err
+	case <-ctx.Done():
+		return *new(O), ctx.Err()
+	}
+}
+
+// poolTask is a task queued in a WorkerPool.
+type poolTask[I, O any] struct {
+	// elem is the element to process.
+	elem I
+
+	// future receives the result.
+	future *Future[O]
+}
+
+// WorkerPool is a pool of Go routines that process submitted elements and
+// deliver each result through a Future.
+type WorkerPool[I, O any] struct {
+	// do_fn is the function that processes an element.
+	do_fn DoFunc[I, O]
+
+	// queueSize is the capacity of the queue.
+	queueSize int
+
+	// workers is the number of workers.
+	workers int
+
+	// queue holds the submitted tasks. Nil if the pool is not running.
+	queue chan poolTask[I, O]
+
+	// quit tells a worker to exit when the pool shrinks.
+	quit chan struct{}
+
+	// ctx is the context passed to do_fn. It is cancelled when a shutdown
+	// exceeds its deadline.
+	ctx context.Context
+
+	// cancel is the cancel function of ctx.
+	cancel context.CancelFunc
+
+	// wg waits for the workers.
+	wg sync.WaitGroup
+
+	// closing is done once Shutdown is called, so that the Submits blocked on
+	// a full queue give up. It is cancelled before the lock is taken.
+	closing context.Context
+
+	// stopSubmits cancels closing.
+	stopSubmits context.CancelFunc
+
+	// submitting counts the Submits in flight so that the queue is only
+	// closed once they are done.
+	submitting sync.WaitGroup
+
+	// drained is closed once the workers of the previous run have exited. Nil
+	// if the pool was never shut down.
+	drained chan struct{}
+
+	// mu protects queue, quit, ctx, cancel, closing, stopSubmits, drained and
+	// workers.
+	mu sync.RWMutex
+
+	// state is the lifecycle state of the pool.
+	state lifecycle
+}
+
+// NewWorkerPool creates a new WorkerPool.
+//
+// Parameters:
+//   - do_fn: The function that processes an element.
+//   - workers: The initial number of workers. Values less than 1 are treated as 1.
+//   - queueSize: The capacity of the queue. Submit blocks when it is full.
+//
+// Returns:
+//   - *WorkerPool[I, O]: The new WorkerPool. Nil if do_fn is nil.
+//   - bool: True if the WorkerPool was created successfully, false otherwise.
+//
+// The workers are not started automatically.
+func NewWorkerPool[I, O any](do_fn DoFunc[I, O], workers, queueSize int) (*WorkerPool[I, O], bool) {
+	if do_fn == nil {
+		return nil, false
+	}
+
+	return &WorkerPool[I, O]{
+		do_fn:     do_fn,
+		workers:   max(workers, 1),
+		queueSize: max(queueSize, 0),
+	}, true
+}
+
+// Start implements the Runner interface.
+func (p *WorkerPool[I, O]) Start() {
+	if p == nil {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.queue != nil {
+		return
+	}
+
+	p.state.set(Starting)
+
+	if p.drained != nil {
+		// Workers of a timed out shutdown may still be running.
+		<-p.drained
+	}
+
+	p.queue = make(chan poolTask[I, O], p.queueSize)
+	p.quit = make(chan struct{})
+	p.ctx, p.cancel = context.WithCancel(context.Background())
+	p.closing, p.stopSubmits = context.WithCancel(context.Background())
+
+	p.wg.Add(p.workers)
+
+	for i := 0; i < p.workers; i++ {
+		go p.work(p.ctx, p.queue, p.quit)
+	}
+
+	p.state.set(Running)
+}
+
+// Close implements the Runner interface.
+//
+// It is equivalent to Shutdown with a context that is never done.
+func (p *WorkerPool[I, O]) Close() {
+	_ = p.Shutdown(context.Background())
+}
+
+// IsClosed implements the Runner interface.
+func (p *WorkerPool[I, O]) IsClosed() bool {
+	if p == nil {
+		return true
+	}
+
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	return p.queue == nil
+}
+
+// State implements the StateProvider interface.
+//
+// The pool is Stopping until the workers have processed the queued elements,
+// even if Shutdown returned early because its context was done.
+func (p *WorkerPool[I, O]) State() RunnerState {
+	if p == nil {
+		return Idle
+	}
+
+	return p.state.get()
+}
+
+// ObserveState implements the StateProvider interface.
+func (p *WorkerPool[I, O]) ObserveState(fn func(RunnerState)) {
+	if p == nil {
+		return
+	}
+
+	p.state.observe(fn)
+}
+
+// Submit is a method of WorkerPool that queues an element for processing. It
+// blocks while the queue is full.
+//
+// Parameters:
+//   - elem: The element to process.
+//
+// Returns:
+//   - *Future[O]: The future result. If the pool is not running, or if it is
+//     shut down while the queue is full, the future is already resolved with
+//     NotRunning. Never returns nil.
+func (p *WorkerPool[I, O]) Submit(elem I) *Future[O] {
+	future := newFuture[O]()
+
+	if p == nil {
+		future.resolve(*new(O), NotRunning)
+		return future
+	}
+
+	p.mu.RLock()
+
+	queue, closing := p.queue, p.closing
+	if queue == nil || closing.Err() != nil {
+		p.mu.RUnlock()
+
+		future.resolve(*new(O), NotRunning)
+		return future
+	}
+
+	p.submitting.Add(1)
+
+	p.mu.RUnlock()
+
+	defer p.submitting.Done()
+
+	select {
+	case queue <- poolTask[I, O]{elem: elem, future: future}:
+	case <-closing.Done():
+		future.resolve(*new(O), NotRunning)
+	}
+
+	return future
+}
+
+// Resize is a method of WorkerPool that changes the number of workers. When
+// shrinking, workers exit after finishing their current task.
+//
+// Parameters:
+//   - n: The new number of workers. Values less than 1 are treated as 1.
+func (p *WorkerPool[I, O]) Resize(n int) {
+	if p == nil {
+		return
+	}
+
+	n = max(n, 1)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	diff := n - p.workers
+
+	p.workers = n
+
+	if p.queue == nil {
+		return
+	}
+
+	if diff > 0 {
+		p.wg.Add(diff)
+
+		for i := 0; i < diff; i++ {
+			go p.work(p.ctx, p.queue, p.quit)
+		}
+
+		return
+	}
+
+	quit, ctx := p.quit, p.ctx
+
+	go func() {
+		for i := 0; i < -diff; i++ {
+			select {
+			case quit <- struct{}{}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// Shutdown is a method of WorkerPool that stops accepting new elements and
+// waits for the queued ones to be processed. Submits blocked on a full queue
+// resolve their futures with NotRunning.
+//
+// Parameters:
+//   - ctx: The context that bounds the wait. When it is done, the context
+//     passed to the function is cancelled.
+//
+// Returns:
+//   - error: The context's error if it is done before all the elements are processed.
+func (p *WorkerPool[I, O]) Shutdown(ctx context.Context) error {
+	if p == nil {
+		return nil
+	}
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	// Wake up the Submits blocked on a full queue before taking the lock.
+	p.mu.RLock()
+	stopSubmits := p.stopSubmits
+	p.mu.RUnlock()
+
+	if stopSubmits != nil {
+		stopSubmits()
+	}
+
+	p.mu.Lock()
+
+	if p.queue == nil {
+		p.mu.Unlock()
+		return nil
+	}
+
+	p.submitting.Wait()
+
+	close(p.queue)
+	p.queue = nil
+
+	cancel := p.cancel
+
+	done := make(chan struct{})
+	p.drained = done
+
+	p.state.set(Stopping)
+
+	p.mu.Unlock()
+
+	go func() {
+		p.wg.Wait()
+		p.state.setIf(Stopped, Stopping)
+		close(done)
+	}()
+
+	defer cancel()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// work is a private method of WorkerPool that processes tasks until the
+// queue is closed or the worker is told to quit.
+//
+// Parameters:
+//   - ctx: The context passed to the function.
+//   - queue: The queue of tasks.
+//   - quit: The channel that tells the worker to exit.
+func (p *WorkerPool[I, O]) work(ctx context.Context, queue <-chan poolTask[I, O], quit <-chan struct{}) {
+	defer p.wg.Done()
+
+	for {
+		select {
+		case <-quit:
+			return
+		case task, ok := <-queue:
+			if !ok {
+				return
+			}
+
+			task.future.resolve(p.process(ctx, task.elem))
+		}
+	}
+}
+
+// process is a private method of WorkerPool that runs the function on an
+// element and recovers from its panics.
+//
+// Parameters:
+//   - ctx: The context passed to the function.
+//   - elem: The element to process.
+//
+// Returns:
+//   - O: The output of the function.
+//   - error: The error of the function, or an *ErrPanic if it panicked.
+func (p *WorkerPool[I, O]) process(ctx context.Context, elem I) (out O, err error) {
+	defer func() {
+		r := recover()
+
+		if r != nil {
+			err = NewErrPanic(r)
+		}
+	}()
+
+	return p.do_fn(ctx, elem)
+}
@@ -0,0 +1,180 @@
+package rw_safe
+
+import (
+	"context"
+	"sync"
+)
+
+// SafeCond is a value guarded by a condition variable. Goroutines can wait
+// until an arbitrary predicate on the value holds.
+type SafeCond[T any] struct {
+	// value is the value of the condition variable.
+	value T
+
+	// mu is the mutex to synchronize access to the value.
+	mu sync.Mutex
+
+	// cond is the condition variable that is signaled on every change.
+	cond *sync.Cond
+}
+
+// NewSafeCond creates a new SafeCond.
+//
+// Parameters:
+//   - value: The initial value.
+//
+// Returns:
+//   - *SafeCond[T]: A new SafeCond. Never returns nil.
+func NewSafeCond[T any](value T) *SafeCond[T] {
+	sc := &SafeCond[T]{
+		value: value,
+	}
+
+	sc.cond = sync.NewCond(&sc.mu)
+
+	return sc
+}
+
+// Get gets the current value.
+//
+// Returns:
+//   - T: The current value.
+//
+// If the receiver is nil, then the zero value is returned instead.
+func (sc *SafeCond[T]) Get() T {
+	if sc == nil {
+		return *new(T)
+	}
+
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+
+	return sc.value
+}
+
+// Set sets the value and wakes up all the waiting goroutines.
+//
+// Parameters:
+//   - value: The new value.
+//
+// Returns:
+//   - bool: True if the receiver is not nil. False otherwise.
+func (sc *SafeCond[T]) Set(value T) bool {
+	if sc == nil {
+		return false
+	}
+
+	sc.mu.Lock()
+	sc.value = value
+	sc.mu.Unlock()
+
+	sc.cond.Broadcast()
+
+	return true
+}
+
+// Modify modifies the value and wakes up all the waiting goroutines.
+//
+// Parameters:
+//   - f: The function to modify the value.
+//
+// If 'f' or the receiver are nil, then nothing is done.
+func (sc *SafeCond[T]) Modify(f func(T) T) {
+	if sc == nil || f == nil {
+		return
+	}
+
+	sc.mu.Lock()
+	sc.value = f(sc.value)
+	sc.mu.Unlock()
+
+	sc.cond.Broadcast()
+}
+
+// Broadcast wakes up all the waiting goroutines so that they re-evaluate
+// their predicates. Does nothing if the receiver is nil.
+func (sc *SafeCond[T]) Broadcast() {
+	if sc == nil {
+		return
+	}
+
+	sc.cond.Broadcast()
+}
+
+// Wait blocks until the predicate holds for the current value.
+//
+// Parameters:
+//   - pred: The predicate to wait for.
+//
+// Returns:
+//   - T: The value for which the predicate held.
+//
+// If 'pred' is nil, then the current value is returned immediately. If the
+// receiver is nil, then the zero value is returned instead.
+func (sc *SafeCond[T]) Wait(pred func(T) bool) T {
+	if sc == nil {
+		return *new(T)
+	}
+
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+
+	if pred == nil {
+		return sc.value
+	}
+
+	for !pred(sc.value) {
+		sc.cond.Wait()
+	}
+
+	return sc.value
+}
+
+// WaitCtx is like Wait but it stops waiting when the context is done.
+//
+// Parameters:
+//   - ctx: The context to observe.
+//   - pred: The predicate to wait for.
+//
+// Returns:
+//   - T: The value for which the predicate held.
+//   - error: The context's error if it is done before the predicate held.
+//
+// If the receiver is nil, then the zero value and no error are returned.
+func (sc *SafeCond[T]) WaitCtx(ctx context.Context, pred func(T) bool) (T, error) {
+	if sc == nil {
+		return *new(T), nil
+	}
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	stop := context.AfterFunc(ctx, func() {
+		// Acquiring the lock guarantees the waiter is either parked in
+		// cond.Wait or has not checked the context yet.
+		sc.mu.Lock()
+		defer sc.mu.Unlock()
+
+		sc.cond.Broadcast()
+	})
+	defer stop()
+
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+
+	if pred == nil {
+		return sc.value, nil
+	}
+
+	for !pred(sc.value) {
+		err := ctx.Err()
+		if err != nil {
+			return sc.value, err
+		}
+
+		sc.cond.Wait()
+	}
+
+	return sc.value, nil
+}